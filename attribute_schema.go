@@ -0,0 +1,43 @@
+package growthbook
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/growthbook/growthbook-golang/eval"
+)
+
+// checkAttributes validates client attributes against the schema.
+// Returns error in strict mode, logs warning otherwise.
+func (client *Client) checkAttributes() error {
+	if client.attributeSchema == nil {
+		return nil
+	}
+	err := client.attributeSchema.CheckAttributes(client.evalAttributes())
+	if err == nil || client.strictSchema {
+		return err
+	}
+	client.logger.Warn("Attributes don't match the schema", "error", err)
+	return nil
+}
+
+// checkPayload validates features conditions against the schema.
+// Returns error in strict mode, logs warnings otherwise.
+func (client *Client) checkPayload(features FeatureMap) error {
+	if client.attributeSchema == nil {
+		return nil
+	}
+	var errs []error
+	for _, finding := range eval.Lint(features, client.attributeSchema) {
+		if finding.Code != eval.AttributeTypeLint {
+			continue
+		}
+		if client.strictSchema {
+			errs = append(errs, fmt.Errorf("%w: feature %q rule %d: %s", ErrAttributeType, finding.Feature, finding.Rule, finding.Message))
+			continue
+		}
+		client.logger.Warn("Feature condition doesn't match the attribute schema",
+			"feature", finding.Feature, "rule", finding.Rule, "message", finding.Message)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,65 @@
+package growthbook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const schemaFeaturesJson = `{"adult": {"defaultValue": false, "rules": [{"condition": {"age": {"$gte": "18"}}, "force": true}]}}`
+
+func TestClientAttributeSchemaWarns(t *testing.T) {
+	ctx := context.TODO()
+	var logs bytes.Buffer
+	client, err := NewClient(ctx,
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+		WithAttributeSchema(AttributeSchema{"age": "number"}, false),
+		WithAttributes(Attributes{"age": "20"}),
+		WithJsonFeatures(schemaFeaturesJson),
+	)
+	require.Nil(t, err)
+	require.Contains(t, logs.String(), `"age\" is string, expected number`)
+	require.Contains(t, logs.String(), `feature=adult`)
+	require.Contains(t, logs.String(), `attribute \"age\" of type number is compared with string`)
+	require.True(t, client.EvalFeature(ctx, "adult").On)
+}
+
+func TestClientAttributeSchemaStrict(t *testing.T) {
+	ctx := context.TODO()
+	client, err := NewClient(ctx, WithAttributeSchema(AttributeSchema{"age": "number"}, true))
+	require.Nil(t, err)
+
+	_, err = client.WithAttributes(Attributes{"age": "20"})
+	require.ErrorIs(t, err, ErrAttributeType)
+	child, err := client.WithAttributes(Attributes{"age": 20})
+	require.Nil(t, err)
+
+	err = client.SetJSONFeatures(schemaFeaturesJson)
+	require.ErrorIs(t, err, ErrAttributeType)
+	require.Empty(t, client.Features())
+	require.False(t, child.EvalFeature(ctx, "adult").On)
+
+	_, err = NewClient(ctx,
+		WithAttributeSchema(AttributeSchema{"age": "number"}, true),
+		WithAttributes(Attributes{"age": "20"}),
+	)
+	require.ErrorIs(t, err, ErrAttributeType)
+
+	var features FeatureMap
+	require.Nil(t, json.Unmarshal([]byte(schemaFeaturesJson), &features))
+	err = client.SetFeature("adult", features["adult"])
+	require.ErrorIs(t, err, ErrAttributeType)
+	require.Empty(t, client.Features())
+	require.Nil(t, client.SetFeature("flag", NewFeature(true)))
+	require.Contains(t, client.Features(), "flag")
+
+	_, err = NewClient(ctx,
+		WithAttributeSchema(AttributeSchema{"age": "number"}, true),
+		WithBootstrapFeatures(features),
+	)
+	require.ErrorIs(t, err, ErrAttributeType)
+}
@@ -1,5 +1,67 @@
 package growthbook
 
-// Attributes is an arbitrary JSON object containing user and request
-// attributes.
-type Attributes map[string]any
+import (
+	"errors"
+	"fmt"
+
+	"github.com/growthbook/growthbook-golang/internal/value"
+)
+
+// ErrUnsupportedAttribute is returned in strict mode for attributes that can't be
+// used for evaluation, see [WithStrictAttributes].
+var ErrUnsupportedAttribute = errors.New("Unsupported attribute value")
+
+// WithStrictAttributes makes attribute options and child methods fail with
+// [ErrUnsupportedAttribute] instead of logging a warning when attributes contain
+// values without JSON counterpart, like channels or functions. Set it before attributes.
+func WithStrictAttributes(strict bool) ClientOption {
+	return func(c *Client) error {
+		c.strictAttributes = strict
+		return nil
+	}
+}
+
+// attributeValues converts attributes for evaluation. Structs, pointers and other
+// Go types are converted via JSON encoding. Values JSON can't encode are dropped
+// with a warning, or rejected in strict mode.
+func (c *Client) attributeValues(attributes Attributes) (value.ObjValue, error) {
+	v, unsupported := value.Normalize(attributes)
+	if err := c.checkUnsupported(unsupported); err != nil {
+		return nil, err
+	}
+	return v.(value.ObjValue), nil
+}
+
+// checkUnsupported logs or, in strict mode, returns attribute values dropped by conversion.
+func (c *Client) checkUnsupported(unsupported []value.Unsupported) error {
+	var errs []error
+	for _, u := range unsupported {
+		if c.strictAttributes {
+			errs = append(errs, fmt.Errorf("%w: %q of type %s", ErrUnsupportedAttribute, u.Path, u.Type))
+			continue
+		}
+		c.logger.Warn("Unsupported attribute value is dropped", "attribute", u.Path, "type", u.Type)
+	}
+	return errors.Join(errs...)
+}
+
+// PrecomputeAttributes converts attributes for evaluation once, like [WithAttributes]
+// does, so that child clients created with [Client.WithAttributeValues] reuse them
+// without converting, e.g. for large attribute maps of the same user across requests.
+// Returned values are shared and must not be modified.
+func (c *Client) PrecomputeAttributes(attributes Attributes) (AttributeValues, error) {
+	return c.attributeValues(attributes)
+}
+
+// WithAttributeValues sets attributes converted by [Client.PrecomputeAttributes].
+func WithAttributeValues(attributes AttributeValues) ClientOption {
+	return func(c *Client) error {
+		c.attributes = attributes
+		return nil
+	}
+}
+
+// WithAttributeValues creates child client instance that uses precomputed attributes for evaluation.
+func (c *Client) WithAttributeValues(attributes AttributeValues) (*Client, error) {
+	return c.cloneWith(WithAttributeValues(attributes))
+}
@@ -0,0 +1,86 @@
+package growthbook
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientUnsupportedAttributes(t *testing.T) {
+	type plan struct {
+		Name string `json:"name"`
+	}
+	var logs bytes.Buffer
+	client, err := NewClient(context.TODO(),
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+		WithJsonFeatures(`{"pro": {"defaultValue": false, "rules": [{"condition": {"plan.name": "pro"}, "force": true}]}}`),
+		WithAttributes(Attributes{"id": "1", "plan": &plan{"pro"}, "done": make(chan struct{})}),
+	)
+	require.Nil(t, err)
+	require.True(t, client.EvalFeature(context.TODO(), "pro").On)
+	require.Equal(t, Attributes{"id": "1", "plan": map[string]any{"name": "pro"}, "done": nil}, client.Attributes())
+	require.Contains(t, logs.String(), "attribute=done")
+	require.Contains(t, logs.String(), `type="chan struct {}"`)
+
+	strict, err := client.cloneWith(WithStrictAttributes(true))
+	require.Nil(t, err)
+	_, err = strict.WithAttributeOverrides(Attributes{"cb": func() {}})
+	require.ErrorIs(t, err, ErrUnsupportedAttribute)
+	_, err = strict.WithAttributes(Attributes{"plan": plan{"free"}})
+	require.Nil(t, err)
+}
+
+func TestPrecomputedAttributes(t *testing.T) {
+	client, err := NewClient(context.TODO(),
+		WithJsonFeatures(`{"pro": {"defaultValue": false, "rules": [{"condition": {"plan.name": "pro"}, "force": true}]}}`),
+	)
+	require.Nil(t, err)
+
+	attrs, err := client.PrecomputeAttributes(Attributes{"id": "1", "plan": struct {
+		Name string `json:"name"`
+	}{"pro"}})
+	require.Nil(t, err)
+	child, err := client.WithAttributeValues(attrs)
+	require.Nil(t, err)
+	require.True(t, child.EvalFeature(context.TODO(), "pro").On)
+	require.Equal(t, Attributes{"id": "1", "plan": map[string]any{"name": "pro"}}, child.Attributes())
+
+	// Overrides don't modify shared values
+	_, err = child.WithAttributeOverrides(Attributes{"id": "2"})
+	require.Nil(t, err)
+	require.Equal(t, "1", child.Attributes()["id"])
+
+	strict, err := client.cloneWith(WithStrictAttributes(true))
+	require.Nil(t, err)
+	_, err = strict.PrecomputeAttributes(Attributes{"cb": func() {}})
+	require.ErrorIs(t, err, ErrUnsupportedAttribute)
+}
+
+func BenchmarkChildClientAttributes(b *testing.B) {
+	attrs := Attributes{}
+	for i := 0; i < 100; i++ {
+		attrs["attr"+strconv.Itoa(i)] = map[string]any{"value": i, "tags": []any{"a", "b"}}
+	}
+	client, err := NewClient(context.TODO(), WithFeatures(FeatureMap{"a": {DefaultValue: 1}}))
+	require.Nil(b, err)
+
+	b.Run("attributes", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			child, _ := client.WithAttributes(attrs)
+			child.EvalFeature(context.TODO(), "a")
+		}
+	})
+	b.Run("precomputed", func(b *testing.B) {
+		values, _ := client.PrecomputeAttributes(attrs)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			child, _ := client.WithAttributeValues(values)
+			child.EvalFeature(context.TODO(), "a")
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package growthbook
+
+import "context"
+
+// BootstrapReplacedCallback is executed once, when the data source loads fresh features
+// that replace bootstrap ones.
+type BootstrapReplacedCallback func(ctx context.Context, bootstrap FeatureMap, fresh FeatureMap)
+
+// WithBootstrapFeatures sets features served immediately after client creation,
+// while the data source loads fresh features in the background. Fresh features
+// replace bootstrap ones on the first update from the data source.
+func WithBootstrapFeatures(features FeatureMap) ClientOption {
+	return func(c *Client) error {
+		if err := c.checkPayload(features); err != nil {
+			return err
+		}
+		c.data.features = features.PrecomputeRanges()
+		c.data.bootstrap = true
+		return nil
+	}
+}
+
+// WithBootstrapJsonFeatures sets bootstrap features from JSON string.
+// See [WithBootstrapFeatures].
+func WithBootstrapJsonFeatures(featuresJson string) ClientOption {
+	return func(c *Client) error {
+		var features FeatureMap
+		err := c.unmarshalPayload([]byte(featuresJson), &features)
+		if err != nil {
+			return err
+		}
+		return WithBootstrapFeatures(features)(c)
+	}
+}
+
+// WithBootstrapReplacedCallback sets callback executed when fresh features replace bootstrap ones.
+func WithBootstrapReplacedCallback(cb BootstrapReplacedCallback) ClientOption {
+	return func(c *Client) error {
+		c.bootstrapCallback = cb
+		return nil
+	}
+}
+
+// IsBootstrapped reports whether the client still serves bootstrap features.
+func (client *Client) IsBootstrapped() bool {
+	client.data.mu.RLock()
+	defer client.data.mu.RUnlock()
+	return client.data.bootstrap
+}
@@ -0,0 +1,48 @@
+package growthbook
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientBootstrapFeatures(t *testing.T) {
+	ctx := context.TODO()
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte(`{"features": {"foo": {"defaultValue": "api"}}, "dateUpdated": "2000-05-01T00:00:12Z"}`))
+	}))
+	defer ts.Close()
+
+	replaced := make(chan FeatureMap, 1)
+	logger, _ := testLogger(slog.LevelError, t)
+	client, err := NewClient(ctx,
+		WithLogger(logger),
+		WithHttpClient(ts.Client()),
+		WithApiHost(ts.URL),
+		WithClientKey("somekey"),
+		WithBootstrapJsonFeatures(`{"foo": {"defaultValue": "bootstrap"}}`),
+		WithBootstrapReplacedCallback(func(ctx context.Context, bootstrap FeatureMap, fresh FeatureMap) {
+			require.Equal(t, "bootstrap", bootstrap["foo"].DefaultValue)
+			replaced <- fresh
+		}),
+		WithPollDataSource(time.Hour),
+	)
+	require.Nil(t, err)
+	defer client.Close()
+
+	require.True(t, client.IsBootstrapped())
+	require.Equal(t, "bootstrap", client.EvalFeature(ctx, "foo").Value)
+
+	close(release)
+	require.Nil(t, client.EnsureLoaded(ctx))
+	require.False(t, client.IsBootstrapped())
+	require.Equal(t, "api", client.EvalFeature(ctx, "foo").Value)
+	require.Equal(t, "api", (<-replaced)["foo"].DefaultValue)
+}
@@ -0,0 +1,106 @@
+package growthbook
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+// Compression compresses feature payloads stored in a [SharedCache].
+// Implement it to use other algorithms, e.g. zstd from github.com/klauspost/compress.
+type Compression interface {
+	// Name identifies the algorithm in stored values, e.g. "gzip".
+	Name() string
+	Compress(data []byte) ([]byte, error)
+	Decompress(data []byte) ([]byte, error)
+}
+
+// GzipCompression compresses payloads with gzip at the default level.
+var GzipCompression Compression = gzipCompression{gzip.DefaultCompression}
+
+// NewGzipCompression creates gzip compression with the level, see [gzip.NewWriterLevel].
+func NewGzipCompression(level int) (Compression, error) {
+	if _, err := gzip.NewWriterLevel(io.Discard, level); err != nil {
+		return nil, err
+	}
+	return gzipCompression{level}, nil
+}
+
+type gzipCompression struct {
+	level int
+}
+
+func (c gzipCompression) Name() string {
+	return "gzip"
+}
+
+func (c gzipCompression) Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := gzip.NewWriterLevel(&buf, c.level)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c gzipCompression) Decompress(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// WithSharedCacheCompression compresses payloads written to the shared cache of
+// [WithCoordinatedPollDataSource]. Compressed values are prefixed with the algorithm
+// name, so instances read gzip and uncompressed payloads whatever their own setting is,
+// and payloads of other algorithms if they are configured with it.
+func WithSharedCacheCompression(compression Compression) ClientOption {
+	return func(c *Client) error {
+		c.data.compression = compression
+		return nil
+	}
+}
+
+// compressedPrefix starts compressed cache values, followed by the algorithm name and
+// a newline. JSON payloads can't start with a zero byte.
+const compressedPrefix = "\x00gb:"
+
+// encodeCachePayload compresses payload for the shared cache, if compression is set.
+func (d *data) encodeCachePayload(payload []byte) ([]byte, error) {
+	if d.compression == nil {
+		return payload, nil
+	}
+	compressed, err := d.compression.Compress(payload)
+	if err != nil {
+		return nil, err
+	}
+	header := compressedPrefix + d.compression.Name() + "\n"
+	return append([]byte(header), compressed...), nil
+}
+
+// decodeCachePayload decompresses payload read from the shared cache.
+func (d *data) decodeCachePayload(value []byte) ([]byte, error) {
+	if !bytes.HasPrefix(value, []byte(compressedPrefix)) {
+		return value, nil
+	}
+	name, compressed, ok := bytes.Cut(value[len(compressedPrefix):], []byte("\n"))
+	if !ok {
+		return nil, fmt.Errorf("Invalid compressed payload header")
+	}
+	switch {
+	case d.compression != nil && d.compression.Name() == string(name):
+		return d.compression.Decompress(compressed)
+	case string(name) == GzipCompression.Name():
+		return GzipCompression.Decompress(compressed)
+	}
+	return nil, fmt.Errorf("Unsupported payload compression %q", name)
+}
@@ -0,0 +1,51 @@
+package growthbook
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharedCacheCompression(t *testing.T) {
+	ctx := context.TODO()
+	featuresJSON := []byte(`{"features": {"foo": {"defaultValue": "` + strings.Repeat("api", 1000) + `"}}, "dateUpdated": "2000-05-01T00:00:12Z"}`)
+	ts := startServer(http.StatusOK, featuresJSON)
+	defer ts.http.Close()
+	cache := &memSharedCache{values: map[string][]byte{}}
+
+	newClient := func(opts ...ClientOption) *Client {
+		client, err := NewClient(ctx, append([]ClientOption{
+			WithHttpClient(ts.http.Client()),
+			WithApiHost(ts.http.URL),
+			WithClientKey("somekey"),
+			WithCoordinatedPollDataSource(time.Hour, cache),
+		}, opts...)...)
+		require.Nil(t, err)
+		require.Nil(t, client.EnsureLoaded(ctx))
+		return client
+	}
+
+	leader := newClient(WithSharedCacheCompression(GzipCompression))
+	defer leader.Close()
+	stored := cache.values["gb:"+leader.data.getApiUrl()+":payload"]
+	require.True(t, bytes.HasPrefix(stored, []byte("\x00gb:gzip\n")))
+	require.Less(t, len(stored), len(featuresJSON)/10)
+	require.Equal(t, len(stored), leader.Stats().SharedCacheBytes)
+
+	// Instance without compression setting reads gzip payload
+	follower := newClient()
+	defer follower.Close()
+	require.Equal(t, int32(1), ts.count.Load())
+	require.Equal(t, leader.Features(), follower.Features())
+	require.Equal(t, len(stored), follower.Stats().SharedCacheBytes)
+
+	_, err := NewGzipCompression(42)
+	require.NotNil(t, err)
+	_, err = leader.data.decodeCachePayload([]byte("\x00gb:zstd\n..."))
+	require.ErrorContains(t, err, "zstd")
+}
@@ -0,0 +1,45 @@
+package growthbook
+
+import (
+	"context"
+	"runtime/debug"
+)
+
+// PanicHandler is called with the recovered value when a user callback panics,
+// e.g. to report it to an error tracker. Callback is the name of the callback kind:
+// "experiment", "featureUsage", "enrichExposure", "subscriber", "bootstrapReplaced",
+// "consistency", "job", "stalePayload", "evalTiming", "ruleMetrics", "sseEvent"
+// "featuresChange" or "rebucket".
+type PanicHandler func(ctx context.Context, callback string, recovered any)
+
+// WithPanicHandler sets handler called when a user callback panics. Panics of
+// callbacks are always recovered and logged, so evaluation never takes down a request.
+func WithPanicHandler(handler PanicHandler) ClientOption {
+	return func(c *Client) error {
+		c.panicHandler = handler
+		return nil
+	}
+}
+
+// callback runs user callback f, recovering and reporting its panic.
+func (client *Client) callback(ctx context.Context, name string, f func()) {
+	defer client.recoverCallback(ctx, name)
+	f()
+}
+
+func (client *Client) recoverCallback(ctx context.Context, name string) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	client.logger.Error("Callback panicked", "callback", name, "panic", r, "stack", string(debug.Stack()))
+	if client.panicHandler == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			client.logger.Error("Panic handler panicked", "callback", name, "panic", r)
+		}
+	}()
+	client.panicHandler(ctx, name, r)
+}
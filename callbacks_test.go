@@ -0,0 +1,37 @@
+package growthbook
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCallbackPanics(t *testing.T) {
+	logger, logs := testLogger(slog.LevelError, t)
+	var panics []string
+	client, err := NewClient(context.TODO(),
+		WithLogger(logger),
+		WithAttributes(Attributes{"id": "1"}),
+		WithJsonFeatures(`{"exp": {"defaultValue": 0, "rules": [{"variations": [1, 2]}]}}`),
+		WithExperimentCallback(func(context.Context, *Experiment, *ExperimentResult, any) { panic("experiment") }),
+		WithFeatureUsageCallback(func(context.Context, string, *FeatureResult, any) { panic("usage") }),
+		WithEnrichExposure(func(context.Context, *Experiment, *ExperimentResult) map[string]any { panic("enrich") }),
+		WithPanicHandler(func(_ context.Context, callback string, recovered any) {
+			panics = append(panics, callback+":"+recovered.(string))
+		}),
+	)
+	require.Nil(t, err)
+	client.Subscribe(func(context.Context, *Experiment, *ExperimentResult) { panic("subscriber") })
+
+	res := client.EvalFeature(context.TODO(), "exp")
+	require.True(t, res.InExperiment())
+	require.Equal(t, []string{"featureUsage:usage", "subscriber:subscriber", "enrichExposure:enrich", "experiment:experiment"}, panics)
+	require.Len(t, *logs, 4)
+
+	// Panicking handler is recovered too
+	child, err := client.cloneWith(WithPanicHandler(func(context.Context, string, any) { panic("handler") }))
+	require.Nil(t, err)
+	require.NotPanics(t, func() { child.EvalFeature(context.TODO(), "exp") })
+}
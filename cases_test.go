@@ -4,27 +4,25 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/url"
 	"os"
 	"reflect"
 	"testing"
 
+	"github.com/growthbook/growthbook-golang/hashutil"
 	"github.com/growthbook/growthbook-golang/internal/condition"
 	"github.com/growthbook/growthbook-golang/internal/value"
 	"github.com/stretchr/testify/require"
 )
 
 type cases struct {
-	EvalCondition          JsonTuples[evalConditionCase]          `json:"evalCondition"`
-	ChooseVariation        JsonTuples[chooseVariationCase]        `json:"chooseVariation"`
-	Hash                   JsonTuples[hashCase]                   `json:"hash"`
-	Run                    JsonTuples[runCase]                    `json:"run"`
-	Feature                JsonTuples[featureCase]                `json:"feature"`
-	GetBucketRange         JsonTuples[getBucketRangeCase]         `json:"getBucketRange"`
-	GetQueryStringOverride JsonTuples[getQueryStringOverrideCase] `json:"getQueryStringOverride"`
-	InNamespace            JsonTuples[inNamespaceCase]            `json:"inNamespace"`
-	GetEqualWeights        JsonTuples[getEqualWeightsCase]        `json:"getEqualWeights"`
-	Decrypt                JsonTuples[decryptCase]                `json:"decrypt"`
+	EvalCondition   JsonTuples[evalConditionCase]   `json:"evalCondition"`
+	ChooseVariation JsonTuples[chooseVariationCase] `json:"chooseVariation"`
+	Run             JsonTuples[runCase]             `json:"run"`
+	Feature         JsonTuples[featureCase]         `json:"feature"`
+	InNamespace     JsonTuples[inNamespaceCase]     `json:"inNamespace"`
+	GetEqualWeights JsonTuples[getEqualWeightsCase] `json:"getEqualWeights"`
+	Decrypt         JsonTuples[decryptCase]         `json:"decrypt"`
+	StickyBucket    JsonTuples[stickyBucketCase]    `json:"stickyBucket"`
 }
 
 type evalConditionCase struct {
@@ -42,13 +40,6 @@ type chooseVariationCase struct {
 	Expected int
 }
 
-type hashCase struct {
-	Seed     string
-	Value    string
-	Version  int
-	Expected *float64
-}
-
 type runCase struct {
 	Name         string
 	Env          env
@@ -65,24 +56,6 @@ type featureCase struct {
 	Expected    *FeatureResult
 }
 
-type getBucketRangeCase struct {
-	Name   string
-	Inputs JsonTuple[struct {
-		Num      int
-		Coverage float64
-		Weights  []float64
-	}]
-	Expected []BucketRange
-}
-
-type getQueryStringOverrideCase struct {
-	Name          string
-	Key           string
-	Url           string
-	NumVariations int
-	Expected      *int
-}
-
 type inNamespaceCase struct {
 	Name      string
 	Id        string
@@ -102,6 +75,15 @@ type decryptCase struct {
 	Expected  string
 }
 
+type stickyBucketCase struct {
+	Name         string
+	Env          env
+	Docs         []*StickyBucketAssignmentDoc
+	FeatureName  string
+	Expected     *ExperimentResult
+	ExpectedDocs map[string]*StickyBucketAssignmentDoc
+}
+
 type env struct {
 	Attributes       Attributes            `json:"attributes"`
 	Features         FeatureMap            `json:"features"`
@@ -145,7 +127,25 @@ func (ts JsonTuples[T]) run(name string, t *testing.T) {
 }
 
 func TestCasesJson(t *testing.T) {
-	file := "cases.json"
+	cases := loadCases(t, "cases.json")
+	cases.EvalCondition.run("evalCondition", t)
+	cases.ChooseVariation.run("chooseVariation", t)
+	cases.Run.run("run", t)
+	cases.Feature.run("feature", t)
+	cases.InNamespace.run("inNamespace", t)
+	cases.GetEqualWeights.run("getEqualWeights", t)
+	cases.Decrypt.run("decrypt", t)
+	cases.StickyBucket.run("stickyBucket", t)
+}
+
+// Cases of features not covered by the upstream spec, in the same format
+func TestCasesExtensionsJson(t *testing.T) {
+	cases := loadCases(t, "cases_extensions.json")
+	cases.EvalCondition.run("evalCondition", t)
+	cases.Feature.run("feature", t)
+}
+
+func loadCases(t *testing.T, file string) cases {
 	data, err := os.ReadFile(file)
 	if err != nil {
 		t.Fatal(err)
@@ -154,17 +154,7 @@ func TestCasesJson(t *testing.T) {
 	if err := json.Unmarshal(data, &cases); err != nil {
 		t.Fatal(err)
 	}
-
-	cases.EvalCondition.run("evalCondition", t)
-	cases.ChooseVariation.run("chooseVariation", t)
-	cases.Hash.run("hash", t)
-	cases.Run.run("run", t)
-	cases.Feature.run("feature", t)
-	cases.GetBucketRange.run("getBucketRange", t)
-	cases.GetQueryStringOverride.run("getQueryStringOverride", t)
-	cases.InNamespace.run("inNamespace", t)
-	cases.GetEqualWeights.run("getEqualWeights", t)
-	cases.Decrypt.run("decrypt", t)
+	return cases
 }
 
 func (c evalConditionCase) test(t *testing.T) {
@@ -176,14 +166,7 @@ func (c evalConditionCase) test(t *testing.T) {
 
 func (c chooseVariationCase) test(t *testing.T) {
 	t.Run(c.Name, func(t *testing.T) {
-		require.Equal(t, c.Expected, chooseVariation(c.N, c.Ranges))
-	})
-}
-
-func (c hashCase) test(t *testing.T) {
-	name := fmt.Sprintf(`hash("%s","%s","%d")`, c.Seed, c.Value, c.Version)
-	t.Run(name, func(t *testing.T) {
-		require.Equal(t, c.Expected, hash(c.Seed, c.Value, c.Version))
+		require.Equal(t, c.Expected, hashutil.ChooseVariation(c.N, c.Ranges))
 	})
 }
 
@@ -205,38 +188,18 @@ func (c featureCase) test(t *testing.T) {
 		require.Nil(t, err)
 
 		res := client.EvalFeature(context.TODO(), c.FeatureName)
-		require.Equal(t, c.Expected, res)
-	})
-}
-
-func (c getBucketRangeCase) test(t *testing.T) {
-	t.Run(c.Name, func(t *testing.T) {
-		client, err := NewClient(context.TODO())
-		require.Nil(t, err)
-
-		i := c.Inputs.val
-		res := client.getBucketRanges(i.Num, i.Coverage, i.Weights)
-		require.Equal(t, c.Expected, roundRanges(res))
-	})
-}
-
-func (c getQueryStringOverrideCase) test(t *testing.T) {
-	t.Run(c.Name, func(t *testing.T) {
-		url, err := url.Parse(c.Url)
-		require.Nil(t, err)
-		res, ok := getQueryStringOverride(c.Key, url, c.NumVariations)
-		if c.Expected == nil {
-			require.False(t, ok)
-		} else {
-			require.True(t, ok)
-			require.Equal(t, *c.Expected, res)
+		// Spec cases predate result reasons and passthrough assignments
+		clearReasons(res)
+		if c.Expected.Passthrough == nil {
+			res.Passthrough = nil
 		}
+		require.Equal(t, c.Expected, res)
 	})
 }
 
 func (c inNamespaceCase) test(t *testing.T) {
 	t.Run(c.Name, func(t *testing.T) {
-		res := c.Namespace.inNamespace(c.Id)
+		res := hashutil.InNamespace(c.Id, c.Namespace)
 		require.Equal(t, c.Expected, res)
 	})
 }
@@ -244,7 +207,7 @@ func (c inNamespaceCase) test(t *testing.T) {
 func (c getEqualWeightsCase) test(t *testing.T) {
 	name := fmt.Sprintf(`("%v")`, c.NumVariations)
 	t.Run(name, func(t *testing.T) {
-		res := getEqualWeights(c.NumVariations)
+		res := hashutil.GetEqualWeights(c.NumVariations)
 		require.Equal(t, roundArr(c.Expected), roundArr(res))
 	})
 }
@@ -262,6 +225,29 @@ func (c decryptCase) test(t *testing.T) {
 	})
 }
 
+func (c stickyBucketCase) test(t *testing.T) {
+	t.Run(c.Name, func(t *testing.T) {
+		ctx := context.TODO()
+		service := NewMemoryStickyBucketService()
+		for _, doc := range c.Docs {
+			require.Nil(t, service.SaveAssignments(ctx, doc))
+		}
+		client, err := c.Env.client()
+		require.Nil(t, err)
+		client, err = client.WithStickyBucketService(service)
+		require.Nil(t, err)
+
+		res := client.EvalFeature(ctx, c.FeatureName)
+		clearReasons(res)
+		require.Equal(t, c.Expected, res.ExperimentResult)
+		for key, expected := range c.ExpectedDocs {
+			doc, err := service.GetAssignments(ctx, expected.AttributeName, expected.AttributeValue)
+			require.Nil(t, err)
+			require.Equal(t, expected, doc, key)
+		}
+	})
+}
+
 func (e *env) client() (*Client, error) {
 	client, err := NewClient(context.TODO(),
 		WithAttributes(e.Attributes),
@@ -296,3 +282,12 @@ func (e *env) client() (*Client, error) {
 
 	return client, nil
 }
+
+func clearReasons(res *FeatureResult) {
+	if res.ExperimentResult != nil {
+		res.ExperimentResult.Reason = ""
+	}
+	for _, p := range res.Passthrough {
+		p.ExperimentResult.Reason = ""
+	}
+}
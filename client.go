@@ -2,11 +2,13 @@ package growthbook
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"log/slog"
+	"maps"
 	"net/url"
+	"time"
 
+	"github.com/growthbook/growthbook-golang/eval"
 	"github.com/growthbook/growthbook-golang/internal/value"
 )
 
@@ -18,21 +20,58 @@ var (
 
 // Client is a GrowthBook SDK client.
 type Client struct {
-	data                 *data
-	enabled              bool
-	attributes           value.ObjValue
-	url                  *url.URL
-	forcedVariations     ForcedVariationsMap
-	qaMode               bool
-	experimentCallback   ExperimentCallback
-	featureUsageCallback FeatureUsageCallback
-	logger               *slog.Logger
-	extraData            any
+	data                  *data
+	enabled               bool
+	attributes            value.ObjValue
+	globalAttributes      value.ObjValue
+	url                   *url.URL
+	forcedVariations      ForcedVariationsMap
+	qaMode                bool
+	devMode               bool
+	evalTimeout           time.Duration
+	experimentCallback    ExperimentCallback
+	featureUsageCallback  FeatureUsageCallback
+	logger                *slog.Logger
+	extraData             any
+	experimentRecordStore ExperimentRecordStore
+	consistencyChecker    *consistencyChecker
+	featureDefaults       map[string]any
+	subscriptions         *subscriptions
+	notified              *notifiedAssignments
+	usage                 *featureUsage
+	results               *savedResults
+	devToolsLogs          *devToolsLogs
+	stickyBucketService   StickyBucketService
+	featureFallbacks      []featureFallback
+	bootstrapCallback     BootstrapReplacedCallback
+	webhook               *changeWebhook
+	hashSeedOverride      func(expKey string) string
+	attributeSchema       AttributeSchema
+	strictSchema          bool
+	enrichExposure        []EnrichExposureFunc
+	environments          map[string]Environment
+	environment           string
+	strictAttributes      bool
+	includeMissingFilter  bool
+	panicHandler          PanicHandler
+	baseLogger            *slog.Logger
+	logFields             []any
+	exposureSampling      ExposureSampling
+	groups                map[string]bool
+	experimentOverrides   ExperimentOverrides
+	redactor              *redactor
+	stalePayloadCallback  StalePayloadCallback
+	experimentMemo        *experimentMemo
+	publisher             *payloadPublisher
+	ruleMetrics           *ruleMetrics
+	concurrency           *concurrencyChecks
+	changeCallback        FeaturesChangeCallback
+	rebucketCallback      RebucketCallback
+	scopes                *scopePool
+	pooled                bool
+	sseHandlers           map[string]SseEventHandler
 }
 
-// ForcedVariationsMap is a map that forces an Experiment to always assign a specific variation. Useful for QA.
-type ForcedVariationsMap map[string]int
-
 // ExperimentCallback function that is executed every time a user is included in an Experiment.
 type ExperimentCallback func(context.Context, *Experiment, *ExperimentResult, any)
 
@@ -54,9 +93,22 @@ func NewClient(ctx context.Context, opts ...ClientOption) (*Client, error) {
 			return nil, err
 		}
 	}
+	if err := client.checkAttributes(); err != nil {
+		return nil, err
+	}
+	if err := client.applyEnvironment(); err != nil {
+		return nil, err
+	}
+	client.scopeLogger()
+	// Features of WithFeatures are shared with the caller
+	client.shareFeatures("NewClient", client.data.getFeatures())
+
+	if client.data.dsFactory != nil {
+		client.launchDataSource(ctx)
+	}
 
-	if client.data.dataSource != nil {
-		go client.startDataSource(ctx)
+	if client.consistencyChecker != nil {
+		client.data.spawn(func() { client.consistencyChecker.run(ctx, client.logger) })
 	}
 
 	return client, nil
@@ -64,89 +116,276 @@ func NewClient(ctx context.Context, opts ...ClientOption) (*Client, error) {
 
 // Close client's background goroutines
 func (client *Client) Close() error {
-	ds := client.data.dataSource
-	if ds == nil || !client.data.getDsStarted() {
+	if client.consistencyChecker != nil {
+		client.consistencyChecker.close()
+	}
+	if client.webhook != nil {
+		client.webhook.cancel()
+	}
+	var ds DataSource
+	var started bool
+	client.data.withLock(func(d *data) error {
+		// Data source that is still starting is closed once started
+		d.closed = true
+		ds, started = d.dataSource, d.dsStarted
+		return nil
+	})
+	if ds == nil || !started {
 		return nil
 	}
 	return ds.Close()
 }
 
+// Reconfigure swaps API connection settings at runtime, e.g. to rotate client key.
+// Only options of data shared with child clients take effect: API host, client key,
+// decryption key or provider, HTTP client and data source. Running data source is
+// closed and a new one is started with the new settings. Features loaded with old
+// settings keep being served until the new data source loads fresh ones. Payload of the
+// old client key is dropped from the default in-memory lightweight cache, while shared
+// caches keep it until it expires, as other instances may still use the old key.
+func (client *Client) Reconfigure(ctx context.Context, opts ...ClientOption) error {
+	d := client.data
+	d.mu.RLock()
+	tmp := client.clone()
+	tmp.data = &data{
+		apiHost:    d.apiHost,
+		ssePath:    d.ssePath,
+		clientKey:  d.clientKey,
+		decryptor:  d.decryptor,
+		httpClient: d.httpClient,
+		dsFactory:  d.dsFactory,
+	}
+	if l := d.light.Load(); l != nil {
+		tmp.data.light.Store(&lightweight{ttl: l.ttl, cache: l.cache})
+	}
+	d.mu.RUnlock()
+
+	for _, opt := range opts {
+		err := opt(tmp)
+		if err != nil {
+			return err
+		}
+	}
+
+	light := tmp.data.light.Load()
+	if tmp.data.dsFactory != nil {
+		// Data source option replaces lightweight mode
+		light = nil
+	}
+
+	var old DataSource
+	var oldStarted bool
+	var oldUrl string
+	var oldLight *lightweight
+	d.withLock(func(d *data) error {
+		old, oldStarted = d.dataSource, d.dsStarted
+		oldUrl, oldLight = d.apiUrl(), d.light.Load()
+		d.apiHost = tmp.data.apiHost
+		d.ssePath = tmp.data.ssePath
+		d.sseHost = ""
+		d.clientKey = tmp.data.clientKey
+		d.decryptor = tmp.data.decryptor
+		d.httpClient = tmp.data.httpClient
+		d.dsFactory = tmp.data.dsFactory
+		d.light.Store(light)
+		if d.dsFactory == nil {
+			d.dataSource = nil
+			d.dsStarted = false
+		}
+		// Payload from the new source can be older than the current one.
+		d.dateUpdated = time.Time{}
+		return nil
+	})
+
+	if old != nil && oldStarted {
+		if err := old.Close(); err != nil {
+			client.logger.Warn("Error closing data source", "error", err)
+		}
+	}
+
+	if oldLight != nil && oldUrl != d.getApiUrl() {
+		if cache, ok := oldLight.cache.(*memoryCache); ok {
+			cache.delete(lightweightKey(oldUrl))
+		}
+	}
+
+	if tmp.data.dsFactory != nil {
+		client.launchDataSource(ctx)
+	}
+	return nil
+}
+
 func defaultClient() *Client {
 	return &Client{
-		data:    newData(),
-		enabled: true,
-		qaMode:  false,
-		logger:  slog.Default(),
+		data:          newData(),
+		enabled:       true,
+		qaMode:        false,
+		logger:        slog.Default(),
+		baseLogger:    slog.Default(),
+		subscriptions: newSubscriptions(),
+		notified:      newNotifiedAssignments(),
+		usage:         newFeatureUsage(),
+		results:       newSavedResults(),
+		devToolsLogs:  newDevToolsLogs(),
+		scopes:        newScopePool(),
 	}
 }
 
-// SetFeatures updates shared client features.
+// SetFeatures updates shared client features. Features are used without copying and
+// must not be modified afterwards. The map is copied only if experiment rules need
+// bucket ranges precomputed, see [FeatureMap.PrecomputeRanges].
 func (client *Client) SetFeatures(features FeatureMap) error {
+	defer client.beginUpdate("SetFeatures")()
+	if err := client.setFeatures(features); err != nil {
+		return err
+	}
+	client.shareFeatures("SetFeatures", client.data.getFeatures())
+	return nil
+}
+
+func (client *Client) setFeatures(features FeatureMap) error {
+	if err := client.checkPayload(features); err != nil {
+		return err
+	}
+	features = features.PrecomputeRanges()
 	client.data.withLock(func(d *data) error {
 		d.features = features
+		d.notifyUpdate()
 		return nil
 	})
+	client.markRefreshed()
 	return nil
 }
 
+// SetFeature adds or replaces a single shared feature. Features map is copied,
+// so snapshots and maps returned by [Client.FeaturesUnsafe] are not modified.
+// Intended for tests and admin tooling. Like [Client.SetFeatures], it returns
+// [ErrAttributeType] if the feature doesn't match the strict attribute schema.
+func (client *Client) SetFeature(key string, feature *Feature) error {
+	update := FeatureMap{key: feature}
+	if err := client.checkPayload(update); err != nil {
+		return err
+	}
+	feature = update.PrecomputeRanges()[key]
+	return client.data.withLock(func(d *data) error {
+		features := maps.Clone(d.features)
+		if features == nil {
+			features = FeatureMap{}
+		}
+		features[key] = feature
+		d.features = features
+		d.notifyUpdate()
+		return nil
+	})
+}
+
+// RemoveFeature removes a single shared feature. See [Client.SetFeature].
+func (client *Client) RemoveFeature(key string) {
+	client.data.withLock(func(d *data) error {
+		if _, ok := d.features[key]; !ok {
+			return nil
+		}
+		features := maps.Clone(d.features)
+		delete(features, key)
+		d.features = features
+		d.notifyUpdate()
+		return nil
+	})
+}
+
 // SetJSONFeatures updates shared features from JSON
 func (client *Client) SetJSONFeatures(featuresJSON string) error {
 	var features FeatureMap
-	err := json.Unmarshal([]byte(featuresJSON), &features)
+	err := client.unmarshalPayload([]byte(featuresJSON), &features)
 	if err != nil {
 		return err
 	}
-	return client.SetFeatures(features)
+	defer client.beginUpdate("SetJSONFeatures")()
+	return client.setFeatures(features)
 }
 
 // SetEncryptedJSONFeatures updates shared features from encrypted JSON.
-// Uses client's decryption key.
+// Uses client's decryption provider.
 func (client *Client) SetEncryptedJSONFeatures(encryptedJSON string) error {
-	if client.data.decryptionKey == "" {
-		return ErrNoDecryptionKey
-	}
-	featuresJSON, err := decrypt(encryptedJSON, client.data.decryptionKey)
+	featuresJSON, err := client.data.decrypt(context.Background(), encryptedJSON)
 	if err != nil {
 		return err
 	}
 	return client.SetJSONFeatures(featuresJSON)
 }
 
-// UpdateFromApiResponse updates shared data from Growthbook API response
+// UpdateFromApiResponse updates shared data from Growthbook API response.
+// Responses older than the current data are handled according to [WithRollbackPolicy].
 func (client *Client) UpdateFromApiResponse(resp *FeatureApiResponse) error {
-	dataUpdated := client.data.getDateUpdated()
-	apiUpdated := resp.DateUpdated
-	if apiUpdated.Before(dataUpdated) {
-		client.logger.Warn("Api response is older then current data, refuse to update",
-			"dataUpdated", dataUpdated, "apiUdpated", apiUpdated)
-		return nil
-	}
+	defer client.beginUpdate("UpdateFromApiResponse")()
+	return client.updateFromApiResponse(context.Background(), resp)
+}
+
+func (client *Client) updateFromApiResponse(ctx context.Context, resp *FeatureApiResponse) error {
+	return client.applyApiResponse(ctx, resp, false)
+}
+
+func (client *Client) applyApiResponse(ctx context.Context, resp *FeatureApiResponse, force bool) error {
 	var features FeatureMap
 	var err error
 	if resp.EncryptedFeatures != "" {
-		features, err = client.DecryptFeatures(resp.EncryptedFeatures)
+		features, err = client.decryptFeatures(ctx, resp.EncryptedFeatures)
 		if err != nil {
 			return err
 		}
 	} else {
 		features = resp.Features
 	}
+	if !force && resp.DateUpdated.Before(client.data.getDateUpdated()) && !client.acceptRollback(resp, features) {
+		// Current data is newer, so still fresh
+		client.markRefreshed()
+		return nil
+	}
+	if err := client.checkPayload(features); err != nil {
+		return err
+	}
+	features = features.PrecomputeRanges()
+	var old FeatureMap
+	var replaced bool
 	client.data.withLock(func(d *data) error {
+		old = d.features
+		if d.bootstrap {
+			replaced = true
+			d.bootstrap = false
+		}
 		d.features = features
 		d.savedGroups = resp.SavedGroups
 		d.dateUpdated = resp.DateUpdated
+		d.payloadSize = len(resp.body)
+		d.notifyUpdate()
 		return nil
 	})
+	client.markRefreshed()
+	if replaced {
+		client.logger.Info("Bootstrap features replaced with fresh data", "dateUpdated", resp.DateUpdated)
+		if client.bootstrapCallback != nil {
+			client.callback(ctx, "bootstrapReplaced", func() { client.bootstrapCallback(ctx, old, features) })
+		}
+	}
+	client.notifyChange(ctx, old, features, resp.DateUpdated)
+	client.checkRebucketing(ctx, old, features)
+	if client.publisher != nil {
+		client.publisher.publish(client, resp)
+	}
 	return nil
 }
 
 func (client *Client) DecryptFeatures(encrypted string) (FeatureMap, error) {
+	return client.decryptFeatures(context.Background(), encrypted)
+}
+
+func (client *Client) decryptFeatures(ctx context.Context, encrypted string) (FeatureMap, error) {
 	var features FeatureMap
-	featuresJSON, err := client.data.decrypt(encrypted)
+	featuresJSON, err := client.data.decrypt(ctx, encrypted)
 	if err != nil {
 		return nil, err
 	}
-	err = json.Unmarshal([]byte(featuresJSON), &features)
+	err = client.unmarshalPayload([]byte(featuresJSON), &features)
 	if err != nil {
 		return nil, err
 	}
@@ -154,53 +393,184 @@ func (client *Client) DecryptFeatures(encrypted string) (FeatureMap, error) {
 }
 
 func (client *Client) UpdateFromApiResponseJSON(respJSON string) error {
+	return client.updateFromApiResponseJSON(context.Background(), respJSON)
+}
+
+func (client *Client) updateFromApiResponseJSON(ctx context.Context, respJSON string) error {
 	var resp FeatureApiResponse
-	err := json.Unmarshal([]byte(respJSON), &resp)
+	resp.body = []byte(respJSON)
+	err := client.unmarshalPayload(resp.body, &resp)
 	if err != nil {
 		return err
 	}
-	return client.UpdateFromApiResponse(&resp)
+	return client.updateFromApiResponse(ctx, &resp)
 }
 
 // EvalFeature evaluates feature based on attributes and features map
 func (client *Client) EvalFeature(ctx context.Context, key string) *FeatureResult {
-	e := client.evaluator()
-	res := e.evalFeature(key)
+	if t := EvalTimingFromContext(ctx); t != nil {
+		defer t.add(time.Now())
+	}
+	opts := client.evalOptions(ctx)
+	client.checkFeatures(ctx, opts.Features)
+	opts.Deadline = client.evalDeadline(ctx)
+	opts.Logger = client.evalLogger(ctx, "feature", key)
+	res := eval.New(ctx, opts).EvalFeature(key)
+	client.usage.record(key, time.Now())
+	client.recordRule(ctx, key, res)
 	if client.featureUsageCallback != nil {
-		client.featureUsageCallback(ctx, key, res, client.extraData)
+		client.callback(ctx, "featureUsage", func() { client.featureUsageCallback(ctx, key, client.redactor.featureResult(res), client.extraData) })
+	}
+	client.logFeatureForDevTools(key, res)
+	// Users in passthrough variations, e.g. holdout groups, are exposed to those experiments too
+	for _, p := range res.Passthrough {
+		client.experimentRun(ctx, p.Experiment, p.ExperimentResult)
+		client.trackExperiment(ctx, p.Experiment, p.ExperimentResult)
 	}
-	if client.experimentCallback != nil && res.InExperiment() {
-		client.experimentCallback(ctx, res.Experiment, res.ExperimentResult, client.extraData)
+	client.experimentRun(ctx, res.Experiment, res.ExperimentResult)
+	if client.consistencyChecker != nil {
+		client.consistencyChecker.sample(ctx, client, key, res)
+	}
+	if res.InExperiment() {
+		client.trackExperiment(ctx, res.Experiment, res.ExperimentResult)
 	}
 	return res
 }
 
 func (client *Client) RunExperiment(ctx context.Context, exp *Experiment) *ExperimentResult {
-	e := client.evaluator()
-	res := e.runExperiment(exp, "")
-	if client.experimentCallback != nil && res.InExperiment {
-		client.experimentCallback(ctx, exp, res, client.extraData)
+	if t := EvalTimingFromContext(ctx); t != nil {
+		defer t.add(time.Now())
+	}
+	res, version, memo := client.memoizedExperiment(ctx, exp)
+	if res == nil {
+		opts := client.evalOptions(ctx)
+		opts.Logger = client.evalLogger(ctx, "experiment", exp.Key)
+		res = eval.New(ctx, opts).RunExperiment(exp)
+		if memo {
+			client.experimentMemo.put(exp, version, res)
+		}
+	}
+	client.experimentRun(ctx, exp, res)
+	if res.InExperiment {
+		client.trackExperiment(ctx, exp, res)
 	}
 	return res
 }
 
+// Features returns deep copy of the current features, so callers can't modify shared data.
 func (client *Client) Features() FeatureMap {
-	return client.data.getFeatures()
+	return client.data.getFeatures().Clone()
+}
+
+// FeaturesUnsafe returns current features without copying. Returned map is shared
+// by all clients and must not be modified. Use it only when [Client.Features] is too slow.
+func (client *Client) FeaturesUnsafe() FeatureMap {
+	features := client.data.getFeatures()
+	client.shareFeatures("FeaturesUnsafe", features)
+	return features
+}
+
+// RequiredAttributes returns sorted attribute paths the feature depends on in the current payload,
+// see [FeatureMap.RequiredAttributes]. Useful to check at startup that the service provides them.
+func (client *Client) RequiredAttributes(featureKey string) []string {
+	return client.data.getFeatures().RequiredAttributes(featureKey)
+}
+
+// Attributes returns copy of the attributes used for evaluation, including
+// global ones, converted to plain Go values.
+func (client *Client) Attributes() Attributes {
+	return value.Native(client.evalAttributes()).(map[string]any)
+}
+
+// evalAttributes merges client attributes over global ones.
+func (client *Client) evalAttributes() value.ObjValue {
+	if len(client.globalAttributes) == 0 {
+		return client.attributes
+	}
+	res := maps.Clone(client.globalAttributes)
+	maps.Copy(res, client.attributes)
+	return res
 }
 
 // Internals
-func (client *Client) evaluator() *evaluator {
-	client.data.mu.RLock()
-	e := evaluator{
-		features:    client.data.features,
-		savedGroups: client.data.savedGroups,
-		client:      client,
+func (client *Client) experimentRun(ctx context.Context, exp *Experiment, res *ExperimentResult) {
+	if exp == nil || res == nil {
+		return
+	}
+	client.results.save(exp, res)
+	redacted := client.redactor.experimentResult(res)
+	client.logExperimentForDevTools(exp, redacted)
+	client.recordExperiment(ctx, exp, redacted)
+	for _, cb := range client.subscriptions.changed(client.notified, exp, res) {
+		client.callback(ctx, "subscriber", func() { cb(ctx, exp, redacted) })
 	}
+}
+
+// evalOptions returns options to evaluate features with the client settings and the current payload.
+func (client *Client) evalOptions(ctx context.Context) *eval.Options {
+	opts := eval.Options{
+		Attributes:          client.evalAttributes(),
+		Disabled:            !client.enabled,
+		QaMode:              client.qaMode,
+		DevMode:             client.devMode,
+		Url:                 client.url,
+		ForcedVariations:    client.forcedVariations,
+		FeatureDefaults:     client.featureDefaults,
+		StickyBucketService: client.stickyBucketService,
+		HashSeedOverride:    client.hashSeedOverride,
+		Logger:              client.logger,
+	}
+	opts.IncludeMissingFilterAttribute = client.includeMissingFilter
+	opts.Groups, opts.Overrides = client.groups, client.experimentOverrides
+	opts.RedactAttribute = client.redactAttribute()
+	if len(client.featureFallbacks) > 0 {
+		opts.Fallback = client.fallbackPayload
+	}
+	if s, ok := client.pinnedSnapshot(ctx); ok {
+		opts.Features, opts.SavedGroups = s.features, s.savedGroups
+		opts.ForcedVariations = client.evalForcedVariations(client.runtimeForcedVariations())
+		client.applyStalePolicy(ctx, &opts)
+		return &opts
+	}
+	if l := client.data.light.Load(); l != nil {
+		if err := client.refreshLightweight(ctx, l); err != nil {
+			client.logger.Error("Error loading features", "error", err)
+		}
+	}
+	client.applyStalePolicy(ctx, &opts)
+	client.data.mu.RLock()
+	opts.Features, opts.SavedGroups = client.data.features, client.data.savedGroups
+	forced := client.data.forced
 	client.data.mu.RUnlock()
-	return &e
+	opts.ForcedVariations = client.evalForcedVariations(forced)
+	return &opts
+}
+
+// evalDeadline returns time after which feature evaluation falls back to the default value.
+// Context deadline is respected only if evaluation timeout is set.
+func (client *Client) evalDeadline(ctx context.Context) time.Time {
+	if client.evalTimeout <= 0 {
+		return time.Time{}
+	}
+	deadline := time.Now().Add(client.evalTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		return ctxDeadline
+	}
+	return deadline
 }
 
 func (client *Client) clone() *Client {
 	c := *client
+	if c.pooled {
+		// Attributes of a scope are reused after release
+		c.pooled = false
+		c.attributes = maps.Clone(c.attributes)
+	}
+	c.results = newSavedResults()
+	c.notified = newNotifiedAssignments()
+	c.devToolsLogs = newDevToolsLogs()
+	if c.experimentMemo != nil {
+		c.experimentMemo = newExperimentMemo(c.experimentMemo.size)
+	}
 	return &c
 }
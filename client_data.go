@@ -1,34 +1,69 @@
 package growthbook
 
 import (
+	"context"
 	"net/http"
+	"net/url"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/growthbook/growthbook-golang/internal/condition"
 )
 
 type data struct {
-	mu            sync.RWMutex
-	features      FeatureMap
-	savedGroups   condition.SavedGroups
-	dateUpdated   time.Time
-	apiHost       string
-	clientKey     string
-	decryptionKey string
-	httpClient    *http.Client
-	dataSource    DataSource
-	dsStarted     bool
-	dsStartWait   chan struct{}
-	dsStartErr    error
+	mu          sync.RWMutex
+	features    FeatureMap
+	savedGroups condition.SavedGroups
+	dateUpdated time.Time
+	payloadSize int
+	bootstrap   bool
+	useNumber   bool
+	strictJson  bool
+	rollback    RollbackPolicy
+	apiHost     string
+	ssePath     string
+	sseHost     string
+	clientKey   string
+	instanceId  string
+	decryptor   DecryptionProvider
+	httpClient  *http.Client
+	dsFactory   dataSourceFactory
+	dataSource  DataSource
+	dsStarted   bool
+	dsStartWait chan struct{}
+	dsWaitOwned bool
+	dsStartErr  error
+	closed      bool
+	updateCh    chan struct{}
+	goroutines  atomic.Int32
+	dropped     atomic.Int64
+	compression Compression
+	cacheBytes  atomic.Int64
+	apiFlights  flightGroup[*FeatureApiResponse]
+	light       atomic.Pointer[lightweight]
+	fetchWait   time.Duration
+	retry       RetryPolicy
+	forced      ForcedVariationsMap
+	maxAge      time.Duration
+	agePolicy   StalePayloadPolicy
+	refreshed   atomic.Int64
+	stale       atomic.Bool
+	version     atomic.Uint64
 }
 
 func newData() *data {
-	return &data{
+	d := &data{
 		dsStartWait: make(chan struct{}),
+		updateCh:    make(chan struct{}),
 		apiHost:     defaultApiHost,
+		instanceId:  newInstanceId(),
 		httpClient:  http.DefaultClient,
 	}
+	// Payload loaded by neither API nor application ages from the client creation
+	d.refreshed.Store(time.Now().UnixNano())
+	return d
 }
 
 func (d *data) getDateUpdated() time.Time {
@@ -37,6 +72,12 @@ func (d *data) getDateUpdated() time.Time {
 	return d.dateUpdated
 }
 
+func (d *data) getHttpClient() *http.Client {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.httpClient
+}
+
 func (d *data) getFeatures() FeatureMap {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
@@ -46,13 +87,31 @@ func (d *data) getFeatures() FeatureMap {
 func (d *data) getApiUrl() string {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
+	return d.apiUrl()
+}
+
+func (d *data) apiUrl() string {
 	return d.apiHost + "/api/features/" + d.clientKey
 }
 
+func (d *data) getRemoteEvalUrl() string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.apiHost + "/api/eval/" + d.clientKey
+}
+
 func (d *data) getSseUrl() string {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
-	return d.apiHost + "/sub/" + d.clientKey
+	host := d.apiHost
+	if d.sseHost != "" {
+		host = d.sseHost
+	}
+	path := d.ssePath
+	if path == "" {
+		path = DefaultSseStreamPath
+	}
+	return host + strings.ReplaceAll(path, "{clientKey}", url.PathEscape(d.clientKey))
 }
 
 func (d *data) getDsStartErr() error {
@@ -61,12 +120,42 @@ func (d *data) getDsStartErr() error {
 	return d.dsStartErr
 }
 
+func (d *data) getDsStartWait() chan struct{} {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.dsStartWait
+}
+
 func (d *data) getDsStarted() bool {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
 	return d.dsStarted
 }
 
+// updated returns channel that is closed on the next data update.
+func (d *data) updated() <-chan struct{} {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.updateCh
+}
+
+// notifyUpdate bumps data version and wakes up everyone waiting for data update.
+// Must be called with lock held.
+func (d *data) notifyUpdate() {
+	d.version.Add(1)
+	close(d.updateCh)
+	d.updateCh = make(chan struct{})
+}
+
+// spawn runs f in a background goroutine counted in [ClientStats].
+func (d *data) spawn(f func()) {
+	d.goroutines.Add(1)
+	go func() {
+		defer d.goroutines.Add(-1)
+		f()
+	}()
+}
+
 type dataUpdate func(*data) error
 
 func (d *data) withLock(f dataUpdate) error {
@@ -75,12 +164,24 @@ func (d *data) withLock(f dataUpdate) error {
 	return f(d)
 }
 
-func (d *data) decrypt(encrypted string) (string, error) {
+func (d *data) decrypt(ctx context.Context, encrypted string) (string, error) {
 	d.mu.RLock()
-	key := d.decryptionKey
+	decryptor := d.decryptor
 	d.mu.RUnlock()
-	if key == "" {
+	if decryptor == nil {
 		return "", ErrNoDecryptionKey
 	}
-	return decrypt(encrypted, key)
+	return decryptor.Decrypt(ctx, encrypted)
+}
+
+// detectSseHost makes the stream read from the host that served the features API
+// after redirects, e.g. a proxy in front of a self-hosted GrowthBook.
+func (d *data) detectSseHost(reqUrl *url.URL, respUrl *url.URL) {
+	host := ""
+	if respUrl != nil && (respUrl.Scheme != reqUrl.Scheme || respUrl.Host != reqUrl.Host) {
+		host = respUrl.Scheme + "://" + respUrl.Host
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.sseHost = host
 }
@@ -1,10 +1,13 @@
 package growthbook
 
 import (
+	"crypto/tls"
+	"errors"
 	"log/slog"
 	"maps"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/growthbook/growthbook-golang/internal/condition"
 	"github.com/growthbook/growthbook-golang/internal/value"
@@ -13,6 +16,8 @@ import (
 type ClientOption func(*Client) error
 
 // WithEnabled sets enabled switch to globally disable all experiments. Default true.
+// Disabled client skips experiment rules of features and doesn't track exposures,
+// while force and rollout rules still apply. Useful for load testing.
 func WithEnabled(enabled bool) ClientOption {
 	return func(c *Client) error {
 		c.enabled = enabled
@@ -39,15 +44,87 @@ func WithClientKey(clientKey string) ClientOption {
 // WithDecryptionKey sets key used to decrypt encrypted features from the API.
 func WithDecryptionKey(decryptionKey string) ClientOption {
 	return func(c *Client) error {
-		c.data.decryptionKey = decryptionKey
+		if decryptionKey == "" {
+			c.data.decryptor = nil
+			return nil
+		}
+		c.data.decryptor = NewAesDecryptionProvider(decryptionKey)
+		return nil
+	}
+}
+
+// WithDecryptionProvider sets provider used to decrypt encrypted features from the API.
+// Use it to keep decryption keys in an external key management service.
+func WithDecryptionProvider(provider DecryptionProvider) ClientOption {
+	return func(c *Client) error {
+		c.data.decryptor = provider
 		return nil
 	}
 }
 
 // WithAttributes sets attributes that used to assign variations.
+// Structs, pointers and other Go values are converted via JSON encoding,
+// see [WithStrictAttributes] for values JSON can't encode.
 func WithAttributes(attributes Attributes) ClientOption {
 	return func(c *Client) error {
-		c.attributes = value.Obj(attributes)
+		attrs, err := c.attributeValues(attributes)
+		if err != nil {
+			return err
+		}
+		c.attributes = attrs
+		return nil
+	}
+}
+
+// WithGlobalAttributes sets attributes used for every evaluation by the client and its child
+// clients, e.g. environment, region or app version. Client attributes take precedence.
+func WithGlobalAttributes(attributes Attributes) ClientOption {
+	return func(c *Client) error {
+		attrs, err := c.attributeValues(attributes)
+		if err != nil {
+			return err
+		}
+		c.globalAttributes = attrs
+		return nil
+	}
+}
+
+// WithAttributeOverrides updates top-level attributes set by the earlier options.
+func WithAttributeOverrides(attributes Attributes) ClientOption {
+	return func(c *Client) error {
+		overrides, err := c.attributeValues(attributes)
+		if err != nil {
+			return err
+		}
+		newAttrs := maps.Clone(c.attributes)
+		if newAttrs == nil {
+			newAttrs = value.ObjValue{}
+		}
+		maps.Copy(newAttrs, overrides)
+		c.attributes = newAttrs
+		return nil
+	}
+}
+
+// WithGroups sets groups the user belongs to, e.g. {"beta-testers": true}.
+// Experiments with groups include only users in any of them.
+func WithGroups(groups map[string]bool) ClientOption {
+	return func(c *Client) error {
+		c.groups = maps.Clone(groups)
+		return nil
+	}
+}
+
+// WithExperimentOverrides changes settings of experiments by key without changing
+// the payload, e.g. from an operational override file.
+// Returns error if an override URL isn't a valid regular expression.
+func WithExperimentOverrides(overrides ExperimentOverrides) ClientOption {
+	return func(c *Client) error {
+		compiled, err := overrides.Compile()
+		if err != nil {
+			return err
+		}
+		c.experimentOverrides = compiled
 		return nil
 	}
 }
@@ -109,7 +186,27 @@ func WithQaMode(qaMode bool) ClientOption {
 	}
 }
 
-// WithHttpClient sets http client for GrowthBook API calls.
+// WithDevMode enables development features, like forcing feature values
+// via URL query string (e.g. ?gb~my-flag=true). Never enable it in production.
+func WithDevMode(devMode bool) ClientOption {
+	return func(c *Client) error {
+		c.devMode = devMode
+		return nil
+	}
+}
+
+// WithEvalTimeout caps feature evaluation time. When evaluation takes longer
+// than timeout or context deadline, EvalFeature returns the feature default value
+// with [TimeoutResultSource]. The deadline is checked before every rule and
+// prerequisite, so a single slow rule or condition isn't interrupted. Zero disables the limit.
+func WithEvalTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) error {
+		c.evalTimeout = timeout
+		return nil
+	}
+}
+
+// WithHttpClient sets http client for GrowthBook API calls and SSE streaming.
 func WithHttpClient(httpClient *http.Client) ClientOption {
 	return func(c *Client) error {
 		c.data.httpClient = httpClient
@@ -117,9 +214,35 @@ func WithHttpClient(httpClient *http.Client) ClientOption {
 	}
 }
 
+// ErrCustomTransport is returned when TLS config can't be applied to the HTTP client transport.
+var ErrCustomTransport = errors.New("TLS config requires *http.Transport")
+
+// WithTLSConfig sets TLS config, e.g. custom CAs or client certificates for mTLS,
+// for GrowthBook API calls and SSE streaming. Proxy settings from the environment
+// are kept. Put it after [WithHttpClient] to update transport of that client.
+func WithTLSConfig(cfg *tls.Config) ClientOption {
+	return func(c *Client) error {
+		httpClient := *c.data.httpClient
+		var transport *http.Transport
+		switch t := httpClient.Transport.(type) {
+		case nil:
+			transport = http.DefaultTransport.(*http.Transport).Clone()
+		case *http.Transport:
+			transport = t.Clone()
+		default:
+			return ErrCustomTransport
+		}
+		transport.TLSClientConfig = cfg
+		httpClient.Transport = transport
+		c.data.httpClient = &httpClient
+		return nil
+	}
+}
+
 // WithLogger sets logger for GrowthBook client.
 func WithLogger(logger *slog.Logger) ClientOption {
 	return func(c *Client) error {
+		c.baseLogger = logger
 		c.logger = logger
 		return nil
 	}
@@ -149,6 +272,66 @@ func WithFeatureUsageCallback(cb FeatureUsageCallback) ClientOption {
 	}
 }
 
+// WithExperimentRecordStore sets store that records every experiment assignment.
+// Use [ReplayForcedVariations] to reproduce recorded session later.
+func WithExperimentRecordStore(store ExperimentRecordStore) ClientOption {
+	return func(c *Client) error {
+		c.experimentRecordStore = store
+		return nil
+	}
+}
+
+// WithFeatureDefaults sets values returned for features missing from the features payload.
+// Makes it safe to ship code before the feature is created in GrowthBook.
+func WithFeatureDefaults(defaults map[string]any) ClientOption {
+	return func(c *Client) error {
+		c.featureDefaults = maps.Clone(defaults)
+		return nil
+	}
+}
+
+// WithStickyBucketService sets service that persists experiment assignments.
+// Enables sticky bucketing for experiments that don't disable it.
+func WithStickyBucketService(service StickyBucketService) ClientOption {
+	return func(c *Client) error {
+		c.stickyBucketService = service
+		return nil
+	}
+}
+
+// WithHashSeedOverride sets function that returns hash seed of the experiment by key,
+// e.g. derived from a test run id, so QA runs deterministically land in the same variations.
+// Empty seed keeps the experiment seed. Sticky buckets and forced variations take precedence.
+func WithHashSeedOverride(override func(expKey string) string) ClientOption {
+	return func(c *Client) error {
+		c.hashSeedOverride = override
+		return nil
+	}
+}
+
+// WithIncludeMissingFilterAttribute keeps users without filter hash attributes, e.g.
+// anonymous traffic, in force rules with filters instead of skipping the rules.
+// Experiments still filter such users out.
+func WithIncludeMissingFilterAttribute(include bool) ClientOption {
+	return func(c *Client) error {
+		c.includeMissingFilter = include
+		return nil
+	}
+}
+
+// WithAttributeSchema declares attributes and their JSON types. Client logs warnings when
+// its attributes or payload conditions don't match the schema. In strict mode creating
+// a client with mismatched attributes fails with [ErrAttributeType], and payloads
+// with mismatched conditions are rejected. Pass it before options setting features,
+// e.g. [WithJsonFeatures] or [WithBootstrapFeatures], for their payloads to be checked.
+func WithAttributeSchema(schema AttributeSchema, strict bool) ClientOption {
+	return func(c *Client) error {
+		c.attributeSchema = schema
+		c.strictSchema = strict
+		return nil
+	}
+}
+
 // Child client instance options
 
 // WithEnabled creates child client instance with updated enabled switch.
@@ -161,6 +344,11 @@ func (c *Client) WithQaMode(qaMode bool) (*Client, error) {
 	return c.cloneWith(WithQaMode(qaMode))
 }
 
+// WithDevMode creates child client instance with updated devMode switch.
+func (c *Client) WithDevMode(devMode bool) (*Client, error) {
+	return c.cloneWith(WithDevMode(devMode))
+}
+
 // WithLogger creates child client instance that uses provided logger.
 func (c *Client) WithLogger(logger *slog.Logger) (*Client, error) {
 	return c.cloneWith(WithLogger(logger))
@@ -173,9 +361,17 @@ func (c *Client) WithAttributes(attributes Attributes) (*Client, error) {
 
 // WithAttributeOverrides creates child client instance with updated top-level attributes.
 func (c *Client) WithAttributeOverrides(attributes Attributes) (*Client, error) {
-	newAttrs := maps.Clone(c.attributes)
-	maps.Copy(newAttrs, value.Obj(attributes))
-	return c.cloneWith(withValueAttributes(newAttrs))
+	return c.cloneWith(WithAttributeOverrides(attributes))
+}
+
+// WithGroups creates child client with updated user groups.
+func (c *Client) WithGroups(groups map[string]bool) (*Client, error) {
+	return c.cloneWith(WithGroups(groups))
+}
+
+// WithExperimentOverrides creates child client with updated experiment overrides.
+func (c *Client) WithExperimentOverrides(overrides ExperimentOverrides) (*Client, error) {
+	return c.cloneWith(WithExperimentOverrides(overrides))
 }
 
 // WithUrl creates child client with updated current page URL.
@@ -203,20 +399,36 @@ func (c *Client) WithFeatureUsageCallback(cb FeatureUsageCallback) (*Client, err
 	return c.cloneWith(WithFeatureUsageCallback(cb))
 }
 
-func withValueAttributes(value value.ObjValue) ClientOption {
-	return func(c *Client) error {
-		c.attributes = value
-		return nil
-	}
+// WithExperimentRecordStore creates child client that records experiment assignments into the store.
+func (c *Client) WithExperimentRecordStore(store ExperimentRecordStore) (*Client, error) {
+	return c.cloneWith(WithExperimentRecordStore(store))
+}
+
+// WithStickyBucketService creates child client that persists experiment assignments with the service.
+// Use it to provide request scoped services, like [CookieStickyBucketService].
+func (c *Client) WithStickyBucketService(service StickyBucketService) (*Client, error) {
+	return c.cloneWith(WithStickyBucketService(service))
+}
+
+// WithHashSeedOverride creates child client with updated hash seed override, see [WithHashSeedOverride].
+func (c *Client) WithHashSeedOverride(override func(expKey string) string) (*Client, error) {
+	return c.cloneWith(WithHashSeedOverride(override))
 }
 
 func (c *Client) cloneWith(opts ...ClientOption) (*Client, error) {
 	clone := c.clone()
+	baseLogger, logFields := clone.baseLogger, len(clone.logFields)
 	for _, opt := range opts {
 		err := opt(clone)
 		if err != nil {
 			return nil, err
 		}
 	}
+	if err := clone.checkAttributes(); err != nil {
+		return nil, err
+	}
+	if clone.baseLogger != baseLogger || len(clone.logFields) != logFields {
+		clone.scopeLogger()
+	}
 	return clone, nil
 }
@@ -2,7 +2,10 @@ package growthbook
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"net/http"
+	"sync"
 	"testing"
 
 	"github.com/growthbook/growthbook-golang/internal/value"
@@ -79,6 +82,58 @@ func TestClientSetFeatures(t *testing.T) {
 	require.Equal(t, result, expected)
 }
 
+func TestClientSharedFeaturesMap(t *testing.T) {
+	ctx := context.TODO()
+	features := FeatureMap{"feature": {DefaultValue: 0, Rules: []FeatureRule{
+		{Key: "exp", Variations: []FeatureValue{1, 2}, Weights: []float64{0.5, 0.5}},
+	}}}
+	resp := &FeatureApiResponse{Features: features}
+	client1, err := NewClient(ctx, WithAttributes(Attributes{"id": "123"}), WithFeatures(features))
+	require.Nil(t, err)
+	client2, err := NewClient(ctx, WithAttributes(Attributes{"id": "123"}))
+	require.Nil(t, err)
+
+	// Run with -race: precomputing ranges must not write into maps being evaluated
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			require.NotNil(t, client1.EvalFeature(ctx, "feature").Experiment)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			require.Nil(t, client2.SetFeatures(features))
+			require.Nil(t, client2.UpdateFromApiResponse(resp))
+			require.Nil(t, client1.SetFeatures(client1.FeaturesUnsafe()))
+		}
+	}()
+	wg.Wait()
+	require.Equal(t, client1.EvalFeature(ctx, "feature").Value, client2.EvalFeature(ctx, "feature").Value)
+}
+
+func TestClientSetFeature(t *testing.T) {
+	ctx := context.TODO()
+	client, _ := NewClient(ctx, WithJsonFeatures(`{"feature1": {"defaultValue": 1}}`))
+	child, _ := client.WithAttributes(Attributes{"id": "123"})
+	pinned := client.PinSnapshot(ctx)
+	updated := client.data.updated()
+
+	client.SetFeature("feature2", NewFeature(2))
+	client.SetFeature("feature1", NewFeature(10))
+	<-updated
+	require.Equal(t, 10, child.EvalFeature(ctx, "feature1").Value)
+	require.Equal(t, 2, child.EvalFeature(ctx, "feature2").Value)
+	require.Equal(t, 1.0, child.EvalFeature(pinned, "feature1").Value)
+	require.Equal(t, UnknownFeatureResultSource, child.EvalFeature(pinned, "feature2").Source)
+
+	client.RemoveFeature("feature2")
+	require.Equal(t, UnknownFeatureResultSource, child.EvalFeature(ctx, "feature2").Source)
+	require.Len(t, client.Features(), 1)
+}
+
 func TestClientSetJSONFeatures(t *testing.T) {
 	ctx := context.TODO()
 	client, _ := NewClient(ctx, WithAttributes(Attributes{"id": "123"}))
@@ -209,3 +264,227 @@ func TestClientExperimentTracking(t *testing.T) {
 	require.Equal(t, 1, count)
 	require.Equal(t, "extra data", extraData)
 }
+
+type testDecryptionProvider struct {
+	plain string
+	calls int
+}
+
+func (p *testDecryptionProvider) Decrypt(_ context.Context, ciphertext string) (string, error) {
+	p.calls++
+	if ciphertext != "encrypted" {
+		return "", ErrCryptoInvalidEncryptedFormat
+	}
+	return p.plain, nil
+}
+
+func TestClientDecryptionProvider(t *testing.T) {
+	ctx := context.TODO()
+	provider := &testDecryptionProvider{plain: `{"feature1": {"defaultValue": "kms"}}`}
+	client, _ := NewClient(ctx, WithDecryptionProvider(provider))
+
+	err := client.UpdateFromApiResponse(&FeatureApiResponse{EncryptedFeatures: "encrypted"})
+	require.Nil(t, err)
+	require.Equal(t, 1, provider.calls)
+	require.Equal(t, FeatureMap{"feature1": &Feature{DefaultValue: "kms"}}, client.Features())
+
+	err = client.SetEncryptedJSONFeatures("invalid")
+	require.ErrorIs(t, err, ErrCryptoInvalidEncryptedFormat)
+}
+
+func TestClientNoDecryptionProvider(t *testing.T) {
+	client, _ := NewClient(context.TODO())
+	err := client.SetEncryptedJSONFeatures("encrypted")
+	require.ErrorIs(t, err, ErrNoDecryptionKey)
+}
+
+func TestClientFeatureDefaults(t *testing.T) {
+	ctx := context.TODO()
+	client, _ := NewClient(ctx,
+		WithFeatures(FeatureMap{"feature": &Feature{DefaultValue: "payload"}}),
+		WithFeatureDefaults(map[string]any{"feature": "default", "new-feature": true}),
+	)
+
+	result := client.EvalFeature(ctx, "new-feature")
+	expected := &FeatureResult{
+		Value:  true,
+		On:     true,
+		Off:    false,
+		Source: DefaultsResultSource,
+	}
+	require.Equal(t, expected, result)
+	require.Equal(t, "payload", client.EvalFeature(ctx, "feature").Value)
+	require.Equal(t, UnknownFeatureResultSource, client.EvalFeature(ctx, "unknown").Source)
+}
+
+func TestClientPinSnapshot(t *testing.T) {
+	client, err := NewClient(context.TODO(), WithJsonFeatures(`{"feature": {"defaultValue": 1}}`))
+	require.Nil(t, err)
+	child, err := client.WithAttributes(Attributes{"id": "1"})
+	require.Nil(t, err)
+
+	ctx := client.PinSnapshot(context.TODO())
+	require.Nil(t, client.SetJSONFeatures(`{"feature": {"defaultValue": 2}}`))
+
+	require.Equal(t, 1.0, client.EvalFeature(ctx, "feature").Value)
+	require.Equal(t, 1.0, child.EvalFeature(ctx, "feature").Value)
+	require.Equal(t, 1.0, client.EvalFeature(client.PinSnapshot(ctx), "feature").Value)
+	require.Equal(t, 2.0, client.EvalFeature(context.TODO(), "feature").Value)
+
+	other, err := NewClient(context.TODO(), WithJsonFeatures(`{"feature": {"defaultValue": 3}}`))
+	require.Nil(t, err)
+	require.Equal(t, 3.0, other.EvalFeature(ctx, "feature").Value)
+}
+
+func TestClientWithTLSConfig(t *testing.T) {
+	cfg := &tls.Config{ServerName: "example.com"}
+	client, err := NewClient(context.TODO(), WithTLSConfig(cfg))
+	require.Nil(t, err)
+	require.Same(t, cfg, client.data.httpClient.Transport.(*http.Transport).TLSClientConfig)
+	require.NotSame(t, cfg, http.DefaultTransport.(*http.Transport).TLSClientConfig)
+
+	custom := &http.Client{Transport: roundTripperFunc(nil)}
+	_, err = NewClient(context.TODO(), WithHttpClient(custom), WithTLSConfig(cfg))
+	require.ErrorIs(t, err, ErrCustomTransport)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestClientFeaturesIsolation(t *testing.T) {
+	ctx := context.TODO()
+	client, err := NewClient(ctx,
+		WithJsonFeatures(`{"feature": {"defaultValue": {"color": "red"}, "rules": [{"force": ["a"], "coverage": 1}]}}`),
+		WithAttributes(Attributes{"id": "1", "tags": []string{"a"}}),
+	)
+	require.Nil(t, err)
+
+	features := client.Features()
+	features["feature"].DefaultValue.(map[string]any)["color"] = "blue"
+	*features["feature"].Rules[0].Coverage = 0
+	features["other"] = &Feature{}
+	attrs := client.Attributes()
+	attrs["tags"].([]any)[0] = "b"
+
+	require.Equal(t, []any{"a"}, client.EvalFeature(ctx, "feature").Value)
+	require.Equal(t, map[string]any{"color": "red"}, client.FeaturesUnsafe()["feature"].DefaultValue)
+	require.NotContains(t, client.Features(), "other")
+	require.Equal(t, Attributes{"id": "1", "tags": []any{"a"}}, client.Attributes())
+}
+
+func TestClientFeaturesConcurrentAccess(t *testing.T) {
+	ctx := context.TODO()
+	featuresJSON := `{"feature": {"defaultValue": {"color": "red"}, "rules": [{"variations": [1, 2]}]}}`
+	client, err := NewClient(ctx, WithJsonFeatures(featuresJSON), WithAttributes(Attributes{"id": "1"}))
+	require.Nil(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				features := client.Features()
+				features["feature"].DefaultValue.(map[string]any)["color"] = j
+				features["feature"].Rules[0].Variations[0] = j
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				client.EvalFeature(ctx, "feature")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				require.Nil(t, client.SetJSONFeatures(featuresJSON))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestClientGlobalAttributes(t *testing.T) {
+	ctx := context.TODO()
+	client, err := NewClient(ctx,
+		WithJsonFeatures(`{"feature": {"defaultValue": "default", "rules": [{"condition": {"region": "eu", "id": "2"}, "force": "eu"}]}}`),
+		WithGlobalAttributes(Attributes{"region": "eu", "id": "1"}),
+	)
+	require.Nil(t, err)
+	require.Equal(t, "default", client.EvalFeature(ctx, "feature").Value)
+
+	child, err := client.WithAttributes(Attributes{"id": "2"})
+	require.Nil(t, err)
+	require.Equal(t, "eu", child.EvalFeature(ctx, "feature").Value)
+	require.Equal(t, Attributes{"region": "eu", "id": "2"}, child.Attributes())
+
+	child, err = child.WithAttributeOverrides(Attributes{"region": "us"})
+	require.Nil(t, err)
+	require.Equal(t, "default", child.EvalFeature(ctx, "feature").Value)
+
+	child, err = client.WithAttributeOverrides(Attributes{"id": "2"})
+	require.Nil(t, err)
+	require.Equal(t, "eu", child.EvalFeature(ctx, "feature").Value)
+}
+
+func TestClientGroupsAndAttributeOverrides(t *testing.T) {
+	ctx := context.TODO()
+	client, err := NewClient(ctx,
+		WithAttributes(Attributes{"id": "1", "region": "us"}),
+		WithAttributeOverrides(Attributes{"region": "eu"}),
+	)
+	require.Nil(t, err)
+	require.Equal(t, Attributes{"id": "1", "region": "eu"}, client.Attributes())
+
+	exp := &Experiment{Key: "exp", Variations: []FeatureValue{0, 1}, Groups: []string{"beta"}}
+	require.False(t, client.RunExperiment(ctx, exp).InExperiment)
+
+	beta, err := client.WithGroups(map[string]bool{"beta": true, "internal": false})
+	require.Nil(t, err)
+	require.True(t, beta.RunExperiment(ctx, exp).InExperiment)
+
+	internal, err := client.WithGroups(map[string]bool{"internal": true})
+	require.Nil(t, err)
+	require.False(t, internal.RunExperiment(ctx, exp).InExperiment)
+}
+
+func TestClientDisabledKeepsRollouts(t *testing.T) {
+	ctx := context.TODO()
+	tracked := 0
+	client, err := NewClient(ctx,
+		WithJsonFeatures(`{
+		  "feature": {"defaultValue": "default", "rules": [
+		    {"key": "exp", "variations": ["control", "treatment"], "weights": [0, 1]},
+		    {"force": "rollout", "coverage": 1}
+		  ]},
+		  "exp-only": {"defaultValue": "default", "rules": [
+		    {"key": "exp2", "variations": ["control", "treatment"], "weights": [0, 1]}
+		  ]}
+		}`),
+		WithAttributes(Attributes{"id": "1"}),
+		WithExperimentCallback(func(context.Context, *Experiment, *ExperimentResult, any) { tracked++ }),
+	)
+	require.Nil(t, err)
+	require.Equal(t, "treatment", client.EvalFeature(ctx, "feature").Value)
+	require.Equal(t, 1, tracked)
+
+	disabled, err := client.WithEnabled(false)
+	require.Nil(t, err)
+	res := disabled.EvalFeature(ctx, "feature")
+	require.Equal(t, "rollout", res.Value)
+	require.Equal(t, ForceResultSource, res.Source)
+	require.Equal(t, "default", disabled.EvalFeature(ctx, "exp-only").Value)
+	require.Equal(t, 1, tracked)
+}
+
+func TestClientRequiredAttributes(t *testing.T) {
+	client, err := NewClient(context.TODO(), WithJsonFeatures(`{
+	  "feature": {"defaultValue": 0, "rules": [{"condition": {"country": "US", "$or": [{"plan": "pro"}]}, "force": 1}]}
+	}`))
+	require.Nil(t, err)
+	require.Equal(t, []string{"country", "plan"}, client.RequiredAttributes("feature"))
+}
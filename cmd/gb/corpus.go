@@ -0,0 +1,204 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"strconv"
+)
+
+func corpus(args []string) int {
+	fs := flag.NewFlagSet("corpus", flag.ExitOnError)
+	n := fs.Int("n", 1000, "number of cases per suite")
+	seed := fs.Uint64("seed", 1, "random seed")
+	_ = fs.Parse(args)
+	if fs.NArg() != 0 || *n < 0 {
+		usage()
+	}
+	if err := writeCorpus(os.Stdout, *n, *seed); err != nil {
+		fmt.Fprintln(os.Stderr, "Error writing corpus:", err)
+		return 2
+	}
+	return 0
+}
+
+// writeCorpus writes n generated cases per suite in the cases.json format, with
+// null expected values. The same seed always generates the same corpus, so SDKs
+// can evaluate it independently and their dumps can be diffed.
+func writeCorpus(w io.Writer, n int, seed uint64) error {
+	g := &generator{rand.New(rand.NewPCG(seed, seed))}
+	suites := map[string][][]any{}
+	for i := range n {
+		suites["evalCondition"] = append(suites["evalCondition"], g.evalConditionCase(i))
+		suites["hash"] = append(suites["hash"], g.hashCase())
+		suites["getBucketRange"] = append(suites["getBucketRange"], g.getBucketRangeCase(i))
+		suites["inNamespace"] = append(suites["inNamespace"], g.inNamespaceCase(i))
+		suites["run"] = append(suites["run"], g.runCase(i))
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(suites)
+}
+
+type generator struct {
+	r *rand.Rand
+}
+
+func (g *generator) pick(values ...any) any {
+	return values[g.r.IntN(len(values))]
+}
+
+// number returns an integer, a float, a numeric string or a version-like string,
+// mixing types the way real attributes do, as numeric comparisons of mixed
+// types are where SDKs have drifted apart.
+func (g *generator) number() any {
+	switch g.r.IntN(5) {
+	case 0:
+		return g.r.IntN(200) - 100
+	case 1:
+		return float64(g.r.IntN(20000)-10000) / 100
+	case 2:
+		return strconv.Itoa(g.r.IntN(200) - 100)
+	case 3:
+		return fmt.Sprintf("%d.%d.%d", g.r.IntN(3), g.r.IntN(12), g.r.IntN(12))
+	default:
+		return g.pick(nil, true, "", "abc", 0)
+	}
+}
+
+func (g *generator) scalar() any {
+	if g.r.IntN(3) == 0 {
+		return g.pick("a", "b", "c", "us", "US", "")
+	}
+	return g.number()
+}
+
+func (g *generator) array() []any {
+	arr := make([]any, g.r.IntN(4))
+	for i := range arr {
+		if g.r.IntN(4) == 0 {
+			arr[i] = map[string]any{"v": g.scalar()}
+		} else {
+			arr[i] = g.scalar()
+		}
+	}
+	return arr
+}
+
+// valueCond returns condition on a single value, nesting operators up to the depth.
+func (g *generator) valueCond(depth int) any {
+	ops := []string{"$eq", "$ne", "$gt", "$gte", "$lt", "$lte", "$in", "$nin", "$exists", "$vgt", "$vlt", "$type"}
+	if depth > 0 {
+		ops = append(ops, "$elemMatch", "$all", "$size", "$not")
+	}
+	switch op := ops[g.r.IntN(len(ops))]; op {
+	case "$in", "$nin", "$all":
+		return map[string]any{op: g.array()}
+	case "$exists":
+		return map[string]any{op: g.r.IntN(2) == 0}
+	case "$vgt", "$vlt":
+		return map[string]any{op: fmt.Sprintf("%d.%d.%d", g.r.IntN(3), g.r.IntN(12), g.r.IntN(12))}
+	case "$type":
+		return map[string]any{op: g.pick("string", "number", "boolean", "array", "object", "null")}
+	case "$elemMatch":
+		if g.r.IntN(2) == 0 {
+			return map[string]any{op: map[string]any{"v": g.valueCond(depth - 1)}}
+		}
+		return map[string]any{op: g.valueCond(depth - 1)}
+	case "$size":
+		return map[string]any{op: g.pick(g.r.IntN(4), g.valueCond(0))}
+	case "$not":
+		return map[string]any{op: g.valueCond(depth - 1)}
+	default:
+		return map[string]any{op: g.scalar()}
+	}
+}
+
+func (g *generator) condition(depth int) map[string]any {
+	cond := map[string]any{}
+	for range g.r.IntN(2) + 1 {
+		attr := g.pick("num", "str", "arr", "missing").(string)
+		if g.r.IntN(4) == 0 {
+			cond[attr] = g.scalar()
+		} else {
+			cond[attr] = g.valueCond(depth)
+		}
+	}
+	if depth > 0 && g.r.IntN(4) == 0 {
+		op := g.pick("$and", "$or", "$nor").(string)
+		return map[string]any{op: []any{cond, g.condition(depth - 1)}}
+	}
+	return cond
+}
+
+func (g *generator) attributes() map[string]any {
+	return map[string]any{
+		"id":  strconv.Itoa(g.r.IntN(100000)),
+		"num": g.number(),
+		"str": g.scalar(),
+		"arr": g.array(),
+	}
+}
+
+func (g *generator) evalConditionCase(i int) []any {
+	return []any{fmt.Sprintf("generated %d", i), g.condition(2), g.attributes(), nil}
+}
+
+func (g *generator) hashCase() []any {
+	return []any{g.pick("", "seed", "a", "exp-1").(string), strconv.Itoa(g.r.IntN(1000000)), g.r.IntN(3), nil}
+}
+
+func (g *generator) weights(n int) []float64 {
+	if g.r.IntN(3) == 0 {
+		return nil
+	}
+	weights := make([]float64, n)
+	rest := 100
+	for i := range n - 1 {
+		w := g.r.IntN(rest + 1)
+		weights[i] = float64(w) / 100
+		rest -= w
+	}
+	weights[n-1] = float64(rest) / 100
+	return weights
+}
+
+func (g *generator) getBucketRangeCase(i int) []any {
+	n := g.r.IntN(4) + 1
+	coverage := float64(g.r.IntN(11)) / 10
+	return []any{fmt.Sprintf("generated %d", i), []any{n, coverage, g.weights(n)}, nil}
+}
+
+func (g *generator) inNamespaceCase(i int) []any {
+	start := float64(g.r.IntN(100)) / 100
+	end := start + float64(g.r.IntN(100))/100
+	namespace := []any{g.pick("ns1", "ns2").(string), start, min(end, 1)}
+	return []any{fmt.Sprintf("generated %d", i), strconv.Itoa(g.r.IntN(100000)), namespace, nil}
+}
+
+func (g *generator) runCase(i int) []any {
+	n := g.r.IntN(3) + 2
+	variations := make([]any, n)
+	for v := range variations {
+		variations[v] = v
+	}
+	exp := map[string]any{
+		"key":        fmt.Sprintf("exp-%d", g.r.IntN(100)),
+		"variations": variations,
+		"coverage":   float64(g.r.IntN(11)) / 10,
+	}
+	if weights := g.weights(n); weights != nil {
+		exp["weights"] = weights
+	}
+	if g.r.IntN(2) == 0 {
+		exp["hashVersion"] = 2
+	}
+	if g.r.IntN(4) == 0 {
+		exp["condition"] = g.condition(1)
+	}
+	env := map[string]any{"attributes": g.attributes()}
+	return []any{fmt.Sprintf("generated %d", i), env, exp, nil, nil, nil}
+}
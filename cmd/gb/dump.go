@@ -0,0 +1,289 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"os"
+
+	"github.com/growthbook/growthbook-golang/eval"
+	"github.com/growthbook/growthbook-golang/hashutil"
+)
+
+// dumpSuites are the cases.json suites evaluated by dump, in output order.
+var dumpSuites = []struct {
+	name string
+	eval func(fields []json.RawMessage) (string, any, error)
+}{
+	{"evalCondition", dumpEvalCondition},
+	{"hash", dumpHash},
+	{"getBucketRange", dumpGetBucketRange},
+	{"chooseVariation", dumpChooseVariation},
+	{"inNamespace", dumpInNamespace},
+	{"getEqualWeights", dumpGetEqualWeights},
+	{"feature", dumpFeature},
+	{"run", dumpRun},
+}
+
+// dumpLine is a single evaluation output. Fields are encoded in a fixed
+// order and floats are rounded, so outputs of SDKs can be diffed as text.
+type dumpLine struct {
+	Suite  string `json:"suite"`
+	Index  int    `json:"index"`
+	Name   string `json:"name"`
+	Result any    `json:"result"`
+}
+
+func dump(args []string) int {
+	fs := flag.NewFlagSet("dump", flag.ExitOnError)
+	_ = fs.Parse(args)
+	if fs.NArg() == 0 {
+		usage()
+	}
+	for _, file := range fs.Args() {
+		if err := dumpFile(os.Stdout, file); err != nil {
+			fmt.Fprintf(os.Stderr, "Error dumping %s: %v\n", file, err)
+			return 2
+		}
+	}
+	return 0
+}
+
+// dumpFile evaluates cases of the file in the cases.json format, ignoring expected
+// values, and writes outputs as JSON lines. Unsupported suites are skipped.
+func dumpFile(w io.Writer, file string) error {
+	var suites map[string]json.RawMessage
+	if err := readJSON(file, &suites); err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	for _, suite := range dumpSuites {
+		data, ok := suites[suite.name]
+		if !ok {
+			continue
+		}
+		var cases [][]json.RawMessage
+		if err := json.Unmarshal(data, &cases); err != nil {
+			return fmt.Errorf("%s: %w", suite.name, err)
+		}
+		for i, fields := range cases {
+			name, res, err := suite.eval(fields)
+			if err != nil {
+				return fmt.Errorf("%s case %d: %w", suite.name, i, err)
+			}
+			if err := enc.Encode(dumpLine{suite.name, i, name, res}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// unmarshalFields decodes leading fields of the case tuple into targets.
+func unmarshalFields(fields []json.RawMessage, targets ...any) error {
+	if len(fields) < len(targets) {
+		return fmt.Errorf("expected at least %d fields, got %d", len(targets), len(fields))
+	}
+	for i, target := range targets {
+		if err := json.Unmarshal(fields[i], target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dumpEvalCondition(fields []json.RawMessage) (string, any, error) {
+	var name string
+	var cond eval.Condition
+	var attrs eval.Attributes
+	var groups eval.SavedGroups
+	if err := unmarshalFields(fields, &name, &cond, &attrs); err != nil {
+		return "", nil, err
+	}
+	if len(fields) > 4 {
+		if err := json.Unmarshal(fields[4], &groups); err != nil {
+			return "", nil, err
+		}
+	}
+	return name, cond.Eval(eval.NewAttributeValues(attrs), groups), nil
+}
+
+func dumpHash(fields []json.RawMessage) (string, any, error) {
+	var seed, value string
+	var version int
+	if err := unmarshalFields(fields, &seed, &value, &version); err != nil {
+		return "", nil, err
+	}
+	name := fmt.Sprintf("%s:%s:%d", seed, value, version)
+	if n, ok := hashutil.Hash(seed, value, version); ok {
+		return name, round(n), nil
+	}
+	return name, nil, nil
+}
+
+func dumpGetBucketRange(fields []json.RawMessage) (string, any, error) {
+	var name string
+	var inputs []json.RawMessage
+	if err := unmarshalFields(fields, &name, &inputs); err != nil {
+		return "", nil, err
+	}
+	var num int
+	var coverage float64
+	var weights []float64
+	if err := unmarshalFields(inputs, &num, &coverage, &weights); err != nil {
+		return "", nil, err
+	}
+	return name, roundRanges(hashutil.GetBucketRanges(num, coverage, weights)), nil
+}
+
+func dumpChooseVariation(fields []json.RawMessage) (string, any, error) {
+	var name string
+	var n float64
+	var ranges []hashutil.BucketRange
+	if err := unmarshalFields(fields, &name, &n, &ranges); err != nil {
+		return "", nil, err
+	}
+	return name, hashutil.ChooseVariation(n, ranges), nil
+}
+
+func dumpInNamespace(fields []json.RawMessage) (string, any, error) {
+	var name, id string
+	var namespace hashutil.Namespace
+	if err := unmarshalFields(fields, &name, &id, &namespace); err != nil {
+		return "", nil, err
+	}
+	return name, hashutil.InNamespace(id, &namespace), nil
+}
+
+func dumpGetEqualWeights(fields []json.RawMessage) (string, any, error) {
+	var n int
+	if err := unmarshalFields(fields, &n); err != nil {
+		return "", nil, err
+	}
+	weights := hashutil.GetEqualWeights(n)
+	res := make([]float64, len(weights))
+	for i, w := range weights {
+		res[i] = round(w)
+	}
+	return fmt.Sprint(n), res, nil
+}
+
+// dumpExperimentResult is the part of experiment results the SDKs agree on.
+type dumpExperimentResult struct {
+	Value        eval.FeatureValue `json:"value"`
+	VariationId  int               `json:"variationId"`
+	InExperiment bool              `json:"inExperiment"`
+	HashUsed     bool              `json:"hashUsed"`
+	Key          string            `json:"key"`
+	Bucket       *float64          `json:"bucket,omitempty"`
+}
+
+// dumpFeatureResult is the part of feature results the SDKs agree on.
+type dumpFeatureResult struct {
+	Value            eval.FeatureValue     `json:"value"`
+	On               bool                  `json:"on"`
+	Off              bool                  `json:"off"`
+	Source           string                `json:"source"`
+	RuleId           string                `json:"ruleId"`
+	ExperimentResult *dumpExperimentResult `json:"experimentResult,omitempty"`
+}
+
+func dumpFeature(fields []json.RawMessage) (string, any, error) {
+	var name, key string
+	var env dumpEnv
+	if err := unmarshalFields(fields, &name, &env, &key); err != nil {
+		return "", nil, err
+	}
+	e, err := env.evaluator()
+	if err != nil {
+		return "", nil, err
+	}
+	res := e.EvalFeature(key)
+	return name, dumpFeatureResult{
+		Value:            res.Value,
+		On:               res.On,
+		Off:              res.Off,
+		Source:           string(res.Source),
+		RuleId:           res.RuleId,
+		ExperimentResult: newDumpExperimentResult(res.ExperimentResult),
+	}, nil
+}
+
+func dumpRun(fields []json.RawMessage) (string, any, error) {
+	var name string
+	var env dumpEnv
+	var exp eval.Experiment
+	if err := unmarshalFields(fields, &name, &env, &exp); err != nil {
+		return "", nil, err
+	}
+	e, err := env.evaluator()
+	if err != nil {
+		return "", nil, err
+	}
+	return name, newDumpExperimentResult(e.RunExperiment(&exp)), nil
+}
+
+func newDumpExperimentResult(res *eval.ExperimentResult) *dumpExperimentResult {
+	if res == nil {
+		return nil
+	}
+	r := &dumpExperimentResult{
+		Value:        res.Value,
+		VariationId:  res.VariationId,
+		InExperiment: res.InExperiment,
+		HashUsed:     res.HashUsed,
+		Key:          res.Key,
+	}
+	if res.Bucket != nil {
+		b := round(*res.Bucket)
+		r.Bucket = &b
+	}
+	return r
+}
+
+// dumpEnv is the evaluation environment of feature and run cases.
+type dumpEnv struct {
+	Attributes       eval.Attributes          `json:"attributes"`
+	Features         eval.FeatureMap          `json:"features"`
+	Enabled          *bool                    `json:"enabled"`
+	Url              string                   `json:"url"`
+	ForcedVariations eval.ForcedVariationsMap `json:"forcedVariations"`
+	QaMode           bool                     `json:"qaMode"`
+	SavedGroups      eval.SavedGroups         `json:"savedGroups"`
+}
+
+func (env *dumpEnv) evaluator() (*eval.Evaluator, error) {
+	opts := &eval.Options{
+		Attributes:       eval.NewAttributeValues(env.Attributes),
+		Features:         env.Features.PrecomputeRanges(),
+		SavedGroups:      env.SavedGroups,
+		Disabled:         env.Enabled != nil && !*env.Enabled,
+		QaMode:           env.QaMode,
+		ForcedVariations: env.ForcedVariations,
+	}
+	if env.Url != "" {
+		u, err := url.Parse(env.Url)
+		if err != nil {
+			return nil, err
+		}
+		opts.Url = u
+	}
+	return eval.New(context.Background(), opts), nil
+}
+
+func roundRanges(ranges []hashutil.BucketRange) []hashutil.BucketRange {
+	res := make([]hashutil.BucketRange, len(ranges))
+	for i, r := range ranges {
+		res[i] = hashutil.BucketRange{Min: round(r.Min), Max: round(r.Max)}
+	}
+	return res
+}
+
+// round drops float noise beyond the precision SDKs are expected to agree on.
+func round(v float64) float64 {
+	return math.Round(v*1e6) / 1e6
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Dump of the shared cases must match their expected values, or diffs against
+// the JS SDK would report drift of the tool instead of the SDK.
+func TestDumpCasesJson(t *testing.T) {
+	var buf bytes.Buffer
+	require.Nil(t, dumpFile(&buf, "../../cases.json"))
+
+	var suites struct {
+		EvalCondition   [][]json.RawMessage
+		Hash            [][]json.RawMessage
+		ChooseVariation [][]json.RawMessage
+		InNamespace     [][]json.RawMessage
+	}
+	require.Nil(t, readJSON("../../cases.json", &suites))
+	cases := map[string][][]json.RawMessage{
+		"evalCondition":   suites.EvalCondition,
+		"hash":            suites.Hash,
+		"chooseVariation": suites.ChooseVariation,
+		"inNamespace":     suites.InNamespace,
+	}
+
+	dec := json.NewDecoder(&buf)
+	count := 0
+	for dec.More() {
+		var line struct {
+			Suite  string
+			Index  int
+			Name   string
+			Result json.RawMessage
+		}
+		require.Nil(t, dec.Decode(&line))
+		count++
+		suite, ok := cases[line.Suite]
+		if !ok {
+			continue
+		}
+		var expected, actual any
+		require.Nil(t, json.Unmarshal(suite[line.Index][3], &expected))
+		require.Nil(t, json.Unmarshal(line.Result, &actual))
+		if n, ok := expected.(float64); ok {
+			expected = round(n)
+		}
+		require.Equal(t, expected, actual, "%s %s", line.Suite, line.Name)
+	}
+	require.Greater(t, count, 0)
+}
+
+func TestCorpusDeterministic(t *testing.T) {
+	var a, b bytes.Buffer
+	require.Nil(t, writeCorpus(&a, 50, 7))
+	require.Nil(t, writeCorpus(&b, 50, 7))
+	require.Equal(t, a.String(), b.String())
+
+	file := filepath.Join(t.TempDir(), "corpus.json")
+	require.Nil(t, os.WriteFile(file, a.Bytes(), 0o600))
+	var out bytes.Buffer
+	require.Nil(t, dumpFile(&out, file))
+	require.Equal(t, 5*50, bytes.Count(out.Bytes(), []byte("\n")))
+}
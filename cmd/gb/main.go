@@ -0,0 +1,106 @@
+// Command gb is a GrowthBook SDK tool.
+//
+// Usage:
+//
+//	gb lint [-schema schema.json] payload.json
+//	gb corpus [-n 1000] [-seed 1] > corpus.json
+//	gb dump cases.json corpus.json > outputs.jsonl
+//
+// Lint inspects features payload, either the SDK API response or a features map,
+// and prints findings as JSON lines. Schema is a JSON object mapping attribute
+// names to their JSON types. Exits with code 1 if anything is found.
+//
+// Corpus generates a deterministic set of cases in the shared cases.json format,
+// with null expected values. Dump evaluates cases of the files, ignoring expected
+// values, and prints outputs in a canonical JSON lines format, so they can be diffed
+// against outputs of the JS SDK for the same files to catch cross-SDK drift.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/growthbook/growthbook-golang/eval"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	switch os.Args[1] {
+	case "lint":
+		os.Exit(lint(os.Args[2:]))
+	case "corpus":
+		os.Exit(corpus(os.Args[2:]))
+	case "dump":
+		os.Exit(dump(os.Args[2:]))
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `Usage:
+  gb lint [-schema schema.json] payload.json
+  gb corpus [-n cases] [-seed seed]
+  gb dump cases.json...`)
+	os.Exit(2)
+}
+
+func lint(args []string) int {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	schemaFile := fs.String("schema", "", "attribute schema JSON file")
+	_ = fs.Parse(args)
+	if fs.NArg() != 1 {
+		usage()
+	}
+
+	features, err := readFeatures(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error reading payload:", err)
+		return 2
+	}
+
+	var schema eval.AttributeSchema
+	if *schemaFile != "" {
+		err = readJSON(*schemaFile, &schema)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error reading schema:", err)
+			return 2
+		}
+	}
+
+	findings := eval.Lint(features, schema)
+	enc := json.NewEncoder(os.Stdout)
+	for _, f := range findings {
+		_ = enc.Encode(f)
+	}
+	if len(findings) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// readFeatures accepts both SDK API response and plain features map.
+func readFeatures(file string) (eval.FeatureMap, error) {
+	var payload struct {
+		Features eval.FeatureMap `json:"features"`
+	}
+	err := readJSON(file, &payload)
+	if err == nil && payload.Features != nil {
+		return payload.Features, nil
+	}
+	var features eval.FeatureMap
+	err = readJSON(file, &features)
+	return features, err
+}
+
+func readJSON(file string, target any) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
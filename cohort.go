@@ -0,0 +1,67 @@
+package growthbook
+
+import (
+	"context"
+	"maps"
+
+	"github.com/growthbook/growthbook-golang/internal/value"
+)
+
+// AttributeRecords is a stream of user attributes passed to the yield function
+// until it returns false.
+type AttributeRecords func(yield func(attrs Attributes) bool)
+
+// SliceAttributeRecords streams attribute records from the slice.
+func SliceAttributeRecords(records []Attributes) AttributeRecords {
+	return func(yield func(Attributes) bool) {
+		for _, attrs := range records {
+			if !yield(attrs) {
+				return
+			}
+		}
+	}
+}
+
+// EvaluateCohort streams records through the targeting condition and passes the
+// id attribute value of every matching record to yield, until it returns false.
+// Records are evaluated as client attributes: merged over global attributes,
+// normalized, and with saved groups of the current payload, so offline cohort
+// checks match runtime targeting. Records without the id attribute are skipped.
+// Empty idAttribute defaults to "id".
+//
+// Returns context error if the context is done, or [ErrUnsupportedAttribute]
+// for an invalid record in strict attributes mode.
+func (client *Client) EvaluateCohort(
+	ctx context.Context,
+	cond Condition,
+	idAttribute string,
+	records AttributeRecords,
+	yield func(id string) bool,
+) error {
+	if idAttribute == "" {
+		idAttribute = "id"
+	}
+	savedGroups := client.evalOptions(ctx).SavedGroups
+	var err error
+	records(func(attrs Attributes) bool {
+		if err = ctx.Err(); err != nil {
+			return false
+		}
+		var values value.ObjValue
+		values, err = client.attributeValues(attrs)
+		if err != nil {
+			return false
+		}
+		if len(client.globalAttributes) > 0 {
+			merged := maps.Clone(client.globalAttributes)
+			maps.Copy(merged, values)
+			values = merged
+		}
+		id, ok := values[idAttribute]
+		if !ok || value.IsNull(id) || !cond.Eval(values, savedGroups) {
+			return true
+		}
+		return yield(id.String())
+	})
+	return err
+}
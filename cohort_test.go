@@ -0,0 +1,52 @@
+package growthbook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/growthbook/growthbook-golang/eval"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateCohort(t *testing.T) {
+	var groups eval.SavedGroups
+	require.Nil(t, json.Unmarshal([]byte(`{"beta": ["2", "3"]}`), &groups))
+	client, err := NewClient(context.TODO(),
+		WithGlobalAttributes(Attributes{"region": "eu"}),
+		WithSavedGroups(groups),
+	)
+	require.Nil(t, err)
+	cond, err := ParseCondition([]byte(`{"region": "eu", "age": {"$gte": 18}, "$or": [{"id": {"$inGroup": "beta"}}, {"plan": "pro"}]}`))
+	require.Nil(t, err)
+
+	records := SliceAttributeRecords([]Attributes{
+		{"id": "1", "age": 20},
+		{"id": "2", "age": 30},
+		{"id": 3, "age": 17},
+		{"id": "4", "age": 40, "plan": "pro"},
+		{"id": "5", "age": 40, "plan": "pro", "region": "us"},
+		{"age": 40, "plan": "pro"},
+		{"id": "6", "age": 18, "plan": "pro"},
+	})
+	var ids []string
+	err = client.EvaluateCohort(context.TODO(), cond, "", records, func(id string) bool {
+		ids = append(ids, id)
+		return true
+	})
+	require.Nil(t, err)
+	require.Equal(t, []string{"2", "4", "6"}, ids)
+
+	ids = nil
+	err = client.EvaluateCohort(context.TODO(), cond, "id", records, func(id string) bool {
+		ids = append(ids, id)
+		return false
+	})
+	require.Nil(t, err)
+	require.Equal(t, []string{"2"}, ids)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	err = client.EvaluateCohort(ctx, cond, "id", records, func(id string) bool { return true })
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,129 @@
+package growthbook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// concurrencyChecks detects misuse of shared client data, e.g. features maps modified after
+// they were passed to the client, and concurrent features updates. Shared between a client
+// and its child clients.
+type concurrencyChecks struct {
+	// goroutine id of the features update in progress, 0 if none
+	updater atomic.Uint64
+
+	mu sync.Mutex
+	// features map shared with the caller, its fingerprint and where it was shared
+	shared      uintptr
+	fingerprint uint64
+	owner       string
+}
+
+// WithConcurrencyChecks enables debug checks for misuse of the client from concurrent code,
+// which otherwise shows up as rare data races or runtime crashes in production:
+//   - maps passed to [Client.SetFeatures] or returned by [Client.FeaturesUnsafe] modified
+//     in place, detected on feature evaluation and logged with the goroutine and line that
+//     shared the map;
+//   - features updated from several goroutines at once, where the last update wins.
+//
+// Checks are best effort and add overhead to every evaluation, so enable them in tests
+// and staging only. Run tests with -race to catch all data races.
+func WithConcurrencyChecks() ClientOption {
+	return func(c *Client) error {
+		c.concurrency = &concurrencyChecks{}
+		return nil
+	}
+}
+
+// beginUpdate notes features update by the current goroutine and warns if another
+// goroutine is updating features at the same time. Returned function ends the update.
+func (client *Client) beginUpdate(op string) func() {
+	cc := client.concurrency
+	if cc == nil {
+		return func() {}
+	}
+	id := goroutineId()
+	if other := cc.updater.Swap(id); other != 0 && other != id {
+		client.logger.Warn("Concurrent features updates, the last one wins. Update features from a single goroutine.",
+			"op", op, "goroutine", id, "otherGoroutine", other, "caller", caller(2))
+	}
+	return func() { cc.updater.CompareAndSwap(id, 0) }
+}
+
+// shareFeatures notes that the features map is shared with the caller of op,
+// so its modifications can be detected.
+func (client *Client) shareFeatures(op string, features FeatureMap) {
+	cc := client.concurrency
+	if cc == nil || features == nil {
+		return
+	}
+	owner := fmt.Sprintf("%s at %s on goroutine %d", op, caller(2), goroutineId())
+	fp := featuresFingerprint(features)
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.shared, cc.fingerprint, cc.owner = reflect.ValueOf(features).Pointer(), fp, owner
+}
+
+// checkFeatures logs an error if the shared features map was modified since it was shared.
+// Every modification is logged once.
+func (client *Client) checkFeatures(ctx context.Context, features FeatureMap) {
+	cc := client.concurrency
+	if cc == nil || features == nil {
+		return
+	}
+	ptr := reflect.ValueOf(features).Pointer()
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if ptr != cc.shared {
+		return
+	}
+	fp := featuresFingerprint(features)
+	if fp == cc.fingerprint {
+		return
+	}
+	cc.fingerprint = fp
+	client.logger.ErrorContext(ctx, "Features map modified after it was shared with the client, which races with evaluations. Pass a copy or use SetFeature.",
+		"sharedBy", cc.owner, "goroutine", goroutineId())
+}
+
+// featuresFingerprint hashes keys, features and rule slices of the map. Modifications of
+// rules in place are not detected.
+func featuresFingerprint(features FeatureMap) uint64 {
+	fp := uint64(len(features))
+	for key, f := range features {
+		h := fnv.New64a()
+		h.Write([]byte(key))
+		v := h.Sum64() ^ uint64(reflect.ValueOf(f).Pointer())
+		if f != nil {
+			v ^= uint64(len(f.Rules))<<48 ^ uint64(reflect.ValueOf(f.Rules).Pointer())<<1
+		}
+		// Sum doesn't depend on map iteration order
+		fp += v * 0x9e3779b97f4a7c15
+	}
+	return fp
+}
+
+// goroutineId parses id of the current goroutine from its stack trace. Intended for diagnostics only.
+func goroutineId() uint64 {
+	var buf [64]byte
+	stack := buf[:runtime.Stack(buf[:], false)]
+	stack = bytes.TrimPrefix(stack, []byte("goroutine "))
+	id, _ := strconv.ParseUint(string(stack[:max(bytes.IndexByte(stack, ' '), 0)]), 10, 64)
+	return id
+}
+
+// caller returns file and line of the caller skip frames above the function calling caller.
+func caller(skip int) string {
+	_, file, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return "unknown"
+	}
+	return file + ":" + strconv.Itoa(line)
+}
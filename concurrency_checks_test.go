@@ -0,0 +1,76 @@
+package growthbook
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrencyChecksSharedFeatures(t *testing.T) {
+	ctx := context.TODO()
+	logger, logs := testLogger(slog.LevelWarn, t)
+	features := FeatureMap{"a": NewFeature(1)}
+	client, err := NewClient(ctx, WithLogger(logger), WithConcurrencyChecks(), WithFeatures(features))
+	require.Nil(t, err)
+	require.True(t, client.EffectiveConfig().ConcurrencyChecks)
+
+	client.EvalFeature(ctx, "a")
+	require.Empty(t, *logs)
+
+	features["b"] = NewFeature(2)
+	client.EvalFeature(ctx, "a")
+	client.EvalFeature(ctx, "a")
+	require.Len(t, *logs, 1)
+	require.Equal(t, "ERROR", (*logs)[0].Level)
+
+	// Features replaced by the client are not shared with the caller
+	require.Nil(t, client.SetJSONFeatures(`{"a": {"defaultValue": 3}}`))
+	client.EvalFeature(ctx, "a")
+	require.Len(t, *logs, 1)
+
+	unsafe := client.FeaturesUnsafe()
+	unsafe["a"].Rules = append(unsafe["a"].Rules, FeatureRule{})
+	client.EvalFeature(ctx, "a")
+	require.Len(t, *logs, 2)
+}
+
+func TestConcurrencyChecksConcurrentUpdates(t *testing.T) {
+	logger, logs := testLogger(slog.LevelWarn, t)
+	client, err := NewClient(context.TODO(), WithLogger(logger), WithConcurrencyChecks())
+	require.Nil(t, err)
+
+	end := client.beginUpdate("test")
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		require.Nil(t, client.SetFeatures(FeatureMap{}))
+	}()
+	<-done
+	end()
+	require.Len(t, *logs, 1)
+	require.Equal(t, "WARN", (*logs)[0].Level)
+
+	require.Nil(t, client.SetFeatures(FeatureMap{}))
+	require.Len(t, *logs, 1)
+}
+
+func TestConcurrencyChecksDisabled(t *testing.T) {
+	ctx := context.TODO()
+	logger, logs := testLogger(slog.LevelWarn, t)
+	features := FeatureMap{"a": NewFeature(1)}
+	client, err := NewClient(ctx, WithLogger(logger), WithFeatures(features))
+	require.Nil(t, err)
+	features["b"] = NewFeature(2)
+	client.EvalFeature(ctx, "a")
+	require.Empty(t, *logs)
+}
+
+func TestGoroutineId(t *testing.T) {
+	id := goroutineId()
+	require.NotZero(t, id)
+	ch := make(chan uint64)
+	go func() { ch <- goroutineId() }()
+	require.NotEqual(t, id, <-ch)
+}
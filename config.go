@@ -0,0 +1,155 @@
+package growthbook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidConfig is returned when [Config] validation fails.
+var ErrInvalidConfig = errors.New("Invalid config")
+
+// Config is a declarative alternative to functional client options.
+// It can be loaded from JSON or YAML config files.
+type Config struct {
+	// GrowthBook API host, defaults to GrowthBook Cloud CDN
+	ApiHost string `json:"apiHost" yaml:"apiHost"`
+	// Client key used to fetch features from the GrowthBook API
+	ClientKey string `json:"clientKey" yaml:"clientKey"`
+	// Key used to decrypt encrypted features
+	DecryptionKey string `json:"decryptionKey" yaml:"decryptionKey"`
+	// Interval of features polling, e.g. "30s". Enables polling data source.
+	PollInterval Duration `json:"pollInterval" yaml:"pollInterval"`
+	// Enables SSE streaming data source
+	SSE bool `json:"sse" yaml:"sse"`
+	// SSE stream path template, defaults to "/sub/{clientKey}"
+	SseStreamPath string `json:"sseStreamPath" yaml:"sseStreamPath"`
+	// Default attributes used for evaluation
+	Attributes Attributes `json:"attributes" yaml:"attributes"`
+	// Attributes used for every evaluation, under client ones
+	GlobalAttributes Attributes `json:"globalAttributes" yaml:"globalAttributes"`
+	// Global switch for experiments, defaults to true
+	Enabled *bool `json:"enabled" yaml:"enabled"`
+	// Disables random assignment of variations
+	QaMode bool `json:"qaMode" yaml:"qaMode"`
+	// Enables development features
+	DevMode bool `json:"devMode" yaml:"devMode"`
+	// URL of the current page
+	Url string `json:"url" yaml:"url"`
+	// Forced variations used for QA
+	ForcedVariations ForcedVariationsMap `json:"forcedVariations" yaml:"forcedVariations"`
+	// Values for features missing from the payload
+	FeatureDefaults map[string]any `json:"featureDefaults" yaml:"featureDefaults"`
+	// API connection settings per environment, overriding top-level ones
+	Environments map[string]Environment `json:"environments" yaml:"environments"`
+	// Selected environment, defaults to GROWTHBOOK_ENV environment variable
+	Environment string `json:"environment" yaml:"environment"`
+}
+
+// Duration is a [time.Duration] that is encoded as a string like "1m30s".
+type Duration time.Duration
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	res, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(res)
+	return nil
+}
+
+// Validate checks config consistency.
+func (cfg *Config) Validate() error {
+	if cfg.PollInterval < 0 {
+		return fmt.Errorf("%w: pollInterval must not be negative", ErrInvalidConfig)
+	}
+	if cfg.SSE && cfg.PollInterval > 0 {
+		return fmt.Errorf("%w: sse and pollInterval are mutually exclusive", ErrInvalidConfig)
+	}
+	if cfg.SSE || cfg.PollInterval > 0 {
+		if cfg.ClientKey == "" && len(cfg.Environments) == 0 {
+			return fmt.Errorf("%w: clientKey is required to load features", ErrInvalidConfig)
+		}
+		for name, env := range cfg.Environments {
+			if cfg.ClientKey == "" && env.ClientKey == "" {
+				return fmt.Errorf("%w: clientKey is required to load features in environment %q", ErrInvalidConfig, name)
+			}
+		}
+	}
+	if cfg.Environment != "" {
+		if _, ok := cfg.Environments[cfg.Environment]; !ok {
+			return fmt.Errorf("%w: environment %q is not configured", ErrInvalidConfig, cfg.Environment)
+		}
+	}
+	return nil
+}
+
+// Options validates config and converts it into client options.
+func (cfg *Config) Options() ([]ClientOption, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	var opts []ClientOption
+	if cfg.ApiHost != "" {
+		opts = append(opts, WithApiHost(cfg.ApiHost))
+	}
+	if cfg.ClientKey != "" {
+		opts = append(opts, WithClientKey(cfg.ClientKey))
+	}
+	if cfg.DecryptionKey != "" {
+		opts = append(opts, WithDecryptionKey(cfg.DecryptionKey))
+	}
+	if cfg.Attributes != nil {
+		opts = append(opts, WithAttributes(cfg.Attributes))
+	}
+	if cfg.GlobalAttributes != nil {
+		opts = append(opts, WithGlobalAttributes(cfg.GlobalAttributes))
+	}
+	if cfg.Enabled != nil {
+		opts = append(opts, WithEnabled(*cfg.Enabled))
+	}
+	if cfg.Url != "" {
+		opts = append(opts, WithUrl(cfg.Url))
+	}
+	if cfg.ForcedVariations != nil {
+		opts = append(opts, WithForcedVariations(cfg.ForcedVariations))
+	}
+	if cfg.FeatureDefaults != nil {
+		opts = append(opts, WithFeatureDefaults(cfg.FeatureDefaults))
+	}
+	if cfg.Environments != nil {
+		opts = append(opts, WithEnvironments(cfg.Environments))
+	}
+	if cfg.Environment != "" {
+		opts = append(opts, WithEnvironment(cfg.Environment))
+	}
+	opts = append(opts, WithQaMode(cfg.QaMode), WithDevMode(cfg.DevMode))
+
+	switch {
+	case cfg.SSE:
+		opts = append(opts, WithSseDataSource())
+		if cfg.SseStreamPath != "" {
+			opts = append(opts, WithSseStreamPath(cfg.SseStreamPath))
+		}
+	case cfg.PollInterval > 0:
+		opts = append(opts, WithPollDataSource(time.Duration(cfg.PollInterval)))
+	}
+
+	return opts, nil
+}
+
+// NewClientFromConfig creates a new GrowthBook SDK client from config.
+// Extra options are applied after the config ones.
+func NewClientFromConfig(ctx context.Context, cfg Config, opts ...ClientOption) (*Client, error) {
+	cfgOpts, err := cfg.Options()
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(ctx, append(cfgOpts, opts...)...)
+}
@@ -0,0 +1,91 @@
+package growthbook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigFromJSON(t *testing.T) {
+	cfgJSON := `{
+      "apiHost": "https://example.com",
+      "clientKey": "somekey",
+      "pollInterval": "1m30s",
+      "attributes": {"id": "1"},
+      "enabled": false,
+      "featureDefaults": {"feature": true}
+    }`
+	var cfg Config
+	err := json.Unmarshal([]byte(cfgJSON), &cfg)
+	require.Nil(t, err)
+	require.Equal(t, Duration(90*time.Second), cfg.PollInterval)
+
+	// Data source is replaced to avoid network calls.
+	client, err := NewClientFromConfig(context.TODO(), cfg, withEmptyDataSource())
+	require.Nil(t, err)
+	defer client.Close()
+	require.Equal(t, "https://example.com/api/features/somekey", client.data.getApiUrl())
+	require.False(t, client.enabled)
+	require.Equal(t, true, client.EvalFeature(context.TODO(), "feature").Value)
+}
+
+func TestConfigValidation(t *testing.T) {
+	tests := map[string]Config{
+		"negative poll interval": {ClientKey: "key", PollInterval: -1},
+		"sse and polling":        {ClientKey: "key", PollInterval: Duration(time.Second), SSE: true},
+		"missing client key":     {SSE: true},
+		"missing env client key": {SSE: true, Environments: map[string]Environment{"staging": {ApiHost: "https://staging"}}},
+		"unknown environment":    {Environment: "dev", Environments: map[string]Environment{"staging": {ClientKey: "key"}}},
+	}
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewClientFromConfig(context.TODO(), cfg)
+			require.ErrorIs(t, err, ErrInvalidConfig)
+		})
+	}
+}
+
+func TestDurationText(t *testing.T) {
+	data, err := json.Marshal(Duration(90 * time.Second))
+	require.Nil(t, err)
+	require.Equal(t, `"1m30s"`, string(data))
+
+	var d Duration
+	require.Error(t, json.Unmarshal([]byte(`"soon"`), &d))
+}
+
+func TestConfigEnvironments(t *testing.T) {
+	cfg := Config{
+		ApiHost:   "https://example.com",
+		ClientKey: "prod",
+		Environments: map[string]Environment{
+			"staging": {ClientKey: "staging"},
+			"local":   {ApiHost: "http://localhost:3100", ClientKey: "local"},
+		},
+	}
+
+	t.Setenv(EnvironmentVariable, "")
+	client, err := NewClientFromConfig(context.TODO(), cfg)
+	require.Nil(t, err)
+	require.Equal(t, "https://example.com/api/features/prod", client.data.getApiUrl())
+
+	t.Setenv(EnvironmentVariable, "staging")
+	client, err = NewClientFromConfig(context.TODO(), cfg)
+	require.Nil(t, err)
+	require.Equal(t, "https://example.com/api/features/staging", client.data.getApiUrl())
+
+	client, err = NewClientFromConfig(context.TODO(), cfg, WithEnvironment("local"))
+	require.Nil(t, err)
+	require.Equal(t, "http://localhost:3100/api/features/local", client.data.getApiUrl())
+
+	t.Setenv(EnvironmentVariable, "qa")
+	_, err = NewClientFromConfig(context.TODO(), cfg)
+	require.ErrorIs(t, err, ErrUnknownEnvironment)
+
+	// Environment variable is ignored if no environments are configured
+	_, err = NewClient(context.TODO())
+	require.Nil(t, err)
+}
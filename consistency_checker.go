@@ -0,0 +1,263 @@
+package growthbook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/growthbook/growthbook-golang/eval"
+	"github.com/growthbook/growthbook-golang/internal/value"
+)
+
+// ConsistencyDivergence describes mismatch between local feature
+// evaluation and GrowthBook remote evaluation of the same feature.
+type ConsistencyDivergence struct {
+	FeatureKey string
+	Local      *FeatureResult
+	// Result of remote evaluation, or of the canary evaluator
+	Remote    *FeatureResult
+	ExtraData any
+}
+
+// CanaryEvaluator evaluates features with another SDK build, e.g. the previous version
+// of the SDK vendored under a different import path, to compare with the client.
+type CanaryEvaluator interface {
+	EvalFeature(ctx context.Context, key string, attributes Attributes) (*FeatureResult, error)
+}
+
+// CanaryEvaluatorFunc adapts function to [CanaryEvaluator].
+type CanaryEvaluatorFunc func(ctx context.Context, key string, attributes Attributes) (*FeatureResult, error)
+
+func (f CanaryEvaluatorFunc) EvalFeature(ctx context.Context, key string, attributes Attributes) (*FeatureResult, error) {
+	return f(ctx, key, attributes)
+}
+
+// ConsistencyCallback is executed every time local evaluation diverges from remote one.
+type ConsistencyCallback func(context.Context, *ConsistencyDivergence)
+
+// ConsistencyStats contains counters of the consistency checker.
+type ConsistencyStats struct {
+	// Evaluations compared with remote evaluation
+	Checked uint64
+	// Evaluations with different local and remote values
+	Diverged uint64
+	// Sampled evaluations skipped because checker queue was full
+	Dropped uint64
+	// Remote evaluation failures
+	Errors uint64
+}
+
+const consistencyQueueSize = 100
+
+type consistencyCheck struct {
+	ctx       context.Context
+	key       string
+	local     *FeatureResult
+	client    *Client
+	extraData any
+}
+
+type consistencyChecker struct {
+	sampleRate float64
+	callback   ConsistencyCallback
+	canary     CanaryEvaluator
+	queue      chan consistencyCheck
+	done       chan struct{}
+	closed     atomic.Bool
+	checked    atomic.Uint64
+	diverged   atomic.Uint64
+	dropped    atomic.Uint64
+	errors     atomic.Uint64
+}
+
+type remoteEvalRequest struct {
+	Attributes       value.ObjValue      `json:"attributes"`
+	ForcedVariations ForcedVariationsMap `json:"forcedVariations"`
+	Url              string              `json:"url"`
+}
+
+// WithConsistencyChecker enables background verifier that re-evaluates sampled
+// features via GrowthBook remote evaluation API and reports divergences to the callback.
+// sampleRate is a fraction of evaluations to check (between 0 and 1).
+func WithConsistencyChecker(sampleRate float64, cb ConsistencyCallback) ClientOption {
+	return func(c *Client) error {
+		if sampleRate < 0 || sampleRate > 1 {
+			return fmt.Errorf("Consistency checker sample rate must be between 0 and 1, got %v", sampleRate)
+		}
+		c.consistencyChecker = &consistencyChecker{
+			sampleRate: sampleRate,
+			callback:   cb,
+			queue:      make(chan consistencyCheck, consistencyQueueSize),
+			done:       make(chan struct{}),
+		}
+		return nil
+	}
+}
+
+// WithCanaryEvaluator enables background verifier that re-evaluates sampled features
+// with the canary evaluator and reports divergences to the callback, to de-risk SDK
+// upgrades. It shares counters with and replaces [WithConsistencyChecker].
+func WithCanaryEvaluator(sampleRate float64, canary CanaryEvaluator, cb ConsistencyCallback) ClientOption {
+	return func(c *Client) error {
+		if canary == nil {
+			return errors.New("Canary evaluator is required")
+		}
+		if err := WithConsistencyChecker(sampleRate, cb)(c); err != nil {
+			return err
+		}
+		c.consistencyChecker.canary = canary
+		return nil
+	}
+}
+
+// ConsistencyStats returns consistency checker counters.
+func (client *Client) ConsistencyStats() ConsistencyStats {
+	cc := client.consistencyChecker
+	if cc == nil {
+		return ConsistencyStats{}
+	}
+	return ConsistencyStats{
+		Checked:  cc.checked.Load(),
+		Diverged: cc.diverged.Load(),
+		Dropped:  cc.dropped.Load(),
+		Errors:   cc.errors.Load(),
+	}
+}
+
+func (cc *consistencyChecker) sample(ctx context.Context, client *Client, key string, local *FeatureResult) {
+	if cc.closed.Load() || rand.Float64() >= cc.sampleRate {
+		return
+	}
+	if client.pooled {
+		// Checks run after the scope may be released
+		client = client.clone()
+	}
+	check := consistencyCheck{context.WithoutCancel(ctx), key, local, client, client.extraData}
+	select {
+	case cc.queue <- check:
+	default:
+		cc.dropped.Add(1)
+	}
+}
+
+func (cc *consistencyChecker) run(ctx context.Context, logger *slog.Logger) {
+	logger = logger.With("source", "Growthbook consistency checker")
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-cc.done:
+			return
+		case check := <-cc.queue:
+			cc.check(check, logger)
+		}
+	}
+}
+
+func (cc *consistencyChecker) close() {
+	if cc.closed.CompareAndSwap(false, true) {
+		close(cc.done)
+	}
+}
+
+func (cc *consistencyChecker) check(check consistencyCheck, logger *slog.Logger) {
+	var remote *FeatureResult
+	var err error
+	if cc.canary != nil {
+		remote, err = cc.canary.EvalFeature(check.ctx, check.key, check.client.Attributes())
+	} else {
+		remote, err = check.client.remoteEvalFeature(check.ctx, check.key)
+	}
+	if err != nil {
+		cc.errors.Add(1)
+		logger.Error("Error calling "+cc.reference()+" evaluation", "key", check.key, "error", err)
+		return
+	}
+	cc.checked.Add(1)
+	if value.Equal(value.New(check.local.Value), value.New(remote.Value)) {
+		return
+	}
+	cc.diverged.Add(1)
+	logger.Warn("Local evaluation diverges from "+cc.reference(), "key", check.key)
+	if cc.callback != nil {
+		check.client.callback(check.ctx, "consistency", func() {
+			cc.callback(check.ctx, &ConsistencyDivergence{
+				FeatureKey: check.key,
+				Local:      check.client.redactor.featureResult(check.local),
+				Remote:     check.client.redactor.featureResult(remote),
+				ExtraData:  check.extraData,
+			})
+		})
+	}
+}
+
+// reference names evaluation the checker compares with.
+func (cc *consistencyChecker) reference() string {
+	if cc.canary != nil {
+		return "canary"
+	}
+	return "remote"
+}
+
+// remoteEvalFeature evaluates feature with client's attributes using GrowthBook remote evaluation API.
+func (client *Client) remoteEvalFeature(ctx context.Context, key string) (*FeatureResult, error) {
+	reqBody := remoteEvalRequest{
+		Attributes:       client.evalAttributes(),
+		ForcedVariations: client.forcedVariations,
+	}
+	if client.url != nil {
+		reqBody.Url = client.url.String()
+	}
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, client.data.getRemoteEvalUrl(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	setReqHeaders(req, "")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.data.getHttpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Error calling remote evaluation, code: %d", resp.StatusCode)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp FeatureApiResponse
+	err = client.unmarshalPayload(respBody, &apiResp)
+	if err != nil {
+		return nil, err
+	}
+
+	features := apiResp.Features
+	if apiResp.EncryptedFeatures != "" {
+		features, err = client.decryptFeatures(ctx, apiResp.EncryptedFeatures)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	opts := client.evalOptions(ctx)
+	opts.Features, opts.SavedGroups = features, apiResp.SavedGroups
+	opts.StickyBucketService = nil
+	return eval.New(ctx, opts).EvalFeature(key), nil
+}
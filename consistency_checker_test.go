@@ -0,0 +1,114 @@
+package growthbook
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsistencyChecker(t *testing.T) {
+	ctx := context.TODO()
+	var body map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/eval/somekey", r.URL.Path)
+		require.Equal(t, http.MethodPost, r.Method)
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"features": {"same": {"defaultValue": 1}, "diff": {"defaultValue": "remote"}}}`))
+	}))
+	defer ts.Close()
+
+	divergences := make(chan *ConsistencyDivergence, 10)
+	logger, _ := testLogger(slog.LevelError, t)
+	client, err := NewClient(ctx,
+		WithLogger(logger),
+		WithHttpClient(ts.Client()),
+		WithApiHost(ts.URL),
+		WithClientKey("somekey"),
+		WithAttributes(Attributes{"id": "1"}),
+		WithJsonFeatures(`{"same": {"defaultValue": 1}, "diff": {"defaultValue": "local"}}`),
+		WithConsistencyChecker(1, func(_ context.Context, d *ConsistencyDivergence) {
+			divergences <- d
+		}),
+	)
+	require.Nil(t, err)
+	defer client.Close()
+
+	client.EvalFeature(ctx, "same")
+	client.EvalFeature(ctx, "diff")
+
+	select {
+	case d := <-divergences:
+		require.Equal(t, "diff", d.FeatureKey)
+		require.Equal(t, "local", d.Local.Value)
+		require.Equal(t, "remote", d.Remote.Value)
+	case <-time.After(time.Second):
+		t.Fatal("Divergence is not reported")
+	}
+	require.Equal(t, map[string]any{"id": "1"}, body["attributes"])
+	require.Eventually(t, func() bool {
+		return client.ConsistencyStats().Checked == 2
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, uint64(1), client.ConsistencyStats().Diverged)
+}
+
+func TestConsistencyCheckerInvalidSampleRate(t *testing.T) {
+	_, err := NewClient(context.TODO(), WithConsistencyChecker(2, nil))
+	require.Error(t, err)
+}
+
+func TestCanaryEvaluator(t *testing.T) {
+	ctx := context.TODO()
+	logger, _ := testLogger(slog.LevelError, t)
+	// Another client stands for the other SDK build
+	other, err := NewClient(ctx, WithLogger(logger),
+		WithJsonFeatures(`{"flag": {"defaultValue": false, "rules": [{"condition": {"id": "1"}, "force": true}]}}`))
+	require.Nil(t, err)
+	defer other.Close()
+	canary := CanaryEvaluatorFunc(func(ctx context.Context, key string, attrs Attributes) (*FeatureResult, error) {
+		c, err := other.WithAttributes(attrs)
+		if err != nil {
+			return nil, err
+		}
+		return c.EvalFeature(ctx, key), nil
+	})
+
+	divergences := make(chan *ConsistencyDivergence, 10)
+	client, err := NewClient(ctx,
+		WithLogger(logger),
+		WithJsonFeatures(`{"flag": {"defaultValue": false, "rules": [{"condition": {"id": "2"}, "force": true}]}}`),
+		WithCanaryEvaluator(1, canary, func(_ context.Context, d *ConsistencyDivergence) {
+			divergences <- d
+		}),
+	)
+	require.Nil(t, err)
+	defer client.Close()
+
+	for _, id := range []string{"1", "3"} {
+		c, err := client.WithAttributes(Attributes{"id": id})
+		require.Nil(t, err)
+		c.EvalFeature(ctx, "flag")
+	}
+
+	select {
+	case d := <-divergences:
+		require.Equal(t, false, d.Local.Value)
+		require.Equal(t, true, d.Remote.Value)
+	case <-time.After(time.Second):
+		t.Fatal("Divergence is not reported")
+	}
+	require.Eventually(t, func() bool {
+		return client.ConsistencyStats().Checked == 2
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, uint64(1), client.ConsistencyStats().Diverged)
+	require.Equal(t, "growthbook.CanaryEvaluatorFunc", client.EffectiveConfig().CanaryEvaluator)
+
+	_, err = NewClient(ctx, WithCanaryEvaluator(0.5, nil, nil))
+	require.Error(t, err)
+}
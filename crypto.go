@@ -1,6 +1,7 @@
 package growthbook
 
 import (
+	"context"
 	"crypto/aes"
 	"crypto/cipher"
 	"encoding/base64"
@@ -14,6 +15,29 @@ var (
 	ErrCryptoInvalidPadding         = errors.New("Crypto: invalid padding")
 )
 
+// DecryptionProvider decrypts encrypted feature payloads.
+// Implement it to keep decryption keys in AWS KMS, GCP KMS, Vault, etc.
+type DecryptionProvider interface {
+	Decrypt(ctx context.Context, ciphertext string) (string, error)
+}
+
+// AesDecryptionProvider is the default provider. It decrypts payloads
+// with a base64 encoded AES key using AES-CBC, same as GrowthBook API.
+type AesDecryptionProvider struct {
+	key string
+}
+
+var _ DecryptionProvider = &AesDecryptionProvider{}
+
+// NewAesDecryptionProvider creates provider with a base64 encoded AES key.
+func NewAesDecryptionProvider(key string) *AesDecryptionProvider {
+	return &AesDecryptionProvider{key}
+}
+
+func (p *AesDecryptionProvider) Decrypt(_ context.Context, ciphertext string) (string, error) {
+	return decrypt(ciphertext, p.key)
+}
+
 func decrypt(encrypted string, encKey string) (string, error) {
 	key, err := base64.StdEncoding.DecodeString(encKey)
 	if err != nil {
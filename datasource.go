@@ -1,38 +1,91 @@
 package growthbook
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type DataSource interface {
 	Start(context.Context) error
 	Close() error
 }
 
-func (client *Client) startDataSource(ctx context.Context) {
-	defer close(client.data.dsStartWait)
-	ds := client.data.dataSource
-
-	err := ds.Start(ctx)
-	if err != nil {
-		client.data.withLock(func(d *data) error {
-			d.dsStartErr = err
-			d.dsStarted = false
-			return nil
-		})
-		return
-	}
+// dataSourceFactory creates data source that updates the client's data.
+type dataSourceFactory func(*Client) DataSource
 
+// launchDataSource creates data source with the factory and starts it in background.
+func (client *Client) launchDataSource(ctx context.Context) {
+	var ds DataSource
+	var wait chan struct{}
 	client.data.withLock(func(d *data) error {
-		d.dsStarted = true
+		if !d.dsWaitOwned {
+			// Nobody else is going to close it
+			close(d.dsStartWait)
+		}
+		ds = d.dsFactory(client)
+		wait = make(chan struct{})
+		d.dataSource = ds
+		d.dsStartWait = wait
+		d.dsWaitOwned = true
+		d.dsStarted = false
 		d.dsStartErr = nil
 		return nil
 	})
+	client.data.spawn(func() { client.startDataSource(ctx, ds, wait) })
+}
+
+func (client *Client) startDataSource(ctx context.Context, ds DataSource, wait chan struct{}) {
+	defer close(wait)
+
+	err := ds.Start(ctx)
+	stale := false
+	client.data.withLock(func(d *data) error {
+		if d.dataSource != ds || d.closed {
+			stale = true
+			return nil
+		}
+		d.dsStarted = err == nil
+		d.dsStartErr = err
+		return nil
+	})
+
+	// Data source was replaced by Reconfigure or client was closed while starting
+	if stale && err == nil {
+		_ = ds.Close()
+	}
+}
+
+// WaitForVersionNewerThan blocks until client has data updated at t or later,
+// or until context is done. Useful to make sure the new instance has payload
+// at least as fresh as the latest publish before it starts serving traffic.
+func (client *Client) WaitForVersionNewerThan(ctx context.Context, t time.Time) error {
+	for {
+		updated := client.data.updated()
+		if !client.data.getDateUpdated().Before(t) {
+			return nil
+		}
+		select {
+		case <-updated:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
 }
 
 func (client *Client) EnsureLoaded(ctx context.Context) error {
-	select {
-	case <-client.data.dsStartWait:
-		return client.data.getDsStartErr()
-	case <-ctx.Done():
-		return ctx.Err()
+	if l := client.data.light.Load(); l != nil {
+		return client.refreshLightweight(ctx, l)
+	}
+	for {
+		wait := client.data.getDsStartWait()
+		select {
+		case <-wait:
+			// Data source could be replaced meanwhile, then wait for the new one.
+			if wait == client.data.getDsStartWait() {
+				return client.data.getDsStartErr()
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
 	}
 }
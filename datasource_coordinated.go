@@ -0,0 +1,210 @@
+package growthbook
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// SharedCache is a cache shared by SDK instances, e.g. Redis or memcached.
+type SharedCache interface {
+	// Get returns value of the key or nil if there is none.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Set stores value of the key. Zero ttl means no expiration.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// SetNX stores value only if the key doesn't exist and reports whether it was stored,
+	// like Redis SET with NX option.
+	SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
+}
+
+// CoordinatedPollDataSource polls GrowthBook API from a single instance of the fleet.
+// The leader is elected via the shared cache and writes payload into it, while the
+// other instances read payload from the cache.
+type CoordinatedPollDataSource struct {
+	client   *Client
+	logger   *slog.Logger
+	interval time.Duration
+	cache    SharedCache
+	id       []byte
+	etag     string
+	payload  []byte
+	lifecycle
+}
+
+// WithCoordinatedPollDataSource sets data source that polls API with the interval only from
+// the instance elected as a leader via the shared cache. Leadership expires after 3 intervals
+// without renewal. Instances fall back to the API if the cache fails or has no payload yet.
+func WithCoordinatedPollDataSource(interval time.Duration, cache SharedCache) ClientOption {
+	return func(c *Client) error {
+		c.data.dsFactory = func(c *Client) DataSource {
+			return newCoordinatedPollDataSource(c, interval, cache)
+		}
+		return nil
+	}
+}
+
+func newCoordinatedPollDataSource(client *Client, interval time.Duration, cache SharedCache) *CoordinatedPollDataSource {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return &CoordinatedPollDataSource{
+		client:   client,
+		interval: interval,
+		cache:    cache,
+		id:       []byte(hex.EncodeToString(id)),
+		logger:   client.logger.With("source", "Growthbook coordinated polling datasource"),
+	}
+}
+
+func (ds *CoordinatedPollDataSource) Start(ctx context.Context) error {
+	ctx, ok, err := ds.begin(ctx)
+	if !ok {
+		return err
+	}
+	ds.logger.Info("Starting")
+
+	err = ds.loadData(ctx)
+	if err != nil {
+		ds.end()
+		return err
+	}
+	ds.logger.Info("First load finished")
+
+	if err := ds.run(); err != nil {
+		return err
+	}
+	ds.client.data.spawn(func() { ds.startPolling(ctx) })
+	ds.logger.Info("Started")
+
+	return nil
+}
+
+func (ds *CoordinatedPollDataSource) Close() error {
+	if ds.end() {
+		ds.logger.Info("Closing")
+	}
+	return nil
+}
+
+func (ds *CoordinatedPollDataSource) startPolling(ctx context.Context) {
+	for {
+		timer := time.NewTimer(ds.interval)
+		select {
+		case <-ctx.Done():
+			ds.logger.Info("Finished polling due to context")
+			return
+		case <-timer.C:
+			err := ds.loadData(ctx)
+			if err != nil {
+				ds.logger.Error("Error loading features", "error", err)
+			}
+			if errors.Is(err, context.Canceled) {
+				ds.logger.Info("Finished polling due to context")
+				return
+			}
+		}
+	}
+}
+
+func (ds *CoordinatedPollDataSource) keyPrefix() string {
+	return "gb:" + ds.client.data.getApiUrl()
+}
+
+// elect acquires or renews leadership. Renewal isn't atomic, so two instances
+// may poll at the same time for a short while, which is harmless.
+func (ds *CoordinatedPollDataSource) elect(ctx context.Context) (bool, error) {
+	key := ds.keyPrefix() + ":leader"
+	ttl := 3 * ds.interval
+	ok, err := ds.cache.SetNX(ctx, key, ds.id, ttl)
+	if err != nil || ok {
+		return ok, err
+	}
+	leader, err := ds.cache.Get(ctx, key)
+	if err != nil || !bytes.Equal(leader, ds.id) {
+		return false, err
+	}
+	return true, ds.cache.Set(ctx, key, ds.id, ttl)
+}
+
+func (ds *CoordinatedPollDataSource) loadData(ctx context.Context) error {
+	leader, err := ds.elect(ctx)
+	if err != nil {
+		ds.logger.Warn("Shared cache error, loading from API", "error", err)
+		return ds.loadFromApi(ctx, false)
+	}
+	if leader {
+		return ds.loadFromApi(ctx, true)
+	}
+
+	cached, err := ds.cache.Get(ctx, ds.keyPrefix()+":payload")
+	if err != nil {
+		ds.logger.Warn("Shared cache error, loading from API", "error", err)
+		return ds.loadFromApi(ctx, false)
+	}
+	if cached == nil {
+		ds.logger.Info("No payload in shared cache yet, loading from API")
+		return ds.loadFromApi(ctx, false)
+	}
+	if bytes.Equal(cached, ds.payload) {
+		ds.client.markRefreshed()
+		return nil
+	}
+	payload, err := ds.client.data.decodeCachePayload(cached)
+	if err != nil {
+		ds.logger.Warn("Error decoding shared cache payload, loading from API", "error", err)
+		return ds.loadFromApi(ctx, false)
+	}
+	err = ds.client.updateFromApiResponseJSON(ctx, string(payload))
+	if err != nil {
+		return err
+	}
+	ds.payload = cached
+	ds.client.data.cacheBytes.Store(int64(len(cached)))
+	return nil
+}
+
+func (ds *CoordinatedPollDataSource) loadFromApi(ctx context.Context, leader bool) error {
+	resp, err := ds.client.CallFeatureApi(ctx, ds.etag)
+	if err != nil {
+		return err
+	}
+
+	if resp.Etag != "" {
+		ds.etag = resp.Etag
+	}
+
+	if resp.Status == http.StatusNotModified {
+		return nil
+	}
+
+	cached := resp.body
+	if leader {
+		cached = ds.writeCache(ctx, resp.body)
+	}
+
+	err = ds.client.updateFromApiResponse(ctx, resp)
+	if err != nil {
+		return err
+	}
+	ds.payload = cached
+	return nil
+}
+
+// writeCache stores payload in the shared cache, compressed if configured,
+// and returns the stored value.
+func (ds *CoordinatedPollDataSource) writeCache(ctx context.Context, payload []byte) []byte {
+	cached, err := ds.client.data.encodeCachePayload(payload)
+	if err == nil {
+		err = ds.cache.Set(ctx, ds.keyPrefix()+":payload", cached, 0)
+	}
+	if err != nil {
+		ds.logger.Warn("Error writing payload to shared cache", "error", err)
+		return payload
+	}
+	ds.client.data.cacheBytes.Store(int64(len(cached)))
+	return cached
+}
@@ -0,0 +1,105 @@
+package growthbook
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type memSharedCache struct {
+	mu     sync.Mutex
+	values map[string][]byte
+	fail   bool
+}
+
+func (c *memSharedCache) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.fail {
+		return nil, errors.New("cache is down")
+	}
+	return c.values[key], nil
+}
+
+func (c *memSharedCache) Set(_ context.Context, key string, value []byte, _ time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.fail {
+		return errors.New("cache is down")
+	}
+	c.values[key] = value
+	return nil
+}
+
+func (c *memSharedCache) SetNX(_ context.Context, key string, value []byte, _ time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.fail {
+		return false, errors.New("cache is down")
+	}
+	if _, ok := c.values[key]; ok {
+		return false, nil
+	}
+	c.values[key] = value
+	return true, nil
+}
+
+func TestCoordinatedPollDataSource(t *testing.T) {
+	ctx := context.TODO()
+	featuresJSON := []byte(`{"features": {"foo": {"defaultValue": "api"}}, "dateUpdated": "2000-05-01T00:00:12Z"}`)
+
+	newClient := func(ts *testServer, cache SharedCache) *Client {
+		logger, _ := testLogger(slog.LevelError, t)
+		client, err := NewClient(ctx,
+			WithLogger(logger),
+			WithHttpClient(ts.http.Client()),
+			WithApiHost(ts.http.URL),
+			WithClientKey("somekey"),
+			WithCoordinatedPollDataSource(10*time.Millisecond, cache),
+		)
+		require.Nil(t, err)
+		require.Nil(t, client.EnsureLoaded(ctx))
+		return client
+	}
+
+	t.Run("Only leader calls API", func(t *testing.T) {
+		ts := startServer(http.StatusOK, featuresJSON)
+		defer ts.http.Close()
+		cache := &memSharedCache{values: map[string][]byte{}}
+
+		leader := newClient(ts, cache)
+		defer leader.Close()
+		require.Equal(t, int32(1), ts.count.Load())
+
+		followers := make([]*Client, 5)
+		for i := range followers {
+			followers[i] = newClient(ts, cache)
+			defer followers[i].Close()
+		}
+		for _, f := range followers {
+			require.Equal(t, "api", f.EvalFeature(ctx, "foo").Value)
+		}
+		require.Equal(t, int32(1), ts.count.Load())
+
+		time.Sleep(55 * time.Millisecond)
+		count := ts.count.Load()
+		require.True(t, count > 2 && count < 10, count)
+	})
+
+	t.Run("Falls back to API when cache is down", func(t *testing.T) {
+		ts := startServer(http.StatusOK, featuresJSON)
+		defer ts.http.Close()
+		cache := &memSharedCache{values: map[string][]byte{}, fail: true}
+
+		client := newClient(ts, cache)
+		defer client.Close()
+		require.Equal(t, "api", client.EvalFeature(ctx, "foo").Value)
+		require.Equal(t, int32(1), ts.count.Load())
+	})
+}
@@ -12,7 +12,9 @@ var _ DataSource = &emptyDataSource{}
 
 func withEmptyDataSource() ClientOption {
 	return func(c *Client) error {
-		c.data.dataSource = newEmptyDataSource(c)
+		c.data.dsFactory = func(c *Client) DataSource {
+			return newEmptyDataSource(c)
+		}
 		return nil
 	}
 }
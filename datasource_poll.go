@@ -3,7 +3,6 @@ package growthbook
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log/slog"
 	"time"
 )
@@ -12,14 +11,15 @@ type PollDataSource struct {
 	client   *Client
 	logger   *slog.Logger
 	interval time.Duration
-	cancel   context.CancelFunc
-	ready    bool
 	etag     string
+	lifecycle
 }
 
 func WithPollDataSource(interval time.Duration) ClientOption {
 	return func(c *Client) error {
-		c.data.dataSource = newPollDataSource(c, interval)
+		c.data.dsFactory = func(c *Client) DataSource {
+			return newPollDataSource(c, interval)
+		}
 		return nil
 	}
 }
@@ -33,30 +33,32 @@ func newPollDataSource(client *Client, interval time.Duration) *PollDataSource {
 }
 
 func (ds *PollDataSource) Start(ctx context.Context) error {
+	ctx, ok, err := ds.begin(ctx)
+	if !ok {
+		return err
+	}
 	ds.logger.Info("Starting")
 
-	ctx, cancel := context.WithCancel(ctx)
-	ds.cancel = cancel
-
-	err := ds.loadData(ctx)
+	err = ds.loadData(ctx)
 	if err != nil {
+		ds.end()
 		return err
 	}
 	ds.logger.Info("First load finished")
 
-	ds.ready = true
-	go ds.startPolling(ctx)
+	if err := ds.run(); err != nil {
+		return err
+	}
+	ds.client.data.spawn(func() { ds.startPolling(ctx) })
 	ds.logger.Info("Started")
 
 	return nil
 }
 
 func (ds *PollDataSource) Close() error {
-	if !ds.ready {
-		return fmt.Errorf("Datasource is not ready")
+	if ds.end() {
+		ds.logger.Info("Closing")
 	}
-	ds.logger.Info("Closing")
-	ds.cancel()
 	return nil
 }
 
@@ -65,7 +67,6 @@ func (ds *PollDataSource) startPolling(ctx context.Context) {
 		timer := time.NewTimer(ds.interval)
 		select {
 		case <-ctx.Done():
-			ds.ready = false
 			ds.logger.Info("Finished polling due to context")
 			return
 		case <-timer.C:
@@ -95,7 +96,7 @@ func (ds *PollDataSource) loadData(ctx context.Context) error {
 		return nil
 	}
 
-	err = ds.client.UpdateFromApiResponse(resp)
+	err = ds.client.updateFromApiResponse(ctx, resp)
 	if err != nil {
 		return err
 	}
@@ -136,3 +136,85 @@ func startEtagServer(response []byte) *testServer {
 	}))
 	return &ts
 }
+
+func TestPollingDataSourceReconfigure(t *testing.T) {
+	ctx := context.TODO()
+	oldJSON := []byte(`{"features": {"foo": {"defaultValue": "old"}}, "dateUpdated": "2000-05-02T00:00:12Z"}`)
+	newJSON := []byte(`{"features": {"foo": {"defaultValue": "new"}}, "dateUpdated": "2000-05-01T00:00:12Z"}`)
+	oldServer := startServer(http.StatusOK, oldJSON)
+	defer oldServer.http.Close()
+	newServer := startServer(http.StatusOK, newJSON)
+	defer newServer.http.Close()
+
+	logger, _ := testLogger(slog.LevelError, t)
+	client, err := NewClient(ctx,
+		WithLogger(logger),
+		WithHttpClient(oldServer.http.Client()),
+		WithApiHost(oldServer.http.URL),
+		WithClientKey("oldkey"),
+		WithPollDataSource(10*time.Millisecond),
+	)
+	require.Nil(t, err)
+	require.Nil(t, client.EnsureLoaded(ctx))
+	require.Equal(t, "old", client.EvalFeature(ctx, "foo").Value)
+
+	err = client.Reconfigure(ctx,
+		WithHttpClient(newServer.http.Client()),
+		WithApiHost(newServer.http.URL),
+		WithClientKey("newkey"),
+	)
+	require.Nil(t, err)
+	require.Nil(t, client.EnsureLoaded(ctx))
+	require.Equal(t, "new", client.EvalFeature(ctx, "foo").Value)
+	require.Equal(t, newServer.http.URL+"/api/features/newkey", client.data.getApiUrl())
+
+	oldCount := oldServer.count.Load()
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, oldCount, oldServer.count.Load())
+	require.Nil(t, client.Close())
+}
+
+func TestPollingDataSourceReconfigureLightweight(t *testing.T) {
+	ctx := context.TODO()
+	oldJSON := []byte(`{"features": {"foo": {"defaultValue": "old"}}, "dateUpdated": "2000-05-01T00:00:12Z"}`)
+	newJSON := []byte(`{"features": {"foo": {"defaultValue": "new"}}, "dateUpdated": "2000-05-01T00:00:12Z"}`)
+	oldServer := startServer(http.StatusOK, oldJSON)
+	defer oldServer.http.Close()
+	newServer := startServer(http.StatusOK, newJSON)
+	defer newServer.http.Close()
+
+	logger, _ := testLogger(slog.LevelError, t)
+	client, err := NewClient(ctx,
+		WithLogger(logger),
+		WithApiHost(oldServer.http.URL),
+		WithClientKey("oldkey"),
+		WithPollDataSource(10*time.Millisecond),
+	)
+	require.Nil(t, err)
+	require.Nil(t, client.EnsureLoaded(ctx))
+
+	require.Nil(t, client.Reconfigure(ctx, WithClientKey("lightkey"), WithLightweightMode(time.Minute, nil)))
+	require.Nil(t, client.EnsureLoaded(ctx))
+	lightKey := lightweightKey(oldServer.http.URL + "/api/features/lightkey")
+	cached, _ := lightweightCache.Get(ctx, lightKey)
+	require.NotNil(t, cached)
+
+	require.Nil(t, client.Reconfigure(ctx,
+		WithApiHost(newServer.http.URL),
+		WithClientKey("newkey"),
+		WithPollDataSource(10*time.Millisecond),
+	))
+	require.Nil(t, client.EnsureLoaded(ctx))
+	require.Equal(t, "new", client.EvalFeature(ctx, "foo").Value)
+	require.Nil(t, client.data.light.Load())
+
+	// Cached payload of the old key is dropped
+	cached, _ = lightweightCache.Get(ctx, lightKey)
+	require.Nil(t, cached)
+
+	// Switching back and forth doesn't close start wait channel twice
+	require.Nil(t, client.Reconfigure(ctx, WithLightweightMode(time.Minute, nil)))
+	require.Nil(t, client.Reconfigure(ctx, WithPollDataSource(10*time.Millisecond)))
+	require.Nil(t, client.EnsureLoaded(ctx))
+	require.Nil(t, client.Close())
+}
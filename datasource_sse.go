@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/tmaxmax/go-sse"
@@ -12,10 +14,20 @@ import (
 
 type SseDataSource struct {
 	client *Client
-	cancel context.CancelFunc
-	ready  bool
 	retry  time.Duration
 	logger *slog.Logger
+	mu     sync.Mutex
+	// Last full payload, "features-patch" events are applied to it
+	payload []byte
+	// Latest payload waiting to be applied, intermediate ones are dropped
+	pending []byte
+	// Features are reloaded from the API as patches couldn't be applied
+	reload bool
+	// Updates are applied by a background goroutine
+	processing bool
+	// ETag of the last revalidation request
+	etag string
+	lifecycle
 }
 
 const minbufsize = 64 * 1024
@@ -23,7 +35,26 @@ const maxbufsize = 10 * 1024 * 1024
 
 func WithSseDataSource() ClientOption {
 	return func(c *Client) error {
-		c.data.dataSource = newSseDataSource(c)
+		c.data.dsFactory = func(c *Client) DataSource {
+			return newSseDataSource(c)
+		}
+		return nil
+	}
+}
+
+// DefaultSseStreamPath is the stream path of GrowthBook Cloud and GrowthBook Proxy.
+const DefaultSseStreamPath = "/sub/{clientKey}"
+
+// WithSseStreamPath sets path and query of the SSE stream relative to the API host,
+// for proxies serving the stream on another path. The {clientKey} placeholder is
+// replaced with the client key, e.g. "/sub/{clientKey}?stream=features".
+// If the features API redirects to another host, the stream is read from that host.
+func WithSseStreamPath(path string) ClientOption {
+	return func(c *Client) error {
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("SSE stream path %q must start with /", path)
+		}
+		c.data.ssePath = path
 		return nil
 	}
 }
@@ -36,67 +67,137 @@ func newSseDataSource(client *Client) *SseDataSource {
 }
 
 func (ds *SseDataSource) Start(ctx context.Context) error {
+	ctx, ok, err := ds.begin(ctx)
+	if !ok {
+		return err
+	}
 	ds.logger.Info("Starting")
 
-	ctx, cancel := context.WithCancel(ctx)
-	ds.cancel = cancel
-
-	err := ds.loadData(ctx)
+	err = ds.loadData(ctx)
 	if err != nil {
+		ds.end()
 		return err
 	}
 	ds.logger.Info("First load finished")
 
-	ds.ready = true
-	go ds.connect(ctx)
+	if err := ds.run(); err != nil {
+		return err
+	}
+	ds.client.data.spawn(func() { ds.connect(ctx) })
+	if interval := ds.client.data.revalidateInterval(); interval > 0 {
+		ds.client.data.spawn(func() { ds.revalidate(ctx, interval) })
+	}
 	ds.logger.Info("Started")
 
 	return nil
 }
 
 func (ds *SseDataSource) Close() error {
-	if !ds.ready {
-		return fmt.Errorf("Datasource is not ready")
+	if ds.end() {
+		ds.logger.Info("Closing")
 	}
-	ds.logger.Info("Closing")
-	ds.cancel()
 	return nil
 }
 
+// connect subscribes to the stream shared with other clients of the same stream URL,
+// until the data source is closed or the connection fails.
 func (ds *SseDataSource) connect(ctx context.Context) error {
-	sseUrl := ds.client.data.getSseUrl()
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sseUrl, http.NoBody)
+	unsubscribe, done, err := sseStreams.subscribe(ctx, ds)
 	if err != nil {
+		ds.logger.Error("Error creating SSE request", "error", err)
 		return err
 	}
-
-	ds.setReqHeaders(req)
-	sseClient := &sse.Client{
-		HTTPClient: ds.client.data.httpClient,
-		OnRetry:    ds.onRetry,
+	defer unsubscribe()
+	select {
+	case <-ctx.Done():
+	case <-done:
 	}
-	sseConn := sseClient.NewConnection(req)
-	buf := make([]byte, minbufsize)
-	sseConn.Buffer(buf, maxbufsize)
-	sseConn.SubscribeEvent("features", func(event sse.Event) {
-		ds.processEvent(event)
-	})
-	sseConn.Connect()
 	return nil
 }
 
+// onRetry logs failed connections, proxies or TLS misconfiguration show up here.
 func (ds *SseDataSource) onRetry(err error, delay time.Duration) {
-	ds.logger.Info("Reconnect", "reason", err, "delay", delay)
+	ds.logger.Warn("Reconnect", "reason", err, "delay", delay)
 }
 
-func (ds *SseDataSource) processEvent(event sse.Event) {
+// processEvent queues the payload to be applied. Events are read without waiting for
+// updates to apply, so a slow consumer doesn't block the stream: only the latest
+// payload is kept and intermediate ones are dropped.
+func (ds *SseDataSource) processEvent(ctx context.Context, event sse.Event) {
 	if event.Data == "" {
 		return
 	}
-	ds.logger.Info("Updating features")
-	err := ds.client.UpdateFromApiResponseJSON(event.Data)
-	if err != nil {
-		ds.logger.Error("Error updating features", "error", err)
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	ds.payload = []byte(event.Data)
+	ds.enqueue(ctx, ds.payload, false)
+}
+
+// processPatchEvent applies RFC 6902 JSON Patch to the last full payload and queues
+// the result. Falls back to full payload reload if the patch can't be applied.
+func (ds *SseDataSource) processPatchEvent(ctx context.Context, event sse.Event) {
+	if event.Data == "" {
+		return
+	}
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	var err error
+	if ds.payload == nil {
+		err = fmt.Errorf("No payload to patch")
+	} else {
+		var patched []byte
+		patched, err = applyJsonPatch(ds.payload, []byte(event.Data))
+		if err == nil {
+			ds.payload = patched
+			ds.enqueue(ctx, patched, false)
+			return
+		}
+	}
+
+	ds.logger.Warn("Error patching features, reloading", "error", err)
+	ds.payload = nil
+	ds.enqueue(ctx, nil, true)
+}
+
+// enqueue replaces the pending update and starts the goroutine applying updates
+// if it isn't running. Must be called with ds.mu held.
+func (ds *SseDataSource) enqueue(ctx context.Context, payload []byte, reload bool) {
+	if ds.pending != nil || ds.reload {
+		ds.client.data.dropped.Add(1)
+	}
+	ds.pending, ds.reload = payload, reload
+	if ds.processing {
+		return
+	}
+	ds.processing = true
+	ds.client.data.spawn(func() { ds.process(ctx) })
+}
+
+// process applies pending updates until there are none left.
+func (ds *SseDataSource) process(ctx context.Context) {
+	for {
+		ds.mu.Lock()
+		payload, reload := ds.pending, ds.reload
+		ds.pending, ds.reload = nil, false
+		if ctx.Err() != nil || (payload == nil && !reload) {
+			ds.processing = false
+			ds.mu.Unlock()
+			return
+		}
+		ds.mu.Unlock()
+
+		if reload {
+			err := ds.loadData(ctx)
+			if err != nil {
+				ds.logger.Error("Error reloading features", "error", err)
+			}
+			continue
+		}
+		ds.logger.Info("Updating features")
+		err := ds.client.updateFromApiResponseJSON(ctx, string(payload))
+		if err != nil {
+			ds.logger.Error("Error updating features", "error", err)
+		}
 	}
 }
 
@@ -114,11 +215,61 @@ func (ds *SseDataSource) loadData(ctx context.Context) error {
 		return nil
 	}
 
-	err = ds.client.UpdateFromApiResponse(resp)
+	err = ds.client.updateFromApiResponse(ctx, resp)
 	if err != nil {
 		return err
 	}
+	ds.mu.Lock()
+	// Keep the newer payload streamed while loading
+	if ds.payload == nil {
+		ds.payload = resp.body
+	}
+	ds.mu.Unlock()
+
+	return nil
+}
+
+// revalidate checks features with conditional API requests while the stream is quiet,
+// so the payload exceeds max age only when neither the stream nor the API deliver it.
+func (ds *SseDataSource) revalidate(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if ds.client.PayloadAge() < interval {
+				continue
+			}
+			if err := ds.revalidateData(ctx); err != nil && ctx.Err() == nil {
+				ds.logger.Warn("Error revalidating features", "error", err)
+			}
+		}
+	}
+}
 
+func (ds *SseDataSource) revalidateData(ctx context.Context) error {
+	resp, err := ds.client.CallFeatureApi(ctx, ds.etag)
+	if err != nil {
+		return err
+	}
+	if resp.Etag != "" {
+		ds.etag = resp.Etag
+	}
+	if resp.Features == nil && resp.EncryptedFeatures == "" {
+		return nil
+	}
+	if err := ds.client.updateFromApiResponse(ctx, resp); err != nil {
+		return err
+	}
+	ds.mu.Lock()
+	// Patches apply to the revalidated payload, unless it was older than the current
+	// one or a streamed payload is being applied
+	if !ds.processing && !ds.client.data.getDateUpdated().After(resp.DateUpdated) {
+		ds.payload = resp.body
+	}
+	ds.mu.Unlock()
 	return nil
 }
 
@@ -0,0 +1,105 @@
+package growthbook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/tmaxmax/go-sse"
+)
+
+// sseEventHandlers process events GrowthBook streams, keyed by SSE event type.
+var sseEventHandlers = map[string]func(ds *SseDataSource, ctx context.Context, event sse.Event){
+	"features":       (*SseDataSource).processEvent,
+	"features-patch": (*SseDataSource).processPatchEvent,
+	"saved-groups":   (*SseDataSource).processSavedGroupsEvent,
+}
+
+// SseEventHandler handles data of SSE events of a type the SDK doesn't process,
+// e.g. added by a newer GrowthBook version. Handlers are called while the stream
+// is read, so they must not block.
+type SseEventHandler func(ctx context.Context, client *Client, data []byte)
+
+// WithSseEventHandler sets handler of SSE events of the type. Types processed
+// by the SDK, "features", "features-patch" and "saved-groups", can't be handled.
+// Events of types without handler are ignored.
+func WithSseEventHandler(eventType string, handler SseEventHandler) ClientOption {
+	return func(c *Client) error {
+		if _, ok := sseEventHandlers[eventType]; ok {
+			return fmt.Errorf("SSE event type %q is processed by the SDK", eventType)
+		}
+		handlers := maps.Clone(c.sseHandlers)
+		if handlers == nil {
+			handlers = map[string]SseEventHandler{}
+		}
+		handlers[eventType] = handler
+		c.sseHandlers = handlers
+		return nil
+	}
+}
+
+// dispatchEvent passes the event to the handler of its type. Events of unknown types
+// are ignored, so GrowthBook can add event types without breaking older SDKs.
+func (ds *SseDataSource) dispatchEvent(ctx context.Context, event sse.Event) {
+	if process, ok := sseEventHandlers[event.Type]; ok {
+		process(ds, ctx, event)
+		return
+	}
+	if handler, ok := ds.client.sseHandlers[event.Type]; ok {
+		ds.client.callback(ctx, "sseEvent", func() { handler(ctx, ds.client, []byte(event.Data)) })
+		return
+	}
+	ds.logger.Debug("Ignoring SSE event of unknown type", "type", event.Type)
+}
+
+// processSavedGroupsEvent replaces saved groups of the last full payload with ones of
+// the event, {"savedGroups": {...}, "dateUpdated": "..."}, and queues the result.
+// Falls back to full payload reload if the event can't be applied.
+func (ds *SseDataSource) processSavedGroupsEvent(ctx context.Context, event sse.Event) {
+	if event.Data == "" {
+		return
+	}
+	ds.mu.Lock()
+	defer ds.mu.Unlock()
+	var err error
+	if ds.payload == nil {
+		err = errors.New("No payload to update")
+	} else {
+		var updated []byte
+		updated, err = mergeSavedGroups(ds.payload, []byte(event.Data))
+		if err == nil {
+			ds.payload = updated
+			ds.enqueue(ctx, updated, false)
+			return
+		}
+	}
+
+	ds.logger.Warn("Error updating saved groups, reloading", "error", err)
+	ds.payload = nil
+	ds.enqueue(ctx, nil, true)
+}
+
+// mergeSavedGroups replaces saved groups and update date of the payload with ones of the update.
+func mergeSavedGroups(payload []byte, update []byte) ([]byte, error) {
+	var p map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, err
+	}
+	var u struct {
+		SavedGroups json.RawMessage `json:"savedGroups"`
+		DateUpdated json.RawMessage `json:"dateUpdated"`
+	}
+	if err := json.Unmarshal(update, &u); err != nil {
+		return nil, err
+	}
+	if u.SavedGroups == nil {
+		return nil, errors.New("No saved groups in the update")
+	}
+	p["savedGroups"] = u.SavedGroups
+	if u.DateUpdated != nil {
+		p["dateUpdated"] = u.DateUpdated
+	}
+	return json.Marshal(p)
+}
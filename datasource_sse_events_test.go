@@ -0,0 +1,44 @@
+package growthbook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmaxmax/go-sse"
+)
+
+func TestSseEventDispatch(t *testing.T) {
+	ctx := context.TODO()
+	var custom []string
+	client, err := NewClient(ctx, WithSseEventHandler("experiments", func(_ context.Context, _ *Client, data []byte) {
+		custom = append(custom, string(data))
+	}))
+	require.Nil(t, err)
+	ds := newSseDataSource(client)
+	ds.processing = true
+
+	ds.dispatchEvent(ctx, sse.Event{Type: "features", Data: `{"features": {"beta": {"defaultValue": false, "rules": [{"condition": {"id": {"$inGroup": "staff"}}, "force": true}]}}, "savedGroups": {"staff": ["1"]}, "dateUpdated": "2000-05-01T00:00:00Z"}`})
+	ds.process(ctx)
+	staff, err := client.WithAttributes(Attributes{"id": "2"})
+	require.Nil(t, err)
+	require.False(t, staff.EvalFeature(ctx, "beta").On)
+
+	ds.processing = true
+	ds.dispatchEvent(ctx, sse.Event{Type: "saved-groups", Data: `{"savedGroups": {"staff": ["1", "2"]}, "dateUpdated": "2000-05-02T00:00:00Z"}`})
+	ds.dispatchEvent(ctx, sse.Event{Type: "unknown", Data: `{"features": {}}`})
+	ds.dispatchEvent(ctx, sse.Event{Type: "experiments", Data: `{"a": 1}`})
+	ds.process(ctx)
+	require.True(t, staff.EvalFeature(ctx, "beta").On)
+	require.Len(t, client.Features(), 1)
+	require.Equal(t, []string{`{"a": 1}`}, custom)
+
+	// Invalid update falls back to reload
+	ds.processing = true
+	ds.dispatchEvent(ctx, sse.Event{Type: "saved-groups", Data: `{"dateUpdated": "2000-05-03T00:00:00Z"}`})
+	require.True(t, ds.reload)
+	require.Nil(t, ds.payload)
+
+	_, err = NewClient(ctx, WithSseEventHandler("features", func(context.Context, *Client, []byte) {}))
+	require.Error(t, err)
+}
@@ -0,0 +1,123 @@
+package growthbook
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/tmaxmax/go-sse"
+)
+
+// sseStreams shares SSE connections between data sources of clients streaming
+// the same URL with the same HTTP client, so clients of one binary using the same
+// client key hold a single connection. Streams of different keys to one host are
+// multiplexed by HTTP/2 of the default transport where the server supports it.
+var sseStreams = sseHub{streams: map[sseStreamKey]*sseStream{}}
+
+type sseStreamKey struct {
+	url        string
+	httpClient *http.Client
+}
+
+type sseHub struct {
+	mu      sync.Mutex
+	streams map[sseStreamKey]*sseStream
+}
+
+// sseStream is a connection that dispatches events to the subscribed data sources.
+type sseStream struct {
+	cancel context.CancelFunc
+	// Closed when the connection fails for good or is closed
+	done        chan struct{}
+	mu          sync.Mutex
+	subscribers map[*SseDataSource]context.Context
+}
+
+// subscribe adds data source to the stream of its client, connecting if there is none.
+// Events are processed with ctx until unsubscribe is called. Returned channel is
+// closed if the connection fails and won't be retried.
+func (h *sseHub) subscribe(ctx context.Context, ds *SseDataSource) (unsubscribe func(), done <-chan struct{}, err error) {
+	key := sseStreamKey{ds.client.data.getSseUrl(), ds.client.data.getHttpClient()}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s := h.streams[key]
+	if s == nil {
+		streamCtx, cancel := context.WithCancel(context.Background())
+		req, err := http.NewRequestWithContext(streamCtx, http.MethodGet, key.url, http.NoBody)
+		if err != nil {
+			cancel()
+			return nil, nil, err
+		}
+		ds.setReqHeaders(req)
+		s = &sseStream{
+			cancel:      cancel,
+			done:        make(chan struct{}),
+			subscribers: map[*SseDataSource]context.Context{},
+		}
+		h.streams[key] = s
+		go func() {
+			s.run(streamCtx, req, key.httpClient)
+			h.remove(key, s)
+		}()
+	}
+	s.mu.Lock()
+	s.subscribers[ds] = ctx
+	s.mu.Unlock()
+
+	unsubscribe = func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		s.mu.Lock()
+		delete(s.subscribers, ds)
+		last := len(s.subscribers) == 0
+		s.mu.Unlock()
+		if last {
+			s.cancel()
+			if h.streams[key] == s {
+				delete(h.streams, key)
+			}
+		}
+	}
+	return unsubscribe, s.done, nil
+}
+
+// remove forgets the stream after its connection is finished.
+func (h *sseHub) remove(key sseStreamKey, s *sseStream) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.streams[key] == s {
+		delete(h.streams, key)
+	}
+}
+
+func (s *sseStream) run(ctx context.Context, req *http.Request, httpClient *http.Client) {
+	defer close(s.done)
+	sseClient := &sse.Client{
+		HTTPClient: httpClient,
+		OnRetry: func(err error, delay time.Duration) {
+			s.each(func(ds *SseDataSource, _ context.Context) { ds.onRetry(err, delay) })
+		},
+	}
+	sseConn := sseClient.NewConnection(req)
+	buf := make([]byte, minbufsize)
+	sseConn.Buffer(buf, maxbufsize)
+	sseConn.SubscribeToAll(func(event sse.Event) {
+		s.each(func(ds *SseDataSource, ctx context.Context) { ds.dispatchEvent(ctx, event) })
+	})
+	err := sseConn.Connect()
+	if err != nil && ctx.Err() == nil {
+		s.each(func(ds *SseDataSource, _ context.Context) { ds.logger.Error("SSE connection failed", "error", err) })
+	}
+}
+
+// each calls f for every subscriber. Data source event processing doesn't block,
+// so a slow client doesn't delay others.
+func (s *sseStream) each(f func(ds *SseDataSource, ctx context.Context)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ds, ctx := range s.subscribers {
+		f(ds, ctx)
+	}
+}
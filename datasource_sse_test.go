@@ -2,15 +2,19 @@ package growthbook
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/tmaxmax/go-sse"
 )
 
 func TestSseDataSource(t *testing.T) {
@@ -70,6 +74,72 @@ func TestSseDataSource(t *testing.T) {
 		require.Nil(t, err)
 	})
 
+	t.Run("Apply features patch", func(t *testing.T) {
+		patch := `[{"op": "replace", "path": "/features/foo/defaultValue", "value": "patched"}, {"op": "replace", "path": "/dateUpdated", "value": "2000-05-03T00:00:12Z"}]`
+		ts := startSseServer(featuresJSON, sseEvents(10*time.Millisecond,
+			fmt.Sprintf("event: features\ndata: %s\n\n", features2JSON),
+			fmt.Sprintf("event: features-patch\ndata: %s\n\n", patch),
+		))
+		defer ts.http.Close()
+		logger, _ := testLogger(slog.LevelWarn, t)
+		client, err := NewClient(ctx,
+			WithLogger(logger),
+			WithHttpClient(ts.http.Client()),
+			WithApiHost(ts.http.URL),
+			WithClientKey("somekey"),
+			WithSseDataSource(),
+		)
+		require.Nil(t, err)
+		require.Nil(t, client.EnsureLoaded(ctx))
+		time.Sleep(100 * time.Millisecond)
+		require.Equal(t, FeatureMap{"foo": &Feature{DefaultValue: "patched"}}, client.Features())
+		require.Equal(t, int32(1), ts.apicount.Load())
+		require.Nil(t, client.Close())
+	})
+
+	t.Run("Reload features on patch failure", func(t *testing.T) {
+		patch := `[{"op": "remove", "path": "/features/missing"}]`
+		ts := startSseServer(featuresJSON, sseEvents(10*time.Millisecond,
+			fmt.Sprintf("event: features\ndata: %s\n\n", features2JSON),
+			fmt.Sprintf("event: features-patch\ndata: %s\n\n", patch),
+		))
+		defer ts.http.Close()
+		logger, _ := testLogger(slog.LevelError, t)
+		client, err := NewClient(ctx,
+			WithLogger(logger),
+			WithHttpClient(ts.http.Client()),
+			WithApiHost(ts.http.URL),
+			WithClientKey("somekey"),
+			WithSseDataSource(),
+		)
+		require.Nil(t, err)
+		require.Nil(t, client.EnsureLoaded(ctx))
+		time.Sleep(100 * time.Millisecond)
+		require.Equal(t, int32(2), ts.apicount.Load())
+		require.Nil(t, client.Close())
+	})
+
+	t.Run("Stream over TLS with custom CA", func(t *testing.T) {
+		ts := startTLSSseServer(featuresJSON, sseResponse(features2JSON, 10*time.Millisecond, 0))
+		defer ts.http.Close()
+		roots := x509.NewCertPool()
+		roots.AddCert(ts.http.Certificate())
+		logger, _ := testLogger(slog.LevelWarn, t)
+		client, err := NewClient(ctx,
+			WithLogger(logger),
+			WithTLSConfig(&tls.Config{RootCAs: roots}),
+			WithApiHost(ts.http.URL),
+			WithClientKey("somekey"),
+			WithSseDataSource(),
+		)
+		require.Nil(t, err)
+		require.Nil(t, client.EnsureLoaded(ctx))
+		time.Sleep(100 * time.Millisecond)
+		require.Equal(t, features2, client.Features())
+		require.Equal(t, int32(1), ts.ssecount.Load())
+		require.Nil(t, client.Close())
+	})
+
 	t.Run("Don't reconnect after closing client", func(t *testing.T) {
 		ts := startSseServer(featuresJSON, sseResponse(features2JSON, 10*time.Millisecond, 3))
 		defer ts.http.Close()
@@ -90,6 +160,111 @@ func TestSseDataSource(t *testing.T) {
 	})
 }
 
+func TestSseDataSourceCoalescesUpdates(t *testing.T) {
+	ctx := context.TODO()
+	client, err := NewClient(ctx)
+	require.Nil(t, err)
+	ds := newSseDataSource(client)
+	// Simulate a goroutine busy applying the previous update
+	ds.processing = true
+	for i := 1; i <= 3; i++ {
+		data := fmt.Sprintf(`{"features": {"foo": {"defaultValue": %d}}, "dateUpdated": "2000-05-0%dT00:00:00Z"}`, i, i)
+		ds.processEvent(ctx, sse.Event{Type: "features", Data: data})
+	}
+	ds.processPatchEvent(ctx, sse.Event{Type: "features-patch", Data: `[{"op": "replace", "path": "/features/foo/defaultValue", "value": 4}]`})
+	require.Equal(t, int64(3), client.Stats().DroppedUpdates)
+	require.Empty(t, client.Features())
+
+	ds.process(ctx)
+	require.Equal(t, FeatureMap{"foo": &Feature{DefaultValue: 4.0}}, client.Features())
+	require.False(t, ds.processing)
+}
+
+func TestSseDataSourcePatchesRevalidatedPayload(t *testing.T) {
+	ctx := context.TODO()
+	ts := startServer(http.StatusOK, []byte(`{"features": {"foo": {"defaultValue": 1}, "bar": {"defaultValue": 2}}, "dateUpdated": "2000-05-02T00:00:00Z"}`))
+	defer ts.http.Close()
+	client, err := NewClient(ctx, WithApiHost(ts.http.URL), WithClientKey("somekey"), WithHttpClient(ts.http.Client()))
+	require.Nil(t, err)
+	ds := newSseDataSource(client)
+	// Updates are applied by the test instead of a background goroutine
+	ds.processing = true
+	ds.processEvent(ctx, sse.Event{Type: "features", Data: `{"features": {"foo": {"defaultValue": 0}}, "dateUpdated": "2000-05-01T00:00:00Z"}`})
+	ds.process(ctx)
+
+	require.Nil(t, ds.revalidateData(ctx))
+	require.Equal(t, 2.0, client.EvalFeature(ctx, "bar").Value)
+
+	// Patch is applied to the revalidated payload, keeping its newer features
+	ds.processing = true
+	ds.processPatchEvent(ctx, sse.Event{Type: "features-patch", Data: `[{"op": "replace", "path": "/features/foo/defaultValue", "value": 3}]`})
+	ds.process(ctx)
+	require.Equal(t, 3.0, client.EvalFeature(ctx, "foo").Value)
+	require.Equal(t, 2.0, client.EvalFeature(ctx, "bar").Value)
+}
+
+func TestSseDataSourceSharesStream(t *testing.T) {
+	ctx := context.TODO()
+	featuresJSON := []byte(`{"features": {"foo": {"defaultValue": "api"}}, "dateUpdated": "2000-05-01T00:00:12Z"}`)
+	features2JSON := `{"features": {"foo": {"defaultValue": "SSE"}}, "dateUpdated": "2000-05-02T00:00:12Z"}`
+	ts := startSseServer(featuresJSON, sseEvents(50*time.Millisecond, fmt.Sprintf("event: features\ndata: %s\n\n", features2JSON)))
+	defer ts.http.Close()
+
+	newClient := func() *Client {
+		client, err := NewClient(ctx,
+			WithHttpClient(ts.http.Client()),
+			WithApiHost(ts.http.URL),
+			WithClientKey("somekey"),
+			WithSseDataSource(),
+		)
+		require.Nil(t, err)
+		require.Nil(t, client.EnsureLoaded(ctx))
+		return client
+	}
+	client1, client2 := newClient(), newClient()
+	features2 := FeatureMap{"foo": &Feature{DefaultValue: "SSE"}}
+	require.Eventually(t, func() bool {
+		return reflect.DeepEqual(features2, client1.Features()) && reflect.DeepEqual(features2, client2.Features())
+	}, time.Second, time.Millisecond)
+	require.Equal(t, int32(1), ts.ssecount.Load())
+
+	require.Nil(t, client1.Close())
+	require.Nil(t, client2.Close())
+	require.Eventually(t, func() bool {
+		sseStreams.mu.Lock()
+		defer sseStreams.mu.Unlock()
+		return len(sseStreams.streams) == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestSseStreamUrl(t *testing.T) {
+	client, err := NewClient(context.TODO(), WithApiHost("https://gb.example.com"), WithClientKey("sdk-1"))
+	require.Nil(t, err)
+	require.Equal(t, "https://gb.example.com/sub/sdk-1", client.data.getSseUrl())
+
+	client, err = NewClient(context.TODO(),
+		WithApiHost("https://gb.example.com"),
+		WithClientKey("sdk-1"),
+		WithSseStreamPath("/sub/{clientKey}?stream=features"),
+	)
+	require.Nil(t, err)
+	require.Equal(t, "https://gb.example.com/sub/sdk-1?stream=features", client.data.getSseUrl())
+
+	// Features served by a proxy after redirect
+	proxy := startSseServer([]byte(`{"features": {}}`), sseEvents(time.Millisecond))
+	defer proxy.http.Close()
+	api := httptest.NewServer(http.RedirectHandler(proxy.http.URL+"/api/features/somekey", http.StatusFound))
+	defer api.Close()
+	client, err = NewClient(context.TODO(), WithApiHost(api.URL), WithClientKey("somekey"))
+	require.Nil(t, err)
+	_, err = client.CallFeatureApi(context.TODO(), "")
+	require.Nil(t, err)
+	require.Equal(t, proxy.http.URL+"/sub/somekey", client.data.getSseUrl())
+
+	_, err = NewClient(context.TODO(), WithSseStreamPath("sub/{clientKey}"))
+	require.NotNil(t, err)
+}
+
 type sseTestServer struct {
 	http     *httptest.Server
 	ssecount atomic.Int32
@@ -99,8 +274,20 @@ type sseTestServer struct {
 type sseResponseGen func(context.Context, http.ResponseWriter)
 
 func startSseServer(apiResponse []byte, sseResponseGen sseResponseGen) *sseTestServer {
+	ts := newSseServer(apiResponse, sseResponseGen)
+	ts.http.Start()
+	return ts
+}
+
+func startTLSSseServer(apiResponse []byte, sseResponseGen sseResponseGen) *sseTestServer {
+	ts := newSseServer(apiResponse, sseResponseGen)
+	ts.http.StartTLS()
+	return ts
+}
+
+func newSseServer(apiResponse []byte, sseResponseGen sseResponseGen) *sseTestServer {
 	var ts sseTestServer
-	ts.http = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	ts.http = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case "/api/features/somekey":
 			w.Header().Add("x-sse-support", "enabled")
@@ -148,3 +335,22 @@ func sseResponse(response string, delay time.Duration, lim int) sseResponseGen {
 		}
 	}
 }
+
+// sseEvents sends events one by one and keeps connection open.
+func sseEvents(delay time.Duration, events ...string) sseResponseGen {
+	return func(ctx context.Context, w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		flusher := w.(http.Flusher)
+		flusher.Flush()
+		for _, event := range events {
+			select {
+			case <-time.After(delay):
+				w.Write([]byte(event))
+				flusher.Flush()
+			case <-ctx.Done():
+				return
+			}
+		}
+		<-ctx.Done()
+	}
+}
@@ -0,0 +1,106 @@
+package growthbook
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrDataSourceClosed is returned by Start of a data source that is closed.
+var ErrDataSourceClosed = errors.New("Data source is closed")
+
+// DataSourceState is a lifecycle state of a data source. States change only
+// forward: New -> Starting -> Running -> Closed. Close is allowed in any state.
+type DataSourceState int32
+
+const (
+	DataSourceNew DataSourceState = iota
+	DataSourceStarting
+	DataSourceRunning
+	DataSourceClosed
+)
+
+func (s DataSourceState) String() string {
+	switch s {
+	case DataSourceNew:
+		return "new"
+	case DataSourceStarting:
+		return "starting"
+	case DataSourceRunning:
+		return "running"
+	case DataSourceClosed:
+		return "closed"
+	}
+	return "unknown"
+}
+
+// lifecycle is the state machine of built-in data sources. Transitions are
+// idempotent: repeated Start and Close calls are no-ops, Close before Start
+// makes Start fail with [ErrDataSourceClosed].
+type lifecycle struct {
+	mu     sync.Mutex
+	state  DataSourceState
+	cancel context.CancelFunc
+}
+
+// State returns the current state of the data source.
+func (l *lifecycle) State() DataSourceState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.state
+}
+
+// begin moves New data source to Starting and returns context canceled on close.
+// ok is false if the data source was already started, err is set if it's closed.
+func (l *lifecycle) begin(ctx context.Context) (_ context.Context, ok bool, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	switch l.state {
+	case DataSourceNew:
+		ctx, l.cancel = context.WithCancel(ctx)
+		l.state = DataSourceStarting
+		return ctx, true, nil
+	case DataSourceClosed:
+		return nil, false, ErrDataSourceClosed
+	}
+	return nil, false, nil
+}
+
+// run moves Starting data source to Running. Returns [ErrDataSourceClosed]
+// if the data source was closed while starting.
+func (l *lifecycle) run() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.state != DataSourceStarting {
+		return ErrDataSourceClosed
+	}
+	l.state = DataSourceRunning
+	return nil
+}
+
+// end moves data source to Closed and cancels its context.
+// Reports whether the data source wasn't closed before.
+func (l *lifecycle) end() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.state == DataSourceClosed {
+		return false
+	}
+	l.state = DataSourceClosed
+	if l.cancel != nil {
+		l.cancel()
+	}
+	return true
+}
+
+// DataSourceState returns state of the client data source, or [DataSourceNew]
+// if there is none or it doesn't report its state.
+func (client *Client) DataSourceState() DataSourceState {
+	client.data.mu.RLock()
+	ds := client.data.dataSource
+	client.data.mu.RUnlock()
+	if s, ok := ds.(interface{ State() DataSourceState }); ok {
+		return s.State()
+	}
+	return DataSourceNew
+}
@@ -0,0 +1,60 @@
+package growthbook
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceState(t *testing.T) {
+	ctx := context.TODO()
+	ts := startServer(http.StatusOK, []byte(`{"features": {"foo": {"defaultValue": "api"}}}`))
+	defer ts.http.Close()
+	client, err := NewClient(ctx, WithHttpClient(ts.http.Client()), WithApiHost(ts.http.URL), WithClientKey("somekey"))
+	require.Nil(t, err)
+
+	t.Run("start, restart and double close", func(t *testing.T) {
+		ds := newPollDataSource(client, time.Hour)
+		require.Equal(t, DataSourceNew, ds.State())
+		require.Nil(t, ds.Start(ctx))
+		require.Equal(t, DataSourceRunning, ds.State())
+		require.Nil(t, ds.Start(ctx))
+		require.Nil(t, ds.Close())
+		require.Nil(t, ds.Close())
+		require.Equal(t, DataSourceClosed, ds.State())
+		require.ErrorIs(t, ds.Start(ctx), ErrDataSourceClosed)
+	})
+
+	t.Run("close before start", func(t *testing.T) {
+		ds := newSseDataSource(client)
+		require.Nil(t, ds.Close())
+		require.ErrorIs(t, ds.Start(ctx), ErrDataSourceClosed)
+		require.Equal(t, DataSourceClosed, ds.State())
+	})
+
+	t.Run("failed start closes data source", func(t *testing.T) {
+		ds := newPollDataSource(client, time.Hour)
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		require.NotNil(t, ds.Start(ctx))
+		require.Equal(t, DataSourceClosed, ds.State())
+	})
+
+	t.Run("client reports state", func(t *testing.T) {
+		client, err := NewClient(ctx,
+			WithHttpClient(ts.http.Client()),
+			WithApiHost(ts.http.URL),
+			WithClientKey("somekey"),
+			WithPollDataSource(time.Hour),
+		)
+		require.Nil(t, err)
+		require.Nil(t, client.EnsureLoaded(ctx))
+		require.Equal(t, DataSourceRunning, client.DataSourceState())
+		require.Nil(t, client.Close())
+		require.Equal(t, DataSourceClosed, client.DataSourceState())
+		require.Equal(t, "closed", client.DataSourceState().String())
+	})
+}
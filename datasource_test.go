@@ -3,6 +3,7 @@ package growthbook
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -20,3 +21,29 @@ func TestEmptyDataSource(t *testing.T) {
 	err = client.Close()
 	require.Nil(t, err)
 }
+
+func TestWaitForVersionNewerThan(t *testing.T) {
+	ctx := context.TODO()
+	client, _ := NewClient(ctx)
+	published := time.Date(2000, time.May, 2, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Returns context error while data is older", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		err := client.UpdateFromApiResponse(&FeatureApiResponse{DateUpdated: published.Add(-time.Hour)})
+		require.Nil(t, err)
+		err = client.WaitForVersionNewerThan(ctx, published)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("Returns after fresh update", func(t *testing.T) {
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			_ = client.UpdateFromApiResponse(&FeatureApiResponse{DateUpdated: published})
+		}()
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		err := client.WaitForVersionNewerThan(ctx, published)
+		require.Nil(t, err)
+	})
+}
@@ -0,0 +1,155 @@
+package growthbook
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DependencyGraph describes prerequisite relations between features.
+type DependencyGraph struct {
+	// Nodes are keys of all features from the payload, sorted.
+	Nodes []string
+	// Edges maps feature key to sorted keys of its prerequisite features.
+	Edges map[string][]string
+	// Cycles are prerequisite chains that lead back to their first feature.
+	Cycles [][]string
+	// Orphans maps feature key to prerequisites missing from the payload.
+	Orphans map[string][]string
+}
+
+// DependencyGraph builds prerequisite graph from parentConditions of the current features.
+func (client *Client) DependencyGraph() *DependencyGraph {
+	return newDependencyGraph(client.data.getFeatures())
+}
+
+func newDependencyGraph(features FeatureMap) *DependencyGraph {
+	g := DependencyGraph{
+		Edges:   map[string][]string{},
+		Orphans: map[string][]string{},
+	}
+	for key, feature := range features {
+		g.Nodes = append(g.Nodes, key)
+		if feature == nil {
+			continue
+		}
+		var parents []string
+		for _, rule := range feature.Rules {
+			for _, parent := range rule.ParentConditions {
+				parents = append(parents, parent.Id)
+			}
+		}
+		slices.Sort(parents)
+		parents = slices.Compact(parents)
+		if len(parents) == 0 {
+			continue
+		}
+		g.Edges[key] = parents
+		for _, parent := range parents {
+			if _, ok := features[parent]; !ok {
+				g.Orphans[key] = append(g.Orphans[key], parent)
+			}
+		}
+	}
+	slices.Sort(g.Nodes)
+	g.Cycles = g.findCycles()
+	return &g
+}
+
+// Dependents returns keys of features that depend on the feature directly or transitively.
+func (g *DependencyGraph) Dependents(key string) []string {
+	reverse := map[string][]string{}
+	for child, parents := range g.Edges {
+		for _, parent := range parents {
+			reverse[parent] = append(reverse[parent], child)
+		}
+	}
+	var res []string
+	seen := map[string]bool{key: true}
+	queue := []string{key}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, child := range reverse[cur] {
+			if seen[child] {
+				continue
+			}
+			seen[child] = true
+			res = append(res, child)
+			queue = append(queue, child)
+		}
+	}
+	slices.Sort(res)
+	return res
+}
+
+func (g *DependencyGraph) findCycles() [][]string {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+	var cycles [][]string
+	state := map[string]int{}
+	var path []string
+
+	var visit func(key string)
+	visit = func(key string) {
+		state[key] = inProgress
+		path = append(path, key)
+		for _, parent := range g.Edges[key] {
+			switch state[parent] {
+			case unvisited:
+				visit(parent)
+			case inProgress:
+				start := slices.Index(path, parent)
+				cycles = append(cycles, slices.Clone(path[start:]))
+			}
+		}
+		path = path[:len(path)-1]
+		state[key] = done
+	}
+
+	for _, key := range g.Nodes {
+		if state[key] == unvisited {
+			visit(key)
+		}
+	}
+	return cycles
+}
+
+func (g *DependencyGraph) inCycle(from, to string) bool {
+	for _, cycle := range g.Cycles {
+		for i, key := range cycle {
+			if key == from && cycle[(i+1)%len(cycle)] == to {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// DOT renders the graph in Graphviz DOT format. Edges point from a feature to its prerequisite.
+// Cycle edges are red, missing prerequisites are dashed.
+func (g *DependencyGraph) DOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph features {\n")
+	for _, key := range g.Nodes {
+		fmt.Fprintf(&sb, "  %q;\n", key)
+	}
+	for _, key := range g.Nodes {
+		for _, parent := range g.Edges[key] {
+			switch {
+			case slices.Contains(g.Orphans[key], parent):
+				fmt.Fprintf(&sb, "  %q [style=dashed];\n", parent)
+				fmt.Fprintf(&sb, "  %q -> %q [style=dashed];\n", key, parent)
+			case g.inCycle(key, parent):
+				fmt.Fprintf(&sb, "  %q -> %q [color=red];\n", key, parent)
+			default:
+				fmt.Fprintf(&sb, "  %q -> %q;\n", key, parent)
+			}
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
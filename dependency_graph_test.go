@@ -0,0 +1,46 @@
+package growthbook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependencyGraph(t *testing.T) {
+	featuresJSON := `{
+      "base": {"defaultValue": true},
+      "child": {"defaultValue": 1, "rules": [
+        {"parentConditions": [{"id": "base", "condition": {"value": true}, "gate": true}]},
+        {"parentConditions": [{"id": "missing", "condition": {"value": true}}], "force": 2}
+      ]},
+      "grandchild": {"defaultValue": 1, "rules": [
+        {"parentConditions": [{"id": "child", "condition": {"value": 1}}], "force": 2}
+      ]},
+      "cycle1": {"defaultValue": 1, "rules": [
+        {"parentConditions": [{"id": "cycle2", "condition": {"value": 1}}], "force": 2}
+      ]},
+      "cycle2": {"defaultValue": 1, "rules": [
+        {"parentConditions": [{"id": "cycle1", "condition": {"value": 1}}], "force": 2}
+      ]}
+    }`
+	client, err := NewClient(context.TODO(), WithJsonFeatures(featuresJSON))
+	require.Nil(t, err)
+
+	g := client.DependencyGraph()
+	require.Equal(t, []string{"base", "child", "cycle1", "cycle2", "grandchild"}, g.Nodes)
+	require.Equal(t, map[string][]string{
+		"child":      {"base", "missing"},
+		"grandchild": {"child"},
+		"cycle1":     {"cycle2"},
+		"cycle2":     {"cycle1"},
+	}, g.Edges)
+	require.Equal(t, map[string][]string{"child": {"missing"}}, g.Orphans)
+	require.Equal(t, [][]string{{"cycle1", "cycle2"}}, g.Cycles)
+	require.Equal(t, []string{"child", "grandchild"}, g.Dependents("base"))
+
+	dot := g.DOT()
+	require.Contains(t, dot, `"child" -> "base";`)
+	require.Contains(t, dot, `"child" -> "missing" [style=dashed];`)
+	require.Contains(t, dot, `"cycle2" -> "cycle1" [color=red];`)
+}
@@ -0,0 +1,140 @@
+package growthbook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Maximum number of log entries kept by a client instance for DevTools.
+const devToolsMaxLogs = 1000
+
+// DevToolsLog is a single evaluation recorded in dev mode.
+type DevToolsLog struct {
+	// "feature" or "experiment"
+	LogType    string      `json:"logType"`
+	FeatureKey string      `json:"featureKey,omitempty"`
+	Experiment *Experiment `json:"experiment,omitempty"`
+	// *FeatureResult for features and *ExperimentResult for experiments
+	Result    any    `json:"result"`
+	Timestamp string `json:"timestamp"`
+}
+
+// DevToolsSdkInfo describes the client that produced DevTools logs.
+type DevToolsSdkInfo struct {
+	ApiHost    string          `json:"apiHost"`
+	ClientKey  string          `json:"clientKey"`
+	Source     string          `json:"source"`
+	Payload    DevToolsPayload `json:"payload"`
+	Attributes any             `json:"attributes"`
+}
+
+// DevToolsPayload is the features payload used for evaluations.
+type DevToolsPayload struct {
+	Features FeatureMap `json:"features"`
+}
+
+// DevToolsEvent is the payload GrowthBook DevTools read from window._gbdebugEvents.
+type DevToolsEvent struct {
+	Logs    []DevToolsLog    `json:"logs"`
+	SdkInfo *DevToolsSdkInfo `json:"sdkInfo,omitempty"`
+}
+
+// devToolsLogs keeps evaluations made by a single client instance in dev mode.
+type devToolsLogs struct {
+	mu   sync.Mutex
+	logs []DevToolsLog
+}
+
+func newDevToolsLogs() *devToolsLogs {
+	return &devToolsLogs{}
+}
+
+func (l *devToolsLogs) add(log DevToolsLog) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.logs) >= devToolsMaxLogs {
+		l.logs = l.logs[1:]
+	}
+	l.logs = append(l.logs, log)
+}
+
+func (client *Client) logFeatureForDevTools(key string, res *FeatureResult) {
+	if !client.devMode {
+		return
+	}
+	client.devToolsLogs.add(DevToolsLog{
+		LogType:    "feature",
+		FeatureKey: key,
+		Result:     client.redactor.featureResult(res),
+		Timestamp:  devToolsTimestamp(),
+	})
+}
+
+func (client *Client) logExperimentForDevTools(exp *Experiment, res *ExperimentResult) {
+	if !client.devMode {
+		return
+	}
+	client.devToolsLogs.add(DevToolsLog{
+		LogType:    "experiment",
+		Experiment: exp,
+		Result:     res,
+		Timestamp:  devToolsTimestamp(),
+	})
+}
+
+func devToolsTimestamp() string {
+	return strconv.FormatInt(time.Now().UnixMilli(), 10)
+}
+
+// DevToolsEvent returns evaluations recorded by this client instance in dev mode,
+// together with the client context. Child clients keep their own logs.
+func (client *Client) DevToolsEvent() *DevToolsEvent {
+	l := client.devToolsLogs
+	l.mu.Lock()
+	logs := make([]DevToolsLog, len(l.logs))
+	copy(logs, l.logs)
+	l.mu.Unlock()
+
+	d := client.data
+	d.mu.RLock()
+	info := DevToolsSdkInfo{
+		ApiHost:    d.apiHost,
+		ClientKey:  d.clientKey,
+		Source:     "go",
+		Payload:    DevToolsPayload{Features: d.features.Clone()},
+		Attributes: client.redactor.attrs(client.Attributes()),
+	}
+	d.mu.RUnlock()
+
+	return &DevToolsEvent{Logs: logs, SdkInfo: &info}
+}
+
+// DevToolsScript returns JavaScript code that passes recorded evaluations to GrowthBook DevTools.
+// Render it inside a <script> tag of the server side rendered page.
+func (client *Client) DevToolsScript() (string, error) {
+	event, err := json.Marshal(client.DevToolsEvent())
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("window._gbdebugEvents = (window._gbdebugEvents || []).concat([%s]);", event), nil
+}
+
+// DevToolsHandler returns HTTP handler that serves [Client.DevToolsEvent] as JSON.
+// Handler responds with 404 unless the client is in dev mode.
+func (client *Client) DevToolsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !client.devMode {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(client.DevToolsEvent())
+		if err != nil {
+			client.logger.Error("Error encoding DevTools event", "error", err)
+		}
+	})
+}
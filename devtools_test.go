@@ -0,0 +1,54 @@
+package growthbook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDevTools(t *testing.T) {
+	ctx := context.TODO()
+	client, _ := NewClient(ctx,
+		WithClientKey("somekey"),
+		WithJsonFeatures(`{"feature": {"defaultValue": 0, "rules": [{"variations": [0, 1]}]}}`),
+		WithAttributes(Attributes{"id": "1"}),
+	)
+
+	t.Run("No logs without dev mode", func(t *testing.T) {
+		client.EvalFeature(ctx, "feature")
+		require.Empty(t, client.DevToolsEvent().Logs)
+		rec := httptest.NewRecorder()
+		client.DevToolsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("Dev mode records evaluations", func(t *testing.T) {
+		dev, _ := client.WithDevMode(true)
+		res := dev.EvalFeature(ctx, "feature")
+		event := dev.DevToolsEvent()
+		require.Len(t, event.Logs, 2)
+		require.Equal(t, "feature", event.Logs[0].LogType)
+		require.Equal(t, "feature", event.Logs[0].FeatureKey)
+		require.Equal(t, res, event.Logs[0].Result)
+		require.Equal(t, "experiment", event.Logs[1].LogType)
+		require.Equal(t, res.Experiment, event.Logs[1].Experiment)
+		require.Equal(t, "somekey", event.SdkInfo.ClientKey)
+
+		script, err := dev.DevToolsScript()
+		require.Nil(t, err)
+		require.True(t, strings.HasPrefix(script, "window._gbdebugEvents = (window._gbdebugEvents || []).concat(["))
+
+		rec := httptest.NewRecorder()
+		dev.DevToolsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		var body map[string]any
+		require.Nil(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.Len(t, body["logs"], 2)
+		require.Equal(t, map[string]any{"id": "1"}, body["sdkInfo"].(map[string]any)["attributes"])
+	})
+}
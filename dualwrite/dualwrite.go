@@ -0,0 +1,183 @@
+// Package dualwrite routes reads and writes of an infrastructure migration, e.g. moving
+// a table to another database, between the old and the new backend by GrowthBook features.
+//
+// Features are numbers between 0 and 1, coverages of ids migrated at each stage:
+// ids written to the new backend too, migrated ids read from the new backend, and
+// migrated ids still read from the old backend whose reads are shadowed to the new one
+// and compared. Ids are bucketed with [growthbook.Client.MigrationBucket], so with a
+// sticky bucketing service set on the client migrated ids stay migrated when coverage is
+// lowered, and coverage 0 rolls every id back.
+package dualwrite
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"github.com/growthbook/growthbook-golang"
+	"github.com/growthbook/growthbook-golang/hashutil"
+)
+
+// Config names the migration and features controlling it.
+type Config struct {
+	// Migration key, bucketing seed of ids
+	Key string
+	// Feature with fraction of ids written to the new backend besides the old one
+	WriteFeature string
+	// Feature with fraction of written ids read from the new backend. Optional.
+	ReadFeature string
+	// Feature with fraction of written ids read from the old backend whose reads are
+	// also made from the new backend and compared. Optional.
+	ShadowFeature string
+}
+
+// Route tells where operations on the id go.
+type Route struct {
+	WriteNew bool
+	ReadNew  bool
+	Shadow   bool
+}
+
+// ShadowResult is the outcome of a shadow read, passed to the shadow callback.
+type ShadowResult struct {
+	Id  string
+	Old any
+	New any
+	// Error of the new backend read
+	Err error
+	// Whether reads succeeded with equal results
+	Match bool
+}
+
+// ShadowCallback is called with the result of every shadow read.
+type ShadowCallback func(ctx context.Context, res *ShadowResult)
+
+// ErrorCallback is called with errors of the new backend that aren't returned to the caller.
+type ErrorCallback func(ctx context.Context, id string, err error)
+
+// Router decides routes of ids by the features of the client.
+type Router struct {
+	client  *growthbook.Client
+	cfg     Config
+	shadow  ShadowCallback
+	onError ErrorCallback
+	equal   func(old, new any) bool
+}
+
+// Option configures [Router].
+type Option func(*Router)
+
+// WithShadowCallback sets callback receiving results of shadow reads.
+func WithShadowCallback(cb ShadowCallback) Option {
+	return func(r *Router) {
+		r.shadow = cb
+	}
+}
+
+// WithErrorCallback sets callback receiving errors of new backend writes
+// of ids still read from the old backend.
+func WithErrorCallback(cb ErrorCallback) Option {
+	return func(r *Router) {
+		r.onError = cb
+	}
+}
+
+// WithEqual sets function comparing old and new results of shadow reads.
+// Default is [reflect.DeepEqual].
+func WithEqual(equal func(old, new any) bool) Option {
+	return func(r *Router) {
+		r.equal = equal
+	}
+}
+
+// NewRouter creates router of the migration. Features are evaluated with
+// attributes of the client.
+func NewRouter(client *growthbook.Client, cfg Config, opts ...Option) (*Router, error) {
+	if client == nil {
+		return nil, errors.New("GrowthBook client is required")
+	}
+	if cfg.Key == "" || cfg.WriteFeature == "" {
+		return nil, errors.New("Migration key and write feature are required")
+	}
+	r := &Router{client: client, cfg: cfg, equal: reflect.DeepEqual}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r, nil
+}
+
+// Route returns route of the id.
+func (r *Router) Route(ctx context.Context, id string) Route {
+	var route Route
+	route.WriteNew = r.client.MigrationBucket(ctx, r.cfg.Key, id, r.coverage(ctx, r.cfg.WriteFeature))
+	if !route.WriteNew {
+		return route
+	}
+	if r.cfg.ReadFeature != "" {
+		route.ReadNew = r.client.MigrationBucket(ctx, r.cfg.Key+":read", id, r.coverage(ctx, r.cfg.ReadFeature))
+	}
+	if !route.ReadNew && r.cfg.ShadowFeature != "" {
+		route.Shadow = hashutil.MigrationBucket(r.cfg.Key+":shadow", id, r.coverage(ctx, r.cfg.ShadowFeature))
+	}
+	return route
+}
+
+// coverage returns value of the feature clamped between 0 and 1, or 0 if it's not a number.
+func (r *Router) coverage(ctx context.Context, feature string) float64 {
+	var c float64
+	switch v := r.client.EvalFeature(ctx, feature).Value.(type) {
+	case float64:
+		c = v
+	case int64:
+		c = float64(v)
+	case int:
+		c = float64(v)
+	}
+	return min(max(c, 0), 1)
+}
+
+// Write writes to the old backend and, for migrated ids, to the new one. Error of the old
+// backend is returned and skips the new write. Error of the new backend is returned for ids
+// read from it, and passed to the error callback otherwise.
+func (r *Router) Write(ctx context.Context, id string, old func(context.Context) error, new func(context.Context) error) error {
+	if err := old(ctx); err != nil {
+		return err
+	}
+	route := r.Route(ctx, id)
+	if !route.WriteNew {
+		return nil
+	}
+	err := new(ctx)
+	if err == nil || route.ReadNew {
+		return err
+	}
+	if r.onError != nil {
+		r.onError(ctx, id, err)
+	}
+	return nil
+}
+
+// Read reads from the backend the id is routed to. Shadow reads from the new backend
+// run in background and don't affect the result.
+func Read[T any](ctx context.Context, r *Router, id string, old func(context.Context) (T, error), new func(context.Context) (T, error)) (T, error) {
+	route := r.Route(ctx, id)
+	if route.ReadNew {
+		return new(ctx)
+	}
+	res, err := old(ctx)
+	if err != nil || !route.Shadow || r.shadow == nil {
+		return res, err
+	}
+	shadowCtx := context.WithoutCancel(ctx)
+	go func() {
+		newRes, newErr := new(shadowCtx)
+		r.shadow(shadowCtx, &ShadowResult{
+			Id:    id,
+			Old:   res,
+			New:   newRes,
+			Err:   newErr,
+			Match: newErr == nil && r.equal(res, newRes),
+		})
+	}()
+	return res, nil
+}
@@ -0,0 +1,112 @@
+package dualwrite
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/growthbook/growthbook-golang"
+	"github.com/stretchr/testify/require"
+)
+
+func newClient(t *testing.T, write, read, shadow float64) *growthbook.Client {
+	client, err := growthbook.NewClient(context.TODO(), growthbook.WithFeatures(growthbook.FeatureMap{
+		"orders-write":  {DefaultValue: write},
+		"orders-read":   {DefaultValue: read},
+		"orders-shadow": {DefaultValue: shadow},
+	}))
+	require.Nil(t, err)
+	return client
+}
+
+var cfg = Config{Key: "orders", WriteFeature: "orders-write", ReadFeature: "orders-read", ShadowFeature: "orders-shadow"}
+
+func TestRoute(t *testing.T) {
+	ctx := context.TODO()
+	r, err := NewRouter(newClient(t, 0.5, 0.5, 1), cfg)
+	require.Nil(t, err)
+
+	var written, read, shadowed int
+	for i := 0; i < 1000; i++ {
+		route := r.Route(ctx, strconv.Itoa(i))
+		if route.ReadNew || route.Shadow {
+			require.True(t, route.WriteNew)
+		}
+		require.False(t, route.ReadNew && route.Shadow)
+		if route.WriteNew {
+			written++
+		}
+		if route.ReadNew {
+			read++
+		}
+		if route.Shadow {
+			shadowed++
+		}
+	}
+	require.InDelta(t, 500, written, 50)
+	require.InDelta(t, 250, read, 50)
+	require.Equal(t, written-read, shadowed)
+
+	none, err := NewRouter(newClient(t, 0, 1, 1), cfg)
+	require.Nil(t, err)
+	require.Equal(t, Route{}, none.Route(ctx, "1"))
+	all, err := NewRouter(newClient(t, 2, 1, 0), cfg)
+	require.Nil(t, err)
+	require.Equal(t, Route{WriteNew: true, ReadNew: true}, all.Route(ctx, "1"))
+
+	_, err = NewRouter(newClient(t, 0, 0, 0), Config{Key: "orders"})
+	require.Error(t, err)
+}
+
+func TestWrite(t *testing.T) {
+	ctx := context.TODO()
+	var reported error
+	onError := WithErrorCallback(func(_ context.Context, _ string, err error) { reported = err })
+	failNew := func(context.Context) error { return errors.New("new") }
+	ok := func(context.Context) error { return nil }
+
+	r, err := NewRouter(newClient(t, 1, 0, 0), cfg, onError)
+	require.Nil(t, err)
+	require.EqualError(t, r.Write(ctx, "1", func(context.Context) error { return errors.New("old") }, failNew), "old")
+	require.Nil(t, reported)
+	require.Nil(t, r.Write(ctx, "1", ok, failNew))
+	require.EqualError(t, reported, "new")
+
+	// Errors of the backend serving reads are returned
+	r, err = NewRouter(newClient(t, 1, 1, 0), cfg, onError)
+	require.Nil(t, err)
+	require.EqualError(t, r.Write(ctx, "1", ok, failNew), "new")
+
+	r, err = NewRouter(newClient(t, 0, 0, 0), cfg)
+	require.Nil(t, err)
+	require.Nil(t, r.Write(ctx, "1", ok, failNew))
+}
+
+func TestRead(t *testing.T) {
+	ctx := context.TODO()
+	old := func(context.Context) (string, error) { return "old", nil }
+	new := func(context.Context) (string, error) { return "new", nil }
+
+	shadows := make(chan *ShadowResult, 1)
+	r, err := NewRouter(newClient(t, 1, 0, 1), cfg, WithShadowCallback(func(_ context.Context, res *ShadowResult) {
+		shadows <- res
+	}))
+	require.Nil(t, err)
+	res, err := Read(ctx, r, "1", old, new)
+	require.Nil(t, err)
+	require.Equal(t, "old", res)
+	select {
+	case s := <-shadows:
+		require.Equal(t, &ShadowResult{Id: "1", Old: "old", New: "new"}, s)
+	case <-time.After(time.Second):
+		t.Fatal("Shadow read is not reported")
+	}
+
+	r, err = NewRouter(newClient(t, 1, 1, 1), cfg)
+	require.Nil(t, err)
+	res, err = Read(ctx, r, "1", old, new)
+	require.Nil(t, err)
+	require.Equal(t, "new", res)
+}
@@ -0,0 +1,303 @@
+// Package dynamodb provides DynamoDB-backed [growthbook.StickyBucketService], for serverless
+// deployments on AWS that need durable sticky assignments without running Redis. It calls
+// the DynamoDB HTTP API signed with Signature Version 4 using the standard library only,
+// so the package adds no dependencies and is compiled only when imported.
+//
+// The table needs a string partition key, "id" by default, which holds
+// "attributeName||attributeValue". Items also keep the attribute name and value,
+// assignments as a map and a version number used for conditional writes.
+package dynamodb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/growthbook/growthbook-golang"
+	"github.com/growthbook/growthbook-golang/eval"
+)
+
+const (
+	defaultPartitionKey = "id"
+	maxSaveAttempts     = 5
+	targetPrefix        = "DynamoDB_20120810."
+)
+
+var (
+	ErrConcurrentUpdates = errors.New("Too many concurrent updates of sticky bucket assignments")
+	ErrNoCredentials     = errors.New("No AWS credentials")
+	ErrNoRegion          = errors.New("No AWS region")
+)
+
+// Credentials are AWS credentials requests are signed with.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	// Session token of temporary credentials, e.g. of an IAM role
+	SessionToken string
+}
+
+// CredentialsProvider returns credentials for a request, e.g. refreshed ones of an IAM role.
+type CredentialsProvider func(ctx context.Context) (Credentials, error)
+
+// EnvCredentials reads credentials from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and
+// AWS_SESSION_TOKEN environment variables, which AWS Lambda sets from the function role.
+func EnvCredentials(_ context.Context) (Credentials, error) {
+	creds := Credentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return creds, ErrNoCredentials
+	}
+	return creds, nil
+}
+
+// StickyBucketService is a [growthbook.StickyBucketService] keeping assignments docs in a
+// DynamoDB table. Saved assignments are merged with ones saved by other instances using
+// conditional writes on the item version, so concurrent evaluations don't overwrite each
+// other's assignments. Reads are strongly consistent.
+type StickyBucketService struct {
+	table        string
+	partitionKey string
+	region       string
+	endpoint     string
+	httpClient   *http.Client
+	credentials  CredentialsProvider
+}
+
+var _ growthbook.StickyBucketService = &StickyBucketService{}
+
+// Option configures [StickyBucketService].
+type Option func(*StickyBucketService)
+
+// WithPartitionKey sets name of the table partition key. Default "id".
+func WithPartitionKey(name string) Option {
+	return func(s *StickyBucketService) {
+		s.partitionKey = name
+	}
+}
+
+// WithRegion sets AWS region of the table. Default is AWS_REGION environment variable.
+func WithRegion(region string) Option {
+	return func(s *StickyBucketService) {
+		s.region = region
+	}
+}
+
+// WithEndpoint sets DynamoDB endpoint URL, e.g. of DynamoDB Local.
+// Default is the regional endpoint, e.g. "https://dynamodb.us-east-1.amazonaws.com".
+func WithEndpoint(endpoint string) Option {
+	return func(s *StickyBucketService) {
+		s.endpoint = endpoint
+	}
+}
+
+// WithHttpClient sets HTTP client of DynamoDB requests. Default [http.DefaultClient].
+func WithHttpClient(client *http.Client) Option {
+	return func(s *StickyBucketService) {
+		s.httpClient = client
+	}
+}
+
+// WithCredentials sets provider of credentials. Default [EnvCredentials].
+func WithCredentials(provider CredentialsProvider) Option {
+	return func(s *StickyBucketService) {
+		s.credentials = provider
+	}
+}
+
+// NewStickyBucketService creates service keeping assignments docs in the table.
+func NewStickyBucketService(table string, opts ...Option) *StickyBucketService {
+	s := &StickyBucketService{
+		table:        table,
+		partitionKey: defaultPartitionKey,
+		region:       os.Getenv("AWS_REGION"),
+		httpClient:   http.DefaultClient,
+		credentials:  EnvCredentials,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *StickyBucketService) GetAssignments(ctx context.Context, attributeName string, attributeValue string) (*growthbook.StickyBucketAssignmentDoc, error) {
+	doc, _, err := s.getItem(ctx, attributeName, attributeValue)
+	return doc, err
+}
+
+// SaveAssignments merges assignments of the doc into the stored doc, assignments of the doc win.
+func (s *StickyBucketService) SaveAssignments(ctx context.Context, doc *growthbook.StickyBucketAssignmentDoc) error {
+	for range maxSaveAttempts {
+		existing, version, err := s.getItem(ctx, doc.AttributeName, doc.AttributeValue)
+		if err != nil {
+			return err
+		}
+		assignments := growthbook.StickyBucketAssignments{}
+		if existing != nil {
+			maps.Copy(assignments, existing.Assignments)
+		}
+		maps.Copy(assignments, doc.Assignments)
+		if len(assignments) == 0 || existing != nil && maps.Equal(assignments, existing.Assignments) {
+			return nil
+		}
+		err = s.putItem(ctx, doc.AttributeName, doc.AttributeValue, assignments, existing != nil, version)
+		var apiErr *apiError
+		if !errors.As(err, &apiErr) || !apiErr.conditionFailed() {
+			return err
+		}
+		// Another instance saved the doc meanwhile, merge with its assignments
+	}
+	return ErrConcurrentUpdates
+}
+
+// itemValue is a DynamoDB attribute value of the types items use.
+type itemValue struct {
+	S *string              `json:"S,omitempty"`
+	N *string              `json:"N,omitempty"`
+	M map[string]itemValue `json:"M,omitempty"`
+}
+
+func stringValue(s string) itemValue {
+	return itemValue{S: &s}
+}
+
+func (s *StickyBucketService) key(attributeName string, attributeValue string) map[string]itemValue {
+	return map[string]itemValue{s.partitionKey: stringValue(eval.StickyBucketKey(attributeName, attributeValue))}
+}
+
+// getItem returns the doc and its version, 0 if there is no doc or it has no version.
+func (s *StickyBucketService) getItem(ctx context.Context, attributeName string, attributeValue string) (*growthbook.StickyBucketAssignmentDoc, int64, error) {
+	var resp struct {
+		Item map[string]itemValue
+	}
+	err := s.call(ctx, "GetItem", map[string]any{
+		"TableName":      s.table,
+		"Key":            s.key(attributeName, attributeValue),
+		"ConsistentRead": true,
+	}, &resp)
+	if err != nil || resp.Item == nil {
+		return nil, 0, err
+	}
+	doc := &growthbook.StickyBucketAssignmentDoc{
+		AttributeName:  attributeName,
+		AttributeValue: attributeValue,
+		Assignments:    growthbook.StickyBucketAssignments{},
+	}
+	for key, value := range resp.Item["assignments"].M {
+		if value.S != nil {
+			doc.Assignments[key] = *value.S
+		}
+	}
+	var version int64
+	if n := resp.Item["version"].N; n != nil {
+		if version, err = strconv.ParseInt(*n, 10, 64); err != nil {
+			return nil, 0, fmt.Errorf("Invalid sticky bucket assignments version: %w", err)
+		}
+	}
+	return doc, version, nil
+}
+
+// putItem writes the item if it still doesn't exist or its version is still the read one.
+func (s *StickyBucketService) putItem(ctx context.Context, attributeName string, attributeValue string, assignments growthbook.StickyBucketAssignments, exists bool, version int64) error {
+	item := s.key(attributeName, attributeValue)
+	item["attributeName"] = stringValue(attributeName)
+	item["attributeValue"] = stringValue(attributeValue)
+	item["assignments"] = itemValue{M: map[string]itemValue{}}
+	for key, value := range assignments {
+		item["assignments"].M[key] = stringValue(value)
+	}
+	next := strconv.FormatInt(version+1, 10)
+	item["version"] = itemValue{N: &next}
+
+	req := map[string]any{
+		"TableName": s.table,
+		"Item":      item,
+	}
+	switch {
+	case !exists:
+		req["ConditionExpression"] = "attribute_not_exists(#key)"
+		req["ExpressionAttributeNames"] = map[string]string{"#key": s.partitionKey}
+	case version == 0:
+		// Item written by another tool
+		req["ConditionExpression"] = "attribute_not_exists(#version)"
+		req["ExpressionAttributeNames"] = map[string]string{"#version": "version"}
+	default:
+		current := strconv.FormatInt(version, 10)
+		req["ConditionExpression"] = "#version = :version"
+		req["ExpressionAttributeNames"] = map[string]string{"#version": "version"}
+		req["ExpressionAttributeValues"] = map[string]itemValue{":version": {N: &current}}
+	}
+	return s.call(ctx, "PutItem", req, nil)
+}
+
+// apiError is an error response of DynamoDB API.
+type apiError struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+	status  int
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("DynamoDB error %d %s: %s", e.status, e.Type, e.Message)
+}
+
+func (e *apiError) conditionFailed() bool {
+	return strings.HasSuffix(e.Type, "#ConditionalCheckFailedException")
+}
+
+// call sends signed request of the DynamoDB operation and decodes the response into res.
+func (s *StickyBucketService) call(ctx context.Context, operation string, params any, res any) error {
+	if s.region == "" {
+		return ErrNoRegion
+	}
+	creds, err := s.credentials(ctx)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	endpoint := s.endpoint
+	if endpoint == "" {
+		endpoint = "https://dynamodb." + s.region + ".amazonaws.com"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.0")
+	req.Header.Set("X-Amz-Target", targetPrefix+operation)
+	signRequest(req, body, creds, s.region, "dynamodb", time.Now())
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &apiError{status: resp.StatusCode}
+		_ = json.Unmarshal(data, apiErr)
+		return apiErr
+	}
+	if res == nil {
+		return nil
+	}
+	return json.Unmarshal(data, res)
+}
@@ -0,0 +1,206 @@
+package dynamodb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/growthbook/growthbook-golang"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTable implements GetItem and PutItem with condition expressions written by the service.
+type fakeTable struct {
+	mu    sync.Mutex
+	items map[string]map[string]itemValue
+	// Called before a conditional put is checked, e.g. to simulate concurrent updates
+	beforePut func()
+}
+
+func startFakeTable(t *testing.T) (*fakeTable, *httptest.Server) {
+	table := &fakeTable{items: map[string]map[string]itemValue{}}
+	ts := httptest.NewServer(http.HandlerFunc(table.serve(t)))
+	t.Cleanup(ts.Close)
+	return table, ts
+}
+
+func (f *fakeTable) serve(t *testing.T) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		require.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+		require.Equal(t, "session", r.Header.Get("X-Amz-Security-Token"))
+		var req struct {
+			TableName                 string
+			Key                       map[string]itemValue
+			Item                      map[string]itemValue
+			ConditionExpression       string
+			ExpressionAttributeValues map[string]itemValue
+		}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "assignments", req.TableName)
+
+		switch r.Header.Get("X-Amz-Target") {
+		case "DynamoDB_20120810.GetItem":
+			f.mu.Lock()
+			item := f.items[*req.Key["id"].S]
+			f.mu.Unlock()
+			_ = json.NewEncoder(w).Encode(map[string]any{"Item": item})
+		case "DynamoDB_20120810.PutItem":
+			f.mu.Lock()
+			beforePut := f.beforePut
+			f.mu.Unlock()
+			if beforePut != nil {
+				beforePut()
+			}
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			key := *req.Item["id"].S
+			existing, exists := f.items[key]
+			var ok bool
+			switch req.ConditionExpression {
+			case "attribute_not_exists(#key)":
+				ok = !exists
+			case "attribute_not_exists(#version)":
+				ok = existing["version"].N == nil
+			case "#version = :version":
+				ok = exists && existing["version"].N != nil && *existing["version"].N == *req.ExpressionAttributeValues[":version"].N
+			}
+			if !ok {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"__type": "com.amazonaws.dynamodb.v20120810#ConditionalCheckFailedException", "message": "The conditional request failed"}`)
+				return
+			}
+			f.items[key] = req.Item
+			fmt.Fprint(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"__type": "com.amazon.coral.service#UnknownOperationException"}`)
+		}
+	}
+}
+
+func (f *fakeTable) put(key string, item string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var value map[string]itemValue
+	_ = json.Unmarshal([]byte(item), &value)
+	f.items[key] = value
+}
+
+func (f *fakeTable) setBeforePut(fn func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.beforePut = fn
+}
+
+func newTestService(ts *httptest.Server) *StickyBucketService {
+	return NewStickyBucketService("assignments",
+		WithRegion("us-east-1"),
+		WithEndpoint(ts.URL),
+		WithHttpClient(ts.Client()),
+		WithCredentials(func(context.Context) (Credentials, error) {
+			return Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret", SessionToken: "session"}, nil
+		}),
+	)
+}
+
+func TestStickyBucketService(t *testing.T) {
+	ctx := context.TODO()
+	table, ts := startFakeTable(t)
+	s := newTestService(ts)
+
+	doc, err := s.GetAssignments(ctx, "id", "123")
+	require.Nil(t, err)
+	require.Nil(t, doc)
+
+	require.Nil(t, s.SaveAssignments(ctx, &growthbook.StickyBucketAssignmentDoc{
+		AttributeName: "id", AttributeValue: "123", Assignments: growthbook.StickyBucketAssignments{"exp1__0": "1"},
+	}))
+	doc, err = s.GetAssignments(ctx, "id", "123")
+	require.Nil(t, err)
+	require.Equal(t, &growthbook.StickyBucketAssignmentDoc{
+		AttributeName: "id", AttributeValue: "123", Assignments: growthbook.StickyBucketAssignments{"exp1__0": "1"},
+	}, doc)
+	require.Equal(t, "1", *table.items["id||123"]["version"].N)
+
+	// Another instance saves assignment of another experiment while the doc is merged
+	table.setBeforePut(func() {
+		table.setBeforePut(nil)
+		table.put("id||123", `{"id": {"S": "id||123"}, "version": {"N": "2"},
+		  "assignments": {"M": {"exp1__0": {"S": "1"}, "exp3__0": {"S": "0"}}}}`)
+	})
+	require.Nil(t, s.SaveAssignments(ctx, &growthbook.StickyBucketAssignmentDoc{
+		AttributeName: "id", AttributeValue: "123", Assignments: growthbook.StickyBucketAssignments{"exp1__0": "2", "exp2__0": "0"},
+	}))
+	doc, err = s.GetAssignments(ctx, "id", "123")
+	require.Nil(t, err)
+	require.Equal(t, growthbook.StickyBucketAssignments{"exp1__0": "2", "exp2__0": "0", "exp3__0": "0"}, doc.Assignments)
+
+	// Doc is updated by others on every attempt
+	version := 100
+	table.setBeforePut(func() {
+		version++
+		table.put("id||123", fmt.Sprintf(`{"id": {"S": "id||123"}, "version": {"N": "%d"}, "assignments": {"M": {}}}`, version))
+	})
+	err = s.SaveAssignments(ctx, &growthbook.StickyBucketAssignmentDoc{
+		AttributeName: "id", AttributeValue: "123", Assignments: growthbook.StickyBucketAssignments{"exp4__0": "1"},
+	})
+	require.ErrorIs(t, err, ErrConcurrentUpdates)
+}
+
+func TestStickyBucketServiceItemWithoutVersion(t *testing.T) {
+	ctx := context.TODO()
+	table, ts := startFakeTable(t)
+	s := newTestService(ts)
+	table.put("id||123", `{"id": {"S": "id||123"}, "assignments": {"M": {"exp1__0": {"S": "1"}}}}`)
+
+	require.Nil(t, s.SaveAssignments(ctx, &growthbook.StickyBucketAssignmentDoc{
+		AttributeName: "id", AttributeValue: "123", Assignments: growthbook.StickyBucketAssignments{"exp2__0": "0"},
+	}))
+	doc, err := s.GetAssignments(ctx, "id", "123")
+	require.Nil(t, err)
+	require.Equal(t, growthbook.StickyBucketAssignments{"exp1__0": "1", "exp2__0": "0"}, doc.Assignments)
+}
+
+func TestStickyBucketServiceErrors(t *testing.T) {
+	ctx := context.TODO()
+	_, ts := startFakeTable(t)
+
+	s := NewStickyBucketService("assignments", WithRegion(""))
+	_, err := s.GetAssignments(ctx, "id", "123")
+	require.ErrorIs(t, err, ErrNoRegion)
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	s = NewStickyBucketService("assignments", WithRegion("us-east-1"), WithEndpoint(ts.URL))
+	_, err = s.GetAssignments(ctx, "id", "123")
+	require.ErrorIs(t, err, ErrNoCredentials)
+
+	s = newTestService(ts)
+	err = s.call(ctx, "DeleteTable", map[string]any{"TableName": "assignments"}, nil)
+	require.ErrorContains(t, err, "UnknownOperationException")
+}
+
+func TestStickyBucketServiceKeepsAssignment(t *testing.T) {
+	ctx := context.TODO()
+	_, ts := startFakeTable(t)
+	featuresJSON := `{"exp": {"defaultValue": "control", "rules": [{
+	  "key": "exp", "variations": ["control", "red", "blue"], "meta": [{"key": "0"}, {"key": "1"}, {"key": "2"}], "weights": %s
+	}]}}`
+
+	client, err := growthbook.NewClient(ctx,
+		growthbook.WithJsonFeatures(fmt.Sprintf(featuresJSON, "[0, 1, 0]")),
+		growthbook.WithAttributes(growthbook.Attributes{"id": "123"}),
+		growthbook.WithStickyBucketService(newTestService(ts)),
+	)
+	require.Nil(t, err)
+	require.Equal(t, "red", client.EvalFeature(ctx, "exp").Value)
+
+	require.Nil(t, client.SetJSONFeatures(fmt.Sprintf(featuresJSON, "[0, 0, 1]")))
+	res := client.EvalFeature(ctx, "exp")
+	require.Equal(t, "red", res.Value)
+	require.True(t, res.ExperimentResult.StickyBucketUsed)
+}
@@ -0,0 +1,206 @@
+package growthbook
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// EffectiveConfig describes how the client is actually configured, after options,
+// config files and environments are resolved. Secrets and attribute values are
+// never included: the client key is reported as the hash used in logs, keys and
+// webhook URLs only as whether they are set, and attributes by name.
+type EffectiveConfig struct {
+	ApiHost       string `json:"apiHost"`
+	ClientKeyHash string `json:"clientKeyHash"`
+	InstanceId    string `json:"instanceId"`
+	Environment   string `json:"environment,omitempty"`
+	// "poll", "sse", "coordinatedPoll", "subscriber", "lightweight", "none",
+	// or the Go type of a custom data source
+	DataSource      string   `json:"dataSource"`
+	DataSourceState string   `json:"dataSourceState"`
+	PollInterval    Duration `json:"pollInterval,omitempty"`
+	// SSE stream host, if it differs from the API host, and path template
+	SseHost       string `json:"sseHost,omitempty"`
+	SseStreamPath string `json:"sseStreamPath,omitempty"`
+	// Message bus topic of the subscriber data source
+	SubscribeTopic string `json:"subscribeTopic,omitempty"`
+	// Time after which lightweight mode refetches features
+	CacheTtl Duration `json:"cacheTtl,omitempty"`
+	// Go type of the shared cache of coordinated polling or lightweight mode
+	SharedCache      string   `json:"sharedCache,omitempty"`
+	CacheCompression string   `json:"cacheCompression,omitempty"`
+	Decryption       bool     `json:"decryption"`
+	HttpTimeout      Duration `json:"httpTimeout,omitempty"`
+	FetchTimeout     Duration `json:"fetchTimeout,omitempty"`
+	MaxRetries       int      `json:"maxRetries,omitempty"`
+	PerTryTimeout    Duration `json:"perTryTimeout,omitempty"`
+	RetryBudget      Duration `json:"retryBudget,omitempty"`
+	MaxPayloadAge    Duration `json:"maxPayloadAge,omitempty"`
+	StalePolicy      string   `json:"stalePolicy,omitempty"`
+	RollbackPolicy   string   `json:"rollbackPolicy"`
+	StrictPayload    bool     `json:"strictPayload"`
+	ChangeWebhook    bool     `json:"changeWebhook"`
+	PayloadPublisher bool     `json:"payloadPublisher"`
+
+	Enabled             bool     `json:"enabled"`
+	QaMode              bool     `json:"qaMode"`
+	DevMode             bool     `json:"devMode"`
+	Url                 string   `json:"url,omitempty"`
+	Attributes          []string `json:"attributes"`
+	GlobalAttributes    []string `json:"globalAttributes"`
+	SensitiveAttributes []string `json:"sensitiveAttributes,omitempty"`
+	StrictAttributes    bool     `json:"strictAttributes"`
+	AttributeSchema     bool     `json:"attributeSchema"`
+	ForcedVariations    []string `json:"forcedVariations,omitempty"`
+	FeatureDefaults     []string `json:"featureDefaults,omitempty"`
+	FeatureFallbacks    int      `json:"featureFallbacks,omitempty"`
+	EvalTimeout         Duration `json:"evalTimeout,omitempty"`
+	// Go type of the sticky bucket service
+	StickyBucketService   string             `json:"stickyBucketService,omitempty"`
+	ExperimentRecordStore string             `json:"experimentRecordStore,omitempty"`
+	ExposureSampling      map[string]float64 `json:"exposureSampling,omitempty"`
+	ExperimentMemo        int                `json:"experimentMemo,omitempty"`
+	ConsistencySampleRate float64            `json:"consistencySampleRate,omitempty"`
+	CanaryEvaluator       string             `json:"canaryEvaluator,omitempty"`
+	ConcurrencyChecks     bool               `json:"concurrencyChecks"`
+	// Kinds of callbacks set, named as in [PanicHandler]
+	Callbacks []string `json:"callbacks"`
+}
+
+// EffectiveConfig returns resolved configuration of the client for diagnostics,
+// e.g. to confirm how a misbehaving service is actually configured.
+func (client *Client) EffectiveConfig() *EffectiveConfig {
+	cfg := &EffectiveConfig{
+		Environment:       client.environment,
+		Enabled:           client.enabled,
+		QaMode:            client.qaMode,
+		DevMode:           client.devMode,
+		Attributes:        sortedKeys(client.attributes),
+		GlobalAttributes:  sortedKeys(client.globalAttributes),
+		StrictAttributes:  client.strictAttributes,
+		AttributeSchema:   client.attributeSchema != nil,
+		ForcedVariations:  sortedKeys(client.forcedVariations),
+		FeatureDefaults:   sortedKeys(client.featureDefaults),
+		FeatureFallbacks:  len(client.featureFallbacks),
+		ChangeWebhook:     client.webhook != nil,
+		PayloadPublisher:  client.publisher != nil,
+		ConcurrencyChecks: client.concurrency != nil,
+		EvalTimeout:       Duration(client.evalTimeout),
+		ExposureSampling:  maps.Clone(client.exposureSampling),
+		DataSourceState:   client.DataSourceState().String(),
+		Callbacks:         client.callbackKinds(),
+	}
+	if client.url != nil {
+		// Query string may carry user data
+		u := *client.url
+		u.RawQuery, u.Fragment, u.User = "", "", nil
+		cfg.Url = u.String()
+	}
+	if client.redactor.enabled() {
+		cfg.SensitiveAttributes = sortedKeys(client.redactor.attributes)
+	}
+	if client.stickyBucketService != nil {
+		cfg.StickyBucketService = fmt.Sprintf("%T", client.stickyBucketService)
+	}
+	if client.experimentRecordStore != nil {
+		cfg.ExperimentRecordStore = fmt.Sprintf("%T", client.experimentRecordStore)
+	}
+	if client.experimentMemo != nil {
+		cfg.ExperimentMemo = client.experimentMemo.size
+	}
+	if client.consistencyChecker != nil {
+		cfg.ConsistencySampleRate = client.consistencyChecker.sampleRate
+		if canary := client.consistencyChecker.canary; canary != nil {
+			cfg.CanaryEvaluator = fmt.Sprintf("%T", canary)
+		}
+	}
+
+	d := client.data
+	d.mu.RLock()
+	cfg.ApiHost = d.apiHost
+	cfg.ClientKeyHash = clientKeyHash(d.clientKey)
+	cfg.InstanceId = d.instanceId
+	cfg.Decryption = d.decryptor != nil
+	cfg.FetchTimeout = Duration(d.fetchWait)
+	cfg.MaxRetries = d.retry.MaxRetries
+	cfg.PerTryTimeout = Duration(d.retry.PerTryTimeout)
+	cfg.RetryBudget = Duration(d.retry.Budget)
+	cfg.MaxPayloadAge = Duration(d.maxAge)
+	cfg.StalePolicy = string(d.agePolicy)
+	cfg.RollbackPolicy = string(d.rollback)
+	cfg.StrictPayload = d.strictJson
+	if d.httpClient != nil {
+		cfg.HttpTimeout = Duration(d.httpClient.Timeout)
+	}
+	if d.compression != nil {
+		cfg.CacheCompression = d.compression.Name()
+	}
+	ds := d.dataSource
+	sseHost, ssePath := d.sseHost, d.ssePath
+	d.mu.RUnlock()
+	if cfg.RollbackPolicy == "" {
+		cfg.RollbackPolicy = string(RejectRollback)
+	}
+
+	switch ds := ds.(type) {
+	case nil:
+		cfg.DataSource = "none"
+		if l := d.light.Load(); l != nil {
+			cfg.DataSource = "lightweight"
+			cfg.CacheTtl = Duration(l.ttl)
+			cfg.SharedCache = fmt.Sprintf("%T", l.cache)
+		}
+	case *PollDataSource:
+		cfg.DataSource = "poll"
+		cfg.PollInterval = Duration(ds.interval)
+	case *CoordinatedPollDataSource:
+		cfg.DataSource = "coordinatedPoll"
+		cfg.PollInterval = Duration(ds.interval)
+		cfg.SharedCache = fmt.Sprintf("%T", ds.cache)
+	case *SubscriberDataSource:
+		cfg.DataSource = "subscriber"
+		cfg.SubscribeTopic = ds.topic
+	case *SseDataSource:
+		cfg.DataSource = "sse"
+		cfg.SseHost = sseHost
+		cfg.SseStreamPath = ssePath
+		if ssePath == "" {
+			cfg.SseStreamPath = DefaultSseStreamPath
+		}
+	default:
+		cfg.DataSource = fmt.Sprintf("%T", ds)
+	}
+	return cfg
+}
+
+// callbackKinds returns sorted names of the callbacks set on the client.
+func (client *Client) callbackKinds() []string {
+	kinds := []string{}
+	add := func(name string, set bool) {
+		if set {
+			kinds = append(kinds, name)
+		}
+	}
+	add("bootstrapReplaced", client.bootstrapCallback != nil)
+	add("consistency", client.consistencyChecker != nil && client.consistencyChecker.callback != nil)
+	add("enrichExposure", len(client.enrichExposure) > 0)
+	add("experiment", client.experimentCallback != nil)
+	add("featureUsage", client.featureUsageCallback != nil)
+	add("panicHandler", client.panicHandler != nil)
+	add("ruleMetrics", client.ruleMetrics != nil && client.ruleMetrics.callback != nil)
+	add("sseEvent", len(client.sseHandlers) > 0)
+	add("featuresChange", client.changeCallback != nil)
+	add("rebucket", client.rebucketCallback != nil)
+	add("stalePayload", client.stalePayloadCallback != nil)
+	return kinds
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
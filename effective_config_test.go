@@ -0,0 +1,68 @@
+package growthbook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEffectiveConfig(t *testing.T) {
+	ctx := context.TODO()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"features": {}}`))
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(ctx,
+		WithApiHost(ts.URL),
+		WithClientKey("sdk-secret-key"),
+		WithDecryptionKey("decryption-secret"),
+		WithHttpClient(ts.Client()),
+		WithPollDataSource(time.Minute),
+		WithAttributes(Attributes{"id": "user-1", "email": "jane@example.com"}),
+		WithUrl("https://example.com/page?token=secret"),
+		WithSensitiveAttributes("email"),
+		WithStickyBucketService(NewMemoryStickyBucketService()),
+		WithExperimentCallback(func(context.Context, *Experiment, *ExperimentResult, any) {}),
+		WithMaxPayloadAge(time.Hour, WarnStalePayload),
+	)
+	require.Nil(t, err)
+	defer client.Close()
+	require.Nil(t, client.EnsureLoaded(ctx))
+
+	cfg := client.EffectiveConfig()
+	require.Equal(t, ts.URL, cfg.ApiHost)
+	require.Equal(t, clientKeyHash("sdk-secret-key"), cfg.ClientKeyHash)
+	require.Equal(t, "poll", cfg.DataSource)
+	require.Equal(t, "running", cfg.DataSourceState)
+	require.Equal(t, Duration(time.Minute), cfg.PollInterval)
+	require.True(t, cfg.Decryption)
+	require.Equal(t, "https://example.com/page", cfg.Url)
+	require.Equal(t, []string{"email", "id"}, cfg.Attributes)
+	require.Equal(t, []string{"email"}, cfg.SensitiveAttributes)
+	require.Equal(t, "*eval.MemoryStickyBucketService", cfg.StickyBucketService)
+	require.Equal(t, []string{"experiment"}, cfg.Callbacks)
+	require.Equal(t, Duration(time.Hour), cfg.MaxPayloadAge)
+	require.Equal(t, "warn", cfg.StalePolicy)
+	require.Equal(t, "reject", cfg.RollbackPolicy)
+
+	data, err := json.Marshal(cfg)
+	require.Nil(t, err)
+	for _, secret := range []string{"sdk-secret-key", "decryption-secret", "user-1", "jane@example.com", "token"} {
+		require.NotContains(t, string(data), secret)
+	}
+	require.Contains(t, string(data), `"pollInterval":"1m0s"`)
+
+	t.Run("Lightweight mode", func(t *testing.T) {
+		light, _ := NewClient(ctx, WithLightweightMode(time.Minute, nil))
+		cfg := light.EffectiveConfig()
+		require.Equal(t, "lightweight", cfg.DataSource)
+		require.Equal(t, Duration(time.Minute), cfg.CacheTtl)
+		require.Equal(t, "*growthbook.memoryCache", cfg.SharedCache)
+	})
+}
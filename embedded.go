@@ -0,0 +1,51 @@
+package growthbook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrInvalidEmbeddedPayload is returned by [NewClientFromEmbedded] for payloads that fail validation.
+var ErrInvalidEmbeddedPayload = errors.New("Invalid embedded payload")
+
+// NewClientFromEmbedded creates client serving payload built into the binary, e.g. with go:embed,
+// so CLIs and edge binaries work without network. Payload is the features API response, plain or
+// encrypted, and is validated at creation: client fails if it can't be decoded or decrypted,
+// has no features, or doesn't match the attribute schema in strict mode. Options are applied
+// before the payload, so decryption key and schema apply to it. With a data source option,
+// embedded features are served as bootstrap ones until the data source loads fresh ones.
+func NewClientFromEmbedded(ctx context.Context, embedded []byte, opts ...ClientOption) (*Client, error) {
+	return NewClient(ctx, append(slices.Clip(opts), withEmbeddedPayload(embedded))...)
+}
+
+func withEmbeddedPayload(embedded []byte) ClientOption {
+	return func(c *Client) error {
+		var resp FeatureApiResponse
+		if err := c.unmarshalPayload(embedded, &resp); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidEmbeddedPayload, err)
+		}
+		features := resp.Features
+		if resp.EncryptedFeatures != "" {
+			var err error
+			features, err = c.decryptFeatures(context.Background(), resp.EncryptedFeatures)
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidEmbeddedPayload, err)
+			}
+		}
+		if len(features) == 0 {
+			return fmt.Errorf("%w: no features", ErrInvalidEmbeddedPayload)
+		}
+		if err := c.checkPayload(features); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidEmbeddedPayload, err)
+		}
+		d := c.data
+		d.features = features.PrecomputeRanges()
+		d.savedGroups = resp.SavedGroups
+		d.dateUpdated = resp.DateUpdated
+		d.payloadSize = len(embedded)
+		d.bootstrap = d.dsFactory != nil
+		return nil
+	}
+}
@@ -0,0 +1,60 @@
+package growthbook
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClientFromEmbedded(t *testing.T) {
+	ctx := context.TODO()
+	client, err := NewClientFromEmbedded(ctx, []byte(`{
+		"features": {"beta": {"defaultValue": false, "rules": [{"condition": {"$groups": {"$elemMatch": {"$eq": "qa"}}, "id": {"$inGroup": "staff"}}, "force": true}]}},
+		"savedGroups": {"staff": ["1", "2"]},
+		"dateUpdated": "2024-01-01T00:00:00Z"
+	}`), WithAttributes(Attributes{"id": "2", "$groups": []string{"qa"}}))
+	require.Nil(t, err)
+	require.True(t, client.EvalFeature(ctx, "beta").On)
+	require.False(t, client.IsBootstrapped())
+
+	// Options are applied before the payload
+	encrypted, err := NewClientFromEmbedded(ctx, []byte(`{"encryptedFeatures": "vMSg2Bj/IurObDsWVmvkUg==.L6qtQkIzKDoE2Dix6IAKDcVel8PHUnzJ7JjmLjFZFQDqidRIoCxKmvxvUj2kTuHFTQ3/NJ3D6XhxhXXv2+dsXpw5woQf0eAgqrcxHrbtFORs18tRXRZza7zqgzwvcznx"}`),
+		WithDecryptionKey("Ns04T5n9+59rl2x3SlNHtQ=="))
+	require.Nil(t, err)
+	require.True(t, encrypted.EvalFeature(ctx, "testfeature1").On)
+
+	for _, payload := range []string{`{"features": `, `{"features": {}}`, `{"encryptedFeatures": "invalid"}`} {
+		_, err = NewClientFromEmbedded(ctx, []byte(payload))
+		require.ErrorIs(t, err, ErrInvalidEmbeddedPayload, payload)
+	}
+	_, err = NewClientFromEmbedded(ctx, []byte(`{"features": {"a": {"rules": [{"condition": {"age": "10"}, "force": true}]}}}`),
+		WithAttributeSchema(AttributeSchema{"age": "number"}, true))
+	require.ErrorIs(t, err, ErrInvalidEmbeddedPayload)
+}
+
+func TestNewClientFromEmbeddedWithDataSource(t *testing.T) {
+	ctx := context.TODO()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"features": {"foo": {"defaultValue": "api"}}, "dateUpdated": "2024-01-02T00:00:00Z"}`))
+	}))
+	defer ts.Close()
+
+	logger, _ := testLogger(slog.LevelError, t)
+	client, err := NewClientFromEmbedded(ctx, []byte(`{"features": {"foo": {"defaultValue": "embedded"}}, "dateUpdated": "2024-01-01T00:00:00Z"}`),
+		WithLogger(logger),
+		WithHttpClient(ts.Client()),
+		WithApiHost(ts.URL),
+		WithClientKey("somekey"),
+		WithPollDataSource(time.Hour),
+	)
+	require.Nil(t, err)
+	defer client.Close()
+	require.Nil(t, client.EnsureLoaded(ctx))
+	require.False(t, client.IsBootstrapped())
+	require.Equal(t, "api", client.EvalFeature(ctx, "foo").Value)
+}
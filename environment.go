@@ -0,0 +1,70 @@
+package growthbook
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// EnvironmentVariable is the name of the process environment variable
+// that selects client environment, if it isn't set with [WithEnvironment].
+const EnvironmentVariable = "GROWTHBOOK_ENV"
+
+// ErrUnknownEnvironment is returned when selected environment isn't configured.
+var ErrUnknownEnvironment = errors.New("Unknown environment")
+
+// Environment is API connection settings of a single environment, e.g. staging or production.
+// Empty settings keep client ones.
+type Environment struct {
+	// GrowthBook API host
+	ApiHost string `json:"apiHost" yaml:"apiHost"`
+	// Client key of the environment SDK connection
+	ClientKey string `json:"clientKey" yaml:"clientKey"`
+	// Key used to decrypt encrypted features
+	DecryptionKey string `json:"decryptionKey" yaml:"decryptionKey"`
+}
+
+// WithEnvironments sets API connection settings per environment name.
+// The environment is selected with [WithEnvironment] or [EnvironmentVariable]
+// when the client is created. Client settings are used if none is selected.
+func WithEnvironments(environments map[string]Environment) ClientOption {
+	return func(c *Client) error {
+		c.environments = environments
+		return nil
+	}
+}
+
+// WithEnvironment selects environment configured with [WithEnvironments],
+// overriding [EnvironmentVariable]. Options can be passed in any order.
+func WithEnvironment(name string) ClientOption {
+	return func(c *Client) error {
+		c.environment = name
+		return nil
+	}
+}
+
+// applyEnvironment applies settings of the selected environment to the client data.
+func (client *Client) applyEnvironment() error {
+	name := client.environment
+	if name == "" {
+		name = os.Getenv(EnvironmentVariable)
+	}
+	if name == "" || (client.environment == "" && len(client.environments) == 0) {
+		return nil
+	}
+	env, ok := client.environments[name]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownEnvironment, name)
+	}
+	if env.ApiHost != "" {
+		client.data.apiHost = env.ApiHost
+	}
+	if env.ClientKey != "" {
+		client.data.clientKey = env.ClientKey
+	}
+	if env.DecryptionKey != "" {
+		client.data.decryptor = NewAesDecryptionProvider(env.DecryptionKey)
+	}
+	client.logger.Info("Using environment", "name", name)
+	return nil
+}
@@ -0,0 +1,112 @@
+package eval
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/growthbook/growthbook-golang/internal/condition"
+	"github.com/growthbook/growthbook-golang/internal/value"
+)
+
+// Attributes is an arbitrary JSON object containing user and request
+// attributes.
+type Attributes map[string]any
+
+// AttributeValues are attributes converted for evaluation. Convert attributes
+// once with [NewAttributeValues] and reuse them for evaluations.
+type AttributeValues = value.ObjValue
+
+// NewAttributeValues converts attributes for evaluation.
+func NewAttributeValues(attributes Attributes) AttributeValues {
+	return value.Obj(attributes)
+}
+
+// SavedGroups are groups of attribute values referenced by conditions.
+type SavedGroups = condition.SavedGroups
+
+// ErrAttributeType is returned when an attribute doesn't match the [AttributeSchema].
+var ErrAttributeType = errors.New("Attribute type doesn't match the schema")
+
+// AttributeSchema declares attributes provided by the service and their JSON types:
+// "string", "number", "boolean", "array", "object", or empty string for any type.
+type AttributeSchema map[string]string
+
+// has reports whether the attribute path or any of its parent objects is declared.
+func (s AttributeSchema) has(path string) bool {
+	for {
+		if _, ok := s[path]; ok {
+			return true
+		}
+		i := strings.LastIndexByte(path, '.')
+		if i < 0 {
+			return false
+		}
+		path = path[:i]
+	}
+}
+
+// CheckAttributes returns [ErrAttributeType] errors joined for attributes of types
+// other than declared. Missing and null attributes are allowed.
+func (s AttributeSchema) CheckAttributes(attrs AttributeValues) error {
+	var errs []error
+	for _, path := range s.paths() {
+		v, ok := lookupAttribute(attrs, path)
+		if !ok {
+			continue
+		}
+		actual := condition.TypeName(v)
+		if actual != "" && actual != s[path] {
+			errs = append(errs, fmt.Errorf("%w: %q is %s, expected %s", ErrAttributeType, path, actual, s[path]))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CheckTypes returns messages describing comparisons of declared attributes with values
+// of other types in the condition. Array attributes aren't checked, because
+// conditions may compare them with their elements.
+func (s AttributeSchema) CheckTypes(cond Condition) []string {
+	var res []string
+	types := cond.AttributeTypes()
+	for _, path := range s.paths() {
+		expected := s[path]
+		if expected == "array" {
+			continue
+		}
+		for _, actual := range types[path] {
+			if actual != expected {
+				res = append(res, fmt.Sprintf("attribute %q of type %s is compared with %s", path, expected, actual))
+			}
+		}
+	}
+	return res
+}
+
+// paths returns sorted paths of attributes with declared types.
+func (s AttributeSchema) paths() []string {
+	res := make([]string, 0, len(s))
+	for path, t := range s {
+		if t != "" {
+			res = append(res, path)
+		}
+	}
+	slices.Sort(res)
+	return res
+}
+
+func lookupAttribute(attrs AttributeValues, path string) (value.Value, bool) {
+	var cur value.Value = attrs
+	for _, field := range strings.Split(path, ".") {
+		obj, ok := cur.(value.ObjValue)
+		if !ok {
+			return nil, false
+		}
+		cur, ok = obj[field]
+		if !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
@@ -0,0 +1,21 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttributeSchemaCheckAttributes(t *testing.T) {
+	schema := AttributeSchema{"id": "string", "age": "number", "user.plan": "string", "extra": ""}
+
+	attrs := NewAttributeValues(Attributes{"id": "1", "age": 20, "user": map[string]any{"plan": "pro"}, "extra": 1})
+	require.Nil(t, schema.CheckAttributes(attrs))
+	require.Nil(t, schema.CheckAttributes(NewAttributeValues(Attributes{"age": nil})))
+
+	attrs = NewAttributeValues(Attributes{"id": 1, "age": "20", "user": map[string]any{"plan": "pro"}})
+	err := schema.CheckAttributes(attrs)
+	require.ErrorIs(t, err, ErrAttributeType)
+	require.Equal(t, "Attribute type doesn't match the schema: \"age\" is string, expected number\n"+
+		"Attribute type doesn't match the schema: \"id\" is number, expected string", err.Error())
+}
@@ -0,0 +1,84 @@
+package eval
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/growthbook/growthbook-golang/hashutil"
+)
+
+// BucketRange represents a single bucket range.
+type BucketRange = hashutil.BucketRange
+
+// PrecomputeRanges returns the features with bucket ranges of experiment rules without
+// explicit ranges cached, so evaluations don't compute them every time. Rules with invalid
+// coverage or weights are skipped, so evaluations keep warning about them. The map and its
+// features are not modified, as evaluations may be reading them: features without up to date
+// ranges are copied with their rules into a copy of the map. Client calls it when features
+// are set, call it again after modifying rules.
+func (m FeatureMap) PrecomputeRanges() FeatureMap {
+	res, copied := m, false
+	for key, f := range m {
+		if f == nil {
+			continue
+		}
+		var rules []FeatureRule
+		for i := range f.Rules {
+			ranges := f.Rules[i].computeRanges()
+			if slices.Equal(ranges, f.Rules[i].ranges) {
+				continue
+			}
+			if rules == nil {
+				rules = slices.Clone(f.Rules)
+			}
+			rules[i].ranges = ranges
+		}
+		if rules == nil {
+			continue
+		}
+		if !copied {
+			res, copied = maps.Clone(m), true
+		}
+		feature := *f
+		feature.Rules = rules
+		res[key] = &feature
+	}
+	return res
+}
+
+func (r *FeatureRule) computeRanges() []BucketRange {
+	if len(r.Variations) == 0 || len(r.Ranges) > 0 {
+		return nil
+	}
+	coverage := 1.0
+	if r.Coverage != nil {
+		coverage = *r.Coverage
+	}
+	if coverage < 0 || coverage > 1 || len(r.Weights) > 0 && !hashutil.ValidWeights(len(r.Variations), r.Weights) {
+		return nil
+	}
+	return hashutil.GetBucketRanges(len(r.Variations), coverage, r.Weights)
+}
+
+// This converts an experiment's coverage and variation weights into
+// an array of bucket ranges.
+func (e *Evaluator) getBucketRanges(numVariations int, coverage float64, weights []float64) []BucketRange {
+	// Make sure coverage is within bounds.
+	if coverage < 0 {
+		e.logger.Warn("Experiment coverage must be greater than or equal to 0")
+	}
+	if coverage > 1 {
+		e.logger.Warn("Experiment coverage must be less than or equal to 1")
+	}
+
+	// Default to equal weights if missing or invalid
+	if len(weights) > 0 && !hashutil.ValidWeights(numVariations, weights) {
+		if len(weights) != numVariations {
+			e.logger.Warn("Experiment weights and variations arrays must be the same length")
+		} else {
+			e.logger.Warn("Experiment weights must add up to 1")
+		}
+	}
+
+	return hashutil.GetBucketRanges(numVariations, coverage, weights)
+}
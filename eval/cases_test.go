@@ -0,0 +1,138 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Evaluation helpers cases from the shared cases.json. Feature and experiment
+// cases are run against the client in the growthbook package.
+type cases struct {
+	Hash                   JsonTuples[hashCase]                   `json:"hash"`
+	GetBucketRange         JsonTuples[getBucketRangeCase]         `json:"getBucketRange"`
+	GetQueryStringOverride JsonTuples[getQueryStringOverrideCase] `json:"getQueryStringOverride"`
+}
+
+type hashCase struct {
+	Seed     string
+	Value    string
+	Version  int
+	Expected *float64
+}
+
+type getBucketRangeCase struct {
+	Name   string
+	Inputs JsonTuple[struct {
+		Num      int
+		Coverage float64
+		Weights  []float64
+	}]
+	Expected []BucketRange
+}
+
+type getQueryStringOverrideCase struct {
+	Name          string
+	Key           string
+	Url           string
+	NumVariations int
+	Expected      *int
+}
+
+type JsonTuple[T any] struct {
+	val T
+}
+
+func (t *JsonTuple[T]) UnmarshalJSON(data []byte) error {
+	var fields []json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	val := reflect.ValueOf(&t.val).Elem()
+	valType := val.Type()
+	for i, elemText := range fields {
+		err := json.Unmarshal(elemText, val.Field(i).Addr().Interface())
+		if err != nil {
+			return fmt.Errorf("Failed to unmarshal %v field from %s case: %w", valType.Field(i).Name, fields[0], err)
+		}
+	}
+	return nil
+}
+
+type JsonTuples[T JsonCase] []JsonTuple[T]
+type JsonCase interface{ test(t *testing.T) }
+
+func (ts JsonTuples[T]) run(name string, t *testing.T) {
+	t.Run(name, func(t *testing.T) {
+		for _, tuple := range ts {
+			tuple.val.test(t)
+		}
+	})
+}
+
+func TestCasesJson(t *testing.T) {
+	file := "../cases.json"
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cases cases
+	if err := json.Unmarshal(data, &cases); err != nil {
+		t.Fatal(err)
+	}
+
+	cases.Hash.run("hash", t)
+	cases.GetBucketRange.run("getBucketRange", t)
+	cases.GetQueryStringOverride.run("getQueryStringOverride", t)
+}
+
+func (c hashCase) test(t *testing.T) {
+	name := fmt.Sprintf(`hash("%s","%s","%d")`, c.Seed, c.Value, c.Version)
+	t.Run(name, func(t *testing.T) {
+		require.Equal(t, c.Expected, hash(c.Seed, c.Value, c.Version))
+	})
+}
+
+func (c getBucketRangeCase) test(t *testing.T) {
+	t.Run(c.Name, func(t *testing.T) {
+		e := New(context.TODO(), &Options{})
+
+		i := c.Inputs.val
+		res := e.getBucketRanges(i.Num, i.Coverage, i.Weights)
+		require.Equal(t, c.Expected, roundRanges(res))
+	})
+}
+
+func (c getQueryStringOverrideCase) test(t *testing.T) {
+	t.Run(c.Name, func(t *testing.T) {
+		url, err := url.Parse(c.Url)
+		require.Nil(t, err)
+		res, ok := getQueryStringOverride(c.Key, url, c.NumVariations)
+		if c.Expected == nil {
+			require.False(t, ok)
+		} else {
+			require.True(t, ok)
+			require.Equal(t, *c.Expected, res)
+		}
+	})
+}
+
+// Helper to round variation ranges for comparison with fixed test
+// values.
+func roundRanges(ranges []BucketRange) []BucketRange {
+	result := make([]BucketRange, len(ranges))
+	for i, r := range ranges {
+		rmin := math.Round(r.Min*1000000) / 1000000
+		rmax := math.Round(r.Max*1000000) / 1000000
+		result[i] = BucketRange{Min: rmin, Max: rmax}
+	}
+	return result
+}
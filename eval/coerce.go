@@ -0,0 +1,48 @@
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// CoerceNumbers converts coverage and weights of the JSON object of a rule or an
+// experiment, decoded with [json.Decoder.UseNumber], from numeric strings into numbers,
+// e.g. coverage "0.5" from older exports. Returns names of the converted fields,
+// or error if a string isn't a number.
+func CoerceNumbers(obj map[string]any) ([]string, error) {
+	var coerced []string
+	if s, ok := obj["coverage"].(string); ok {
+		n, err := coerceNumber(s)
+		if err != nil {
+			return nil, fmt.Errorf("coverage: %w", err)
+		}
+		obj["coverage"] = n
+		coerced = append(coerced, "coverage")
+	}
+	if weights, ok := obj["weights"].([]any); ok {
+		for i, w := range weights {
+			s, ok := w.(string)
+			if !ok {
+				continue
+			}
+			n, err := coerceNumber(s)
+			if err != nil {
+				return nil, fmt.Errorf("weights[%d]: %w", i, err)
+			}
+			weights[i] = n
+			coerced = append(coerced, fmt.Sprintf("weights[%d]", i))
+		}
+	}
+	return coerced, nil
+}
+
+func coerceNumber(s string) (json.Number, error) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("%q is not a number", s)
+	}
+	return json.Number(strconv.FormatFloat(f, 'g', -1, 64)), nil
+}
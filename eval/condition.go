@@ -0,0 +1,22 @@
+package eval
+
+import "github.com/growthbook/growthbook-golang/internal/condition"
+
+// Condition is a targeting condition in the GrowthBook JSON condition format.
+type Condition = condition.Base
+
+// NewCondition builds condition from the object of the same shape as JSON conditions,
+// e.g. map[string]any{"country": map[string]any{"$in": []string{"US", "CA"}}}.
+func NewCondition(obj map[string]any) (Condition, error) {
+	return condition.New(obj)
+}
+
+// MustCondition is like [NewCondition] but panics if the condition is invalid.
+// Intended for tests and static conditions.
+func MustCondition(obj map[string]any) Condition {
+	cond, err := NewCondition(obj)
+	if err != nil {
+		panic(err)
+	}
+	return cond
+}
@@ -0,0 +1,590 @@
+// Package eval is the GrowthBook evaluation core: targeting conditions, hashing,
+// bucketing and feature and experiment evaluation. It has no network dependencies,
+// so it can be used in WASM builds and CLI tools. The growthbook package builds
+// the client with data sources and tracking on top of it.
+package eval
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/growthbook/growthbook-golang/hashutil"
+	"github.com/growthbook/growthbook-golang/internal/value"
+)
+
+// Options configure evaluation of features and experiments.
+type Options struct {
+	// Attributes used to assign variations and evaluate conditions
+	Attributes AttributeValues
+	// Features evaluated by [Evaluator.EvalFeature]
+	Features FeatureMap
+	// Saved groups referenced by conditions
+	SavedGroups SavedGroups
+	// Disabled switches off all experiments
+	Disabled bool
+	// QaMode excludes users from experiments, unless forced
+	QaMode bool
+	// DevMode allows to force feature values via "gb~" query parameters of Url
+	DevMode bool
+	// Url of the current page, used for query string overrides
+	Url *url.URL
+	// ForcedVariations force variations of experiments by key
+	ForcedVariations ForcedVariationsMap
+	// Groups the user belongs to, matched against experiment groups
+	Groups map[string]bool
+	// Overrides change settings of experiments by key
+	Overrides ExperimentOverrides
+	// FeatureDefaults are values of features missing from the payload
+	FeatureDefaults map[string]any
+	// StickyBucketService enables sticky bucketing
+	StickyBucketService StickyBucketService
+	// HashSeedOverride returns hash seed used instead of the experiment seed, if not empty
+	HashSeedOverride func(expKey string) string
+	// Deadline after which feature evaluation falls back to the default value. It is checked
+	// before every rule and prerequisite, so a single rule or condition runs to completion.
+	Deadline time.Time
+	// Logger for debug information, defaults to [slog.Default]
+	Logger *slog.Logger
+	// Fallback returns payload used to evaluate features missing from Features
+	Fallback func(key string) (FeatureMap, SavedGroups, bool)
+	// IncludeMissingFilterAttribute keeps users without filter hash attributes, e.g.
+	// anonymous traffic, in force rules with filters. Experiments still filter them
+	// out to stay mutually exclusive.
+	IncludeMissingFilterAttribute bool
+	// DefaultValuesOnly skips feature rules, e.g. when targeting in the payload is too old
+	DefaultValuesOnly bool
+	// StalePayload makes features evaluate to nil with [StalePayloadResultSource]
+	StalePayload bool
+	// RedactAttribute, if set, replaces attribute values written to logs
+	RedactAttribute func(attribute string, value string) string
+}
+
+// ForcedVariationsMap is a map that forces an Experiment to always assign a specific variation. Useful for QA.
+type ForcedVariationsMap map[string]int
+
+// Evaluator evaluates features and experiments within a single evaluation context.
+// Evaluator is not thread-safe, create a new one for every evaluation.
+type Evaluator struct {
+	ctx         context.Context
+	opts        *Options
+	attributes  value.ObjValue
+	features    FeatureMap
+	savedGroups SavedGroups
+	evaluated   stack[string]
+	logger      *slog.Logger
+	stickyDocs  map[string]*StickyBucketAssignmentDoc
+}
+
+// New creates evaluator with the options. Options must not be modified while the evaluator is used.
+func New(ctx context.Context, opts *Options) *Evaluator {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Evaluator{
+		ctx:         ctx,
+		opts:        opts,
+		attributes:  opts.Attributes,
+		features:    opts.Features,
+		savedGroups: opts.SavedGroups,
+		logger:      logger,
+	}
+}
+
+// EvalFeature evaluates feature by key.
+func (e *Evaluator) EvalFeature(key string) *FeatureResult {
+	return e.evalFeature(key)
+}
+
+// RunExperiment evaluates inline experiment.
+func (e *Evaluator) RunExperiment(exp *Experiment) *ExperimentResult {
+	return e.runExperiment(exp, "", nil)
+}
+
+func (e *Evaluator) expired() bool {
+	return !e.opts.Deadline.IsZero() && time.Now().After(e.opts.Deadline)
+}
+
+func (e *Evaluator) evalFeature(key string) *FeatureResult {
+	if e.evaluated.has(key) {
+		return getFeatureResult(nil, CyclicPrerequisiteResultSource, "", nil, nil)
+	}
+	e.evaluated.push(key)
+	defer e.evaluated.pop()
+
+	if e.opts.DevMode {
+		if v, ok := getQueryStringFeatureOverride(key, e.opts.Url); ok {
+			e.logger.Debug("Force feature via querystring", "id", key, "value", v)
+			return getFeatureResult(v, OverrideResultSource, "", nil, nil)
+		}
+	}
+
+	feature := e.features[key]
+	if feature == nil {
+		if res := e.evalFallbackFeature(key); res != nil {
+			return res
+		}
+		if v, ok := e.opts.FeatureDefaults[key]; ok {
+			return getFeatureResult(v, DefaultsResultSource, "", nil, nil)
+		}
+		return getFeatureResult(nil, UnknownFeatureResultSource, "", nil, nil)
+	}
+	if e.opts.StalePayload {
+		return getFeatureResult(nil, StalePayloadResultSource, "", nil, nil)
+	}
+	if e.opts.DefaultValuesOnly {
+		return getFeatureResult(feature.DefaultValue, DefaultValueResultSource, "", nil, nil)
+	}
+
+	var passthrough []PassthroughAssignment
+	for _, rule := range feature.Rules {
+		if e.expired() {
+			e.logger.Warn("Feature evaluation timed out", "id", key)
+			return timeoutResult(feature, passthrough)
+		}
+		res := e.evalRule(key, &rule)
+		if res == nil {
+			continue
+		}
+		// Prerequisite evaluation timed out
+		if res.Source == TimeoutResultSource {
+			return timeoutResult(feature, passthrough)
+		}
+		// Passthrough variation, e.g. a holdout group, continues evaluation to later rules
+		if res.ExperimentResult != nil && res.ExperimentResult.Passthrough {
+			e.logger.Debug("Passthrough variation, continue to next rule", "id", key, "experiment", res.Experiment.Key)
+			passthrough = append(passthrough, PassthroughAssignment{res.Experiment, res.ExperimentResult})
+			continue
+		}
+		res.Passthrough = passthrough
+		return res
+	}
+
+	res := getFeatureResult(feature.DefaultValue, DefaultValueResultSource, "", nil, nil)
+	res.Passthrough = passthrough
+	return res
+}
+
+func timeoutResult(feature *Feature, passthrough []PassthroughAssignment) *FeatureResult {
+	res := getFeatureResult(feature.DefaultValue, TimeoutResultSource, "", nil, nil)
+	res.Passthrough = passthrough
+	return res
+}
+
+// evalFallbackFeature returns nil if the fallback payload has no feature.
+func (e *Evaluator) evalFallbackFeature(key string) *FeatureResult {
+	if e.opts.Fallback == nil {
+		return nil
+	}
+	features, savedGroups, ok := e.opts.Fallback(key)
+	if !ok || features[key] == nil {
+		return nil
+	}
+	fe := Evaluator{
+		ctx:         e.ctx,
+		opts:        e.opts,
+		attributes:  e.attributes,
+		features:    features,
+		savedGroups: savedGroups,
+		logger:      e.logger,
+	}
+	return fe.evalFeature(key)
+}
+
+// runExperiment evaluates the experiment. Ranges precomputed for the feature rule
+// are used if the experiment has no explicit ranges.
+func (e *Evaluator) runExperiment(exp *Experiment, featureId string, ranges []BucketRange) *ExperimentResult {
+	// 0. Apply experiment override, if any. It may change coverage and weights.
+	original := exp
+	exp, urlRegexp := e.applyOverride(exp)
+	if exp != original {
+		ranges = nil
+	}
+
+	// 1. If experiment.variations has fewer than 2 variations, return getExperimentResult(experiment)
+	if len(exp.Variations) < 2 {
+		e.logger.Debug("Invalid experiment", "id", exp.Key)
+		return e.getExperimentResult(exp, -1, false, featureId, nil, ExcludedInvalidReason)
+	}
+
+	// 2. If context.enabled is false, return getExperimentResult(experiment)
+	if e.opts.Disabled {
+		e.logger.Debug("Experiments disabled", "id", exp.Key)
+		return e.getExperimentResult(exp, -1, false, featureId, nil, ExcludedDisabledReason)
+	}
+
+	// 3. If context.url exists
+	if qsOverride, ok := getQueryStringOverride(exp.Key, e.opts.Url, len(exp.Variations)); ok {
+		e.logger.Debug("Force via querystring", "id", exp.Key, "variation", qsOverride)
+		return e.getExperimentResult(exp, qsOverride, false, featureId, nil, ForcedQueryStringReason)
+	}
+
+	// 4. Return if forced via context
+	if varId, ok := e.opts.ForcedVariations[exp.Key]; ok {
+		e.logger.Debug("Force via dev tools", "id", exp.Key, "variation", varId)
+		return e.getExperimentResult(exp, varId, false, featureId, nil, ForcedVariationReason)
+	}
+
+	// 4.5 Draft experiments run only when forced, stopped ones return the released variation
+	switch exp.Status {
+	case DraftStatus:
+		e.logger.Debug("Skip because draft", "id", exp.Key)
+		return e.getExperimentResult(exp, -1, false, featureId, nil, ExcludedDraftReason)
+	case StoppedStatus:
+		if exp.Force == nil {
+			e.logger.Debug("Skip because stopped", "id", exp.Key)
+			return e.getExperimentResult(exp, -1, false, featureId, nil, ExcludedStoppedReason)
+		}
+		e.logger.Debug("Released variation of stopped experiment", "id", exp.Key, "variation", *exp.Force)
+		// Users get the released variation, but aren't part of the experiment anymore
+		res := e.getExperimentResult(exp, *exp.Force, false, featureId, nil, ReleasedReason)
+		res.InExperiment = false
+		return res
+	}
+
+	// 5. If experiment.active is set to false, return getExperimentResult(experiment)
+	if !exp.getActive() {
+		e.logger.Debug("Skip because inactive", "id", exp.Key)
+		return e.getExperimentResult(exp, -1, false, featureId, nil, ExcludedInactiveReason)
+	}
+
+	// 6. Get the user hash value and return if empty
+	_, hashValue := e.getHashAttribute(exp.HashAttribute, e.fallbackAttribute(exp))
+	if hashValue == "" {
+		e.logger.Debug("Skip because of missing hashAttribute", "id", exp.Key)
+		return e.getExperimentResult(exp, -1, false, featureId, nil, ExcludedMissingHashAttributeReason)
+	}
+
+	// 6.5 If sticky bucketing is permitted, check to see if a sticky bucket value exists. If so, skip step 7.
+	assigned := -1
+	foundStickyBucket := false
+	stickyBucketVersionIsBlocked := false
+	if e.stickyBucketingEnabled(exp) {
+		assigned, stickyBucketVersionIsBlocked = e.getStickyBucketVariation(exp)
+		foundStickyBucket = assigned >= 0
+	}
+
+	// 7. Apply filters and namespace
+	if !foundStickyBucket {
+		if len(exp.Filters) > 0 {
+			if e.isFilteredOut(exp.Filters, false) {
+				e.logger.Debug("Skip because of filters", "id", exp.Key)
+				return e.getExperimentResult(exp, -1, false, featureId, nil, ExcludedFilteredReason)
+			}
+		} else if exp.Namespace != nil && !hashutil.InNamespace(hashValue, exp.Namespace) {
+			e.logger.Debug("Skip because of namespace", "id", exp.Key)
+			return e.getExperimentResult(exp, -1, false, featureId, nil, ExcludedNamespaceReason)
+		}
+	}
+
+	// 8 Return if any conditions are not met, return
+	if !exp.Condition.Eval(e.attributes, e.savedGroups) {
+		e.logger.Debug("Skip because of condition exp", "id", exp.Key)
+		return e.getExperimentResult(exp, -1, false, featureId, nil, ExcludedConditionReason)
+	}
+
+	// 8.1 Exclude if the user is in none of the experiment groups
+	if len(exp.Groups) > 0 && !e.inAnyGroup(exp.Groups) {
+		e.logger.Debug("Skip because of groups", "id", exp.Key)
+		return e.getExperimentResult(exp, -1, false, featureId, nil, ExcludedGroupsReason)
+	}
+
+	// 8.2 If experiment.parentConditions is set (prerequisites), return if any of them evaluate to false. See the corresponding logic in
+	if len(exp.ParentConditions) > 0 {
+		for _, parent := range exp.ParentConditions {
+			if e.expired() {
+				e.logger.Warn("Prerequisite evaluation timed out", "id", exp.Key)
+				return e.getExperimentResult(exp, -1, false, featureId, nil, ExcludedPrerequisiteReason)
+			}
+			res := e.evalFeature(parent.Id)
+			if res == nil {
+				e.logger.Debug("Skip because of prerequisite fails", "id", exp.Key)
+				return e.getExperimentResult(exp, -1, false, featureId, nil, ExcludedPrerequisiteReason)
+			}
+
+			if res.Source == TimeoutResultSource {
+				return e.getExperimentResult(exp, -1, false, featureId, nil, ExcludedPrerequisiteReason)
+			}
+
+			if res.Source == CyclicPrerequisiteResultSource {
+				return e.getExperimentResult(exp, -1, false, featureId, nil, ExcludedPrerequisiteReason)
+			}
+
+			evalObj := value.ObjValue{"value": value.New(res.Value)}
+			evaled := parent.Condition.Eval(evalObj, e.savedGroups)
+			if !evaled {
+				e.logger.Debug("Skip because of prerequisite evaluation fails", "id", exp.Key)
+				return e.getExperimentResult(exp, -1, false, featureId, nil, ExcludedPrerequisiteReason)
+			}
+		}
+	}
+
+	// 8.2.5 Exclude if the override url doesn't match
+	if urlRegexp != nil && !urlMatches(urlRegexp, e.opts.Url) {
+		e.logger.Debug("Skip because of url", "id", exp.Key)
+		return e.getExperimentResult(exp, -1, false, featureId, nil, ExcludedUrlReason)
+	}
+
+	// 8.3 TODO Apply any url targeting based on experiment.urlPatterns, return if no match
+
+	// 9 Choose a variation
+	n := hash(e.getSeed(exp), hashValue, if0(exp.HashVersion, 1))
+	if n == nil {
+		e.logger.Debug("Skip because of invalid hash version", "id", exp.Key)
+		return e.getExperimentResult(exp, -1, false, featureId, nil, ExcludedHashVersionReason)
+	}
+
+	// 9.1 If a sticky bucket value exists, use it.
+	// 9.2 Else, calculate bucket ranges for the variations and choose one
+	if !foundStickyBucket {
+		if len(exp.Ranges) > 0 {
+			ranges = exp.Ranges
+		}
+		if len(ranges) == 0 {
+			ranges = e.getBucketRanges(len(exp.Variations), exp.getCoverage(), exp.Weights)
+		}
+		assigned = hashutil.ChooseVariation(*n, ranges)
+	}
+
+	// 9.5 Unenroll if any prior sticky buckets are blocked by version
+	if stickyBucketVersionIsBlocked {
+		e.logger.Debug("Skip because sticky bucket version is blocked", "id", exp.Key)
+		res := e.getExperimentResult(exp, -1, false, featureId, nil, ExcludedStickyBucketReason)
+		res.StickyBucketUsed = true
+		return res
+	}
+
+	// 10. If assigned == -1, return getExperimentResult(experiment)
+	if assigned < 0 {
+		e.logger.Debug("Skip because of coverage", "id", exp.Key)
+		return e.getExperimentResult(exp, -1, false, featureId, nil, ExcludedCoverageReason)
+	}
+
+	// 11. If experiment has a forced variation, return
+	if exp.Force != nil {
+		e.logger.Debug("Force variation", "id", exp.Key, "variation", *exp.Force)
+		return e.getExperimentResult(exp, *exp.Force, false, featureId, nil, ForcedRuleReason)
+	}
+
+	// 12. If context.qaMode, return getExperimentResult(experiment)
+	if e.opts.QaMode {
+		e.logger.Debug("Skip because of QA mode", "id", exp.Key)
+		return e.getExperimentResult(exp, -1, false, featureId, nil, ExcludedQaModeReason)
+	}
+
+	// 13. Build the result object
+	res := e.getExperimentResult(exp, assigned, true, featureId, n, AssignedReason)
+	res.StickyBucketUsed = foundStickyBucket
+	if foundStickyBucket {
+		res.Reason = StickyBucketReason
+	}
+
+	// 13.5 Persist sticky bucket
+	if e.stickyBucketingEnabled(exp) {
+		e.saveStickyBucketAssignment(exp, res)
+	}
+
+	return res
+}
+
+func (e *Evaluator) inAnyGroup(groups []string) bool {
+	for _, group := range groups {
+		if e.opts.Groups[group] {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Evaluator) getSeed(exp *Experiment) string {
+	if e.opts.HashSeedOverride != nil {
+		if seed := e.opts.HashSeedOverride(exp.Key); seed != "" {
+			return seed
+		}
+	}
+	return exp.getSeed()
+}
+
+func (e *Evaluator) getExperimentResult(
+	exp *Experiment,
+	variationId int,
+	hashUsed bool,
+	featureId string,
+	bucket *float64,
+	reason ExperimentReason,
+) *ExperimentResult {
+	inExperiment := true
+
+	if variationId < 0 || variationId >= len(exp.Variations) {
+		variationId = 0
+		inExperiment = false
+	}
+
+	hashAttribute, hashValue := e.getHashAttribute(exp.HashAttribute, e.fallbackAttribute(exp))
+
+	var meta *VariationMeta
+	if variationId >= 0 && variationId < len(exp.Meta) {
+		meta = &exp.Meta[variationId]
+	}
+
+	key := exp.variationKey(variationId)
+
+	res := ExperimentResult{
+		Key:           key,
+		FeatureId:     featureId,
+		InExperiment:  inExperiment,
+		HashUsed:      hashUsed,
+		VariationId:   variationId,
+		Value:         exp.Variations[variationId],
+		HashAttribute: hashAttribute,
+		HashValue:     hashValue,
+		Bucket:        bucket,
+		Reason:        reason,
+	}
+
+	if meta != nil {
+		res.Name = meta.Name
+		res.Passthrough = meta.Passthrough
+	}
+
+	return &res
+}
+
+func (e *Evaluator) evalRule(featureId string, rule *FeatureRule) *FeatureResult {
+	if len(rule.ParentConditions) > 0 {
+		for _, parent := range rule.ParentConditions {
+			if e.expired() {
+				e.logger.Warn("Prerequisite evaluation timed out", "id", featureId, "prerequisite", parent.Id)
+				return getFeatureResult(nil, TimeoutResultSource, "", nil, nil)
+			}
+			res := e.evalFeature(parent.Id)
+			if res == nil {
+				return nil
+			}
+
+			if res.Source == CyclicPrerequisiteResultSource || res.Source == TimeoutResultSource {
+				return res
+			}
+
+			evalObj := value.ObjValue{"value": value.New(res.Value)}
+			evaled := parent.Condition.Eval(evalObj, e.savedGroups)
+			if !evaled {
+				if parent.Gate {
+					return getFeatureResult(nil, PrerequisiteResultSource, "", nil, nil)
+				}
+				return nil
+			}
+		}
+	}
+
+	if e.isFilteredOut(rule.Filters, rule.Force != nil && e.opts.IncludeMissingFilterAttribute) {
+		return nil
+	}
+
+	if rule.Force != nil {
+		if !rule.Condition.Eval(e.attributes, e.savedGroups) {
+			return nil
+		}
+
+		if !e.isIncludedInRollout(featureId, rule) {
+			return nil
+		}
+
+		return getFeatureResult(rule.Force, ForceResultSource, rule.Id, nil, nil)
+	}
+
+	if len(rule.Variations) == 0 {
+		return nil
+	}
+
+	exp := experimentFromFeatureRule(featureId, rule)
+	res := e.runExperiment(exp, featureId, rule.ranges)
+	if !res.InExperiment && res.Reason != ReleasedReason {
+		return nil
+	}
+
+	return getFeatureResult(res.Value, ExperimentResultSource, rule.Id, exp, res)
+}
+
+func (e *Evaluator) isIncludedInRollout(featureId string, rule *FeatureRule) bool {
+	if rule == nil {
+		return true
+	}
+
+	if rule.Coverage == nil && rule.Range == nil {
+		return true
+	}
+
+	if rule.Range == nil && *rule.Coverage == 0.0 {
+		return false
+	}
+
+	_, hashValue := e.getHashAttribute(rule.HashAttribute, "")
+	if hashValue == "" {
+		return false
+	}
+
+	seed := rule.Seed
+	if seed == "" {
+		seed = featureId
+	}
+	n := hash(seed, hashValue, if0(rule.HashVersion, 1))
+	if n == nil {
+		return false
+	}
+
+	if rule.Range != nil {
+		return rule.Range.InRange(*n)
+	}
+
+	if rule.Coverage != nil {
+		return *n <= *rule.Coverage
+	}
+
+	return true
+}
+
+// isFilteredOut reports whether the user is outside of any filter ranges.
+// Users missing filter hash attribute are filtered out unless includeMissing is set.
+func (e *Evaluator) isFilteredOut(filters []Filter, includeMissing bool) bool {
+	for _, filter := range filters {
+		attr, hashValue := e.getHashAttribute(filter.Attribute, filter.FallbackAttribute)
+		if hashValue == "" {
+			if includeMissing {
+				e.logger.Debug("Filter hash attribute is missing, not filtered", "attribute", attr, "seed", filter.Seed)
+				continue
+			}
+			e.logger.Debug("Filter hash attribute is missing, filtered out", "attribute", attr, "seed", filter.Seed)
+			return true
+		}
+
+		hash := hash(filter.Seed, hashValue, if0(filter.HashVersion, 2))
+		if hash == nil {
+			return true
+		}
+		if hashutil.ChooseVariation(*hash, filter.Ranges) == -1 {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Evaluator) getHashAttribute(key string, fallback string) (string, string) {
+	if key == "" {
+		key = "id"
+	}
+
+	hashValue, ok := e.attributes[key]
+	if ok && !value.IsNull(hashValue) {
+		return key, hashValue.String()
+	}
+
+	hashValue, ok = e.attributes[fallback]
+	if ok && !value.IsNull(hashValue) {
+		return fallback, hashValue.String()
+	}
+
+	return key, ""
+}
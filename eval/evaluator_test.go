@@ -0,0 +1,334 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/growthbook/growthbook-golang/hashutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluator(t *testing.T) {
+	var features FeatureMap
+	err := json.Unmarshal([]byte(`{
+	  "banner": {"defaultValue": "off", "rules": [{"condition": {"country": "US"}, "force": "on"}]},
+	  "exp": {"defaultValue": 0, "rules": [{"key": "exp", "variations": [0, 1], "weights": [0, 1]}]}
+	}`), &features)
+	require.Nil(t, err)
+
+	opts := &Options{
+		Attributes: NewAttributeValues(Attributes{"id": "1", "country": "US"}),
+		Features:   features,
+	}
+	e := New(context.TODO(), opts)
+	require.Equal(t, "on", e.EvalFeature("banner").Value)
+	res := e.EvalFeature("exp")
+	require.Equal(t, 1.0, res.Value)
+	require.True(t, res.InExperiment())
+	require.Equal(t, UnknownFeatureResultSource, e.EvalFeature("missing").Source)
+
+	opts.Disabled = true
+	require.False(t, New(context.TODO(), opts).EvalFeature("exp").InExperiment())
+}
+
+func TestFeatureMapRequiredAttributes(t *testing.T) {
+	var features FeatureMap
+	err := json.Unmarshal([]byte(`{
+	  "parent": {"defaultValue": true, "rules": [{"condition": {"beta": true}, "force": false}]},
+	  "feature": {"defaultValue": 0, "rules": [
+	    {"condition": {"country": "US"}, "parentConditions": [{"id": "parent", "condition": {"value": true}}], "force": 1},
+	    {"force": 2, "coverage": 0.5, "hashAttribute": "deviceId"},
+	    {"key": "exp", "variations": [0, 1], "fallbackAttribute": "anonId", "filters": [{"seed": "s", "ranges": [[0, 0.5]]}]}
+	  ]}
+	}`), &features)
+	require.Nil(t, err)
+	require.Equal(t, []string{"anonId", "beta", "country", "deviceId", "id"}, features.RequiredAttributes("feature"))
+	require.Equal(t, []string{"beta"}, features.RequiredAttributes("parent"))
+	require.Nil(t, features.RequiredAttributes("missing"))
+}
+
+func TestSplitTrafficMatchesExperiment(t *testing.T) {
+	weights := []float64{0.2, 0.3, 0.5}
+	exp := NewExperiment("split")
+	exp.Variations = []FeatureValue{0, 1, 2}
+	exp.Weights = weights
+	exp.Seed = "seed"
+	exp.HashVersion = 2
+	for i := 0; i < 1000; i++ {
+		id := strconv.Itoa(i)
+		res := New(context.TODO(), &Options{Attributes: NewAttributeValues(Attributes{"id": id})}).RunExperiment(exp)
+		require.Equal(t, res.VariationId, hashutil.SplitTraffic("seed", id, weights))
+	}
+}
+
+func TestFilterMissingHashAttribute(t *testing.T) {
+	var features FeatureMap
+	err := json.Unmarshal([]byte(`{
+	  "rollout": {"defaultValue": "off", "rules": [{"force": "on",
+	    "filters": [{"seed": "s", "attribute": "userId", "fallbackAttribute": "deviceId", "ranges": [[0, 1]]}]}]},
+	  "exp": {"defaultValue": 0, "rules": [{"key": "exp", "variations": [0, 1], "weights": [0, 1],
+	    "filters": [{"seed": "s", "attribute": "userId", "ranges": [[0, 1]]}]}]}
+	}`), &features)
+	require.Nil(t, err)
+
+	eval := func(attrs Attributes, includeMissing bool) *Evaluator {
+		opts := &Options{
+			Attributes:                    NewAttributeValues(attrs),
+			Features:                      features,
+			IncludeMissingFilterAttribute: includeMissing,
+		}
+		return New(context.TODO(), opts)
+	}
+	require.Equal(t, "on", eval(Attributes{"id": "1", "userId": "u1"}, false).EvalFeature("rollout").Value)
+	require.Equal(t, "on", eval(Attributes{"id": "1", "deviceId": "d1"}, false).EvalFeature("rollout").Value)
+	require.Equal(t, "off", eval(Attributes{"id": "1"}, false).EvalFeature("rollout").Value)
+	require.Equal(t, "on", eval(Attributes{"id": "1"}, true).EvalFeature("rollout").Value)
+	require.False(t, eval(Attributes{"id": "1"}, true).EvalFeature("exp").InExperiment())
+	require.Equal(t, []string{"deviceId", "userId"}, features.RequiredAttributes("rollout"))
+}
+
+func TestExperimentOverrides(t *testing.T) {
+	var overrides ExperimentOverrides
+	err := json.Unmarshal([]byte(`{
+	  "weights": {"weights": [0, 1]},
+	  "coverage": {"coverage": 0},
+	  "force": {"force": 1},
+	  "inactive": {"active": false},
+	  "stopped": {"status": "stopped"},
+	  "condition": {"condition": {"country": "US"}},
+	  "groups": {"groups": ["beta"]},
+	  "url": {"url": "^/checkout"}
+	}`), &overrides)
+	require.Nil(t, err)
+	overrides, err = overrides.Compile()
+	require.Nil(t, err)
+
+	run := func(key string, rawUrl string) *ExperimentResult {
+		u, _ := url.Parse(rawUrl)
+		opts := &Options{
+			Attributes: NewAttributeValues(Attributes{"id": "1", "country": "CA"}),
+			Overrides:  overrides,
+			Url:        u,
+		}
+		exp := &Experiment{Key: key, Variations: []FeatureValue{"a", "b"}, Weights: []float64{1, 0}}
+		return New(context.TODO(), opts).RunExperiment(exp)
+	}
+
+	require.Equal(t, 0, run("none", "").VariationId)
+	require.Equal(t, 1, run("weights", "").VariationId)
+	require.False(t, run("coverage", "").InExperiment)
+	require.Equal(t, 1, run("force", "").VariationId)
+	require.False(t, run("inactive", "").InExperiment)
+	require.False(t, run("stopped", "").InExperiment)
+	require.False(t, run("condition", "").InExperiment)
+	require.False(t, run("groups", "").InExperiment)
+	require.False(t, run("url", "https://example.com/cart").InExperiment)
+	require.True(t, run("url", "https://example.com/checkout?step=1").InExperiment)
+
+	_, err = ExperimentOverrides{"bad": {Url: "("}}.Compile()
+	require.NotNil(t, err)
+}
+
+func TestExperimentStatus(t *testing.T) {
+	run := func(exp *Experiment, forced ForcedVariationsMap, rawUrl string) *ExperimentResult {
+		u, _ := url.Parse(rawUrl)
+		opts := &Options{
+			Attributes:       NewAttributeValues(Attributes{"id": "1"}),
+			ForcedVariations: forced,
+			Url:              u,
+		}
+		return New(context.TODO(), opts).RunExperiment(exp)
+	}
+	released := 1
+	draft := &Experiment{Key: "draft", Variations: []FeatureValue{"a", "b"}, Status: DraftStatus}
+	stopped := &Experiment{Key: "stopped", Variations: []FeatureValue{"a", "b"}, Weights: []float64{1, 0}, Status: StoppedStatus}
+	releasedExp := *stopped
+	releasedExp.Force = &released
+
+	require.False(t, run(draft, nil, "").InExperiment)
+	require.Equal(t, "a", run(draft, nil, "").Value)
+	require.Equal(t, 1, run(draft, ForcedVariationsMap{"draft": 1}, "").VariationId)
+	require.Equal(t, 1, run(draft, nil, "https://example.com/?draft=1").VariationId)
+
+	res := run(stopped, nil, "")
+	require.False(t, res.InExperiment)
+	require.Equal(t, "a", res.Value)
+	res = run(&releasedExp, nil, "")
+	require.False(t, res.InExperiment)
+	require.False(t, res.HashUsed)
+	require.Equal(t, "b", res.Value)
+
+	var features FeatureMap
+	err := json.Unmarshal([]byte(`{"feature": {"defaultValue": "off", "rules": [
+	  {"key": "exp", "status": "draft", "variations": ["a", "b"]},
+	  {"force": "on"}
+	]}}`), &features)
+	require.Nil(t, err)
+	require.Equal(t, DraftStatus, features["feature"].Rules[0].Status)
+	opts := &Options{Attributes: NewAttributeValues(Attributes{"id": "1"}), Features: features}
+	require.Equal(t, "on", New(context.TODO(), opts).EvalFeature("feature").Value)
+
+	err = json.Unmarshal([]byte(`{
+	  "released": {"defaultValue": "off", "rules": [
+	    {"key": "exp", "status": "stopped", "releasedVariation": 1, "variations": ["a", "b"], "weights": [1, 0]},
+	    {"force": "on"}
+	  ]},
+	  "notReleased": {"defaultValue": "off", "rules": [
+	    {"key": "exp", "status": "stopped", "variations": ["a", "b"]},
+	    {"force": "on"}
+	  ]},
+	  "running": {"defaultValue": "off", "rules": [
+	    {"key": "exp", "releasedVariation": 1, "variations": ["a", "b"], "weights": [1, 0]}
+	  ]}
+	}`), &features)
+	require.Nil(t, err)
+	opts.Features = features
+	e := New(context.TODO(), opts)
+	fres := e.EvalFeature("released")
+	require.Equal(t, "b", fres.Value)
+	require.Equal(t, ReleasedReason, fres.ExperimentResult.Reason)
+	require.False(t, fres.InExperiment())
+	require.Equal(t, "on", e.EvalFeature("notReleased").Value)
+	require.Equal(t, "a", e.EvalFeature("running").Value)
+}
+
+func TestPrecomputedRanges(t *testing.T) {
+	var features FeatureMap
+	err := json.Unmarshal([]byte(`{"feature": {"defaultValue": "off", "rules": [
+	  {"key": "exp", "variations": ["a", "b"], "weights": [0, 1]}
+	]}}`), &features)
+	require.Nil(t, err)
+	original := features["feature"]
+	features = features.PrecomputeRanges()
+	require.Equal(t, []BucketRange{{Min: 0, Max: 0}, {Min: 0, Max: 1}}, features["feature"].Rules[0].ranges)
+	require.Nil(t, features.Clone()["feature"].Rules[0].ranges)
+	// Original features are not modified, and precomputed ones are not copied again
+	require.Nil(t, original.Rules[0].ranges)
+	require.Same(t, features["feature"], features.PrecomputeRanges()["feature"])
+
+	eval := func(overrides ExperimentOverrides) *FeatureResult {
+		opts := &Options{Attributes: NewAttributeValues(Attributes{"id": "1"}), Features: features, Overrides: overrides}
+		return New(context.TODO(), opts).EvalFeature("feature")
+	}
+	require.Equal(t, "b", eval(nil).Value)
+	require.Equal(t, "a", eval(ExperimentOverrides{"exp": {Weights: []float64{1, 0}}}).Value)
+}
+
+func BenchmarkEvalFeatureManyExperiments(b *testing.B) {
+	// Low coverage excludes the user after bucketing, so every rule is evaluated
+	rules := make([]FeatureRule, 100)
+	for i := range rules {
+		coverage := 0.001
+		rules[i] = FeatureRule{
+			Key:        "exp" + strconv.Itoa(i),
+			Variations: []FeatureValue{0, 1, 2},
+			Weights:    []float64{0.5, 0.25, 0.25},
+			Coverage:   &coverage,
+		}
+	}
+	bench := func(b *testing.B, precompute bool) {
+		features := FeatureMap{"feature": {DefaultValue: 0, Rules: rules}}.Clone()
+		if precompute {
+			features = features.PrecomputeRanges()
+		}
+		opts := &Options{Attributes: NewAttributeValues(Attributes{"id": "1"}), Features: features}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			New(context.TODO(), opts).EvalFeature("feature")
+		}
+	}
+	b.Run("computed", func(b *testing.B) { bench(b, false) })
+	b.Run("precomputed", func(b *testing.B) { bench(b, true) })
+}
+
+func TestExperimentReasons(t *testing.T) {
+	var features FeatureMap
+	err := json.Unmarshal([]byte(`{"parent": {"defaultValue": false}}`), &features)
+	require.Nil(t, err)
+	run := func(exp Experiment, attrs Attributes, modify func(*Options)) *ExperimentResult {
+		exp.Key = "exp"
+		if exp.Variations == nil {
+			exp.Variations = []FeatureValue{"a", "b"}
+		}
+		opts := &Options{Attributes: NewAttributeValues(attrs), Features: features}
+		if modify != nil {
+			modify(opts)
+		}
+		return New(context.TODO(), opts).RunExperiment(&exp)
+	}
+	user := Attributes{"id": "1", "country": "US"}
+	zero, one := 0.0, 1
+	inactive := false
+	cases := []struct {
+		name   string
+		exp    Experiment
+		attrs  Attributes
+		modify func(*Options)
+		reason ExperimentReason
+	}{
+		{"assigned", Experiment{}, user, nil, AssignedReason},
+		{"invalid", Experiment{Variations: []FeatureValue{"a"}}, user, nil, ExcludedInvalidReason},
+		{"disabled", Experiment{}, user, func(o *Options) { o.Disabled = true }, ExcludedDisabledReason},
+		{"forced variation", Experiment{}, user, func(o *Options) { o.ForcedVariations = ForcedVariationsMap{"exp": 1} }, ForcedVariationReason},
+		{"inactive", Experiment{Active: &inactive}, user, nil, ExcludedInactiveReason},
+		{"missing hash attribute", Experiment{}, Attributes{}, nil, ExcludedMissingHashAttributeReason},
+		{"filtered", Experiment{Filters: []Filter{{Seed: "s", Ranges: []BucketRange{{Min: 0, Max: 0}}}}}, user, nil, ExcludedFilteredReason},
+		{"condition", Experiment{Condition: MustCondition(map[string]any{"country": "CA"})}, user, nil, ExcludedConditionReason},
+		{"prerequisite", Experiment{ParentConditions: []ParentCondition{{Id: "parent", Condition: MustCondition(map[string]any{"value": true})}}}, user, nil, ExcludedPrerequisiteReason},
+		{"coverage", Experiment{Coverage: &zero}, user, nil, ExcludedCoverageReason},
+		{"forced by rule", Experiment{Force: &one}, user, nil, ForcedRuleReason},
+		{"qa mode", Experiment{}, user, func(o *Options) { o.QaMode = true }, ExcludedQaModeReason},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			res := run(c.exp, c.attrs, c.modify)
+			require.Equal(t, c.reason, res.Reason)
+			require.Equal(t, c.reason.Excluded(), !res.InExperiment)
+		})
+	}
+
+	data, err := json.Marshal(&ExperimentResult{})
+	require.Nil(t, err)
+	require.NotContains(t, string(data), "reason")
+}
+
+func TestDeadlineInPrerequisites(t *testing.T) {
+	var features FeatureMap
+	err := json.Unmarshal([]byte(`{
+	  "child": {"defaultValue": "default", "rules": [
+	    {"parentConditions": [{"id": "parent", "condition": {"value": "off"}}], "force": "forced"}
+	  ]}
+	}`), &features)
+	require.Nil(t, err)
+	// Parent feature is slow to load, so it falls back to its default value
+	parent := FeatureMap{"parent": {DefaultValue: "off", Rules: []FeatureRule{{Force: "on"}}}}
+	opts := &Options{
+		Attributes: NewAttributeValues(Attributes{"id": "1"}),
+		Features:   features,
+		Deadline:   time.Now().Add(20 * time.Millisecond),
+		Logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Fallback: func(key string) (FeatureMap, SavedGroups, bool) {
+			time.Sleep(30 * time.Millisecond)
+			return parent, nil, true
+		},
+	}
+
+	res := New(context.TODO(), opts).EvalFeature("child")
+	require.Equal(t, "default", res.Value)
+	require.Equal(t, TimeoutResultSource, res.Source)
+
+	exp := Experiment{
+		Key:              "exp",
+		Variations:       []FeatureValue{"a", "b"},
+		ParentConditions: []ParentCondition{{Id: "parent", Condition: MustCondition(map[string]any{"value": "off"})}},
+	}
+	opts.Deadline = time.Now().Add(20 * time.Millisecond)
+	expRes := New(context.TODO(), opts).RunExperiment(&exp)
+	require.Equal(t, ExcludedPrerequisiteReason, expRes.Reason)
+}
@@ -0,0 +1,151 @@
+package eval
+
+import (
+	"strconv"
+
+	"github.com/growthbook/growthbook-golang/internal/condition"
+)
+
+// ExperimentStatus is the lifecycle stage of the experiment, running if empty.
+type ExperimentStatus string
+
+const (
+	DraftStatus   ExperimentStatus = "draft"
+	RunningStatus ExperimentStatus = "running"
+	StoppedStatus ExperimentStatus = "stopped"
+)
+
+// Experiment defines a single experiment.
+type Experiment struct {
+	// The globally unique identifier for the experiment
+	Key string `json:"key"`
+	// The different variations to choose between
+	Variations []FeatureValue `json:"variations"`
+	// How to weight traffic between variations. Must add to 1.
+	Weights []float64 `json:"weights,omitempty"`
+	// If set to false, always return the control (first variation)
+	Active *bool `json:"active,omitempty"`
+	// What percent of users should be included in the experiment (between 0 and 1, inclusive)
+	Coverage *float64 `json:"coverage,omitempty"`
+	// Array of ranges, one per variation
+	Ranges []BucketRange `json:"ranges,omitempty"`
+	// Optional targeting condition
+	Condition condition.Base `json:"condition"`
+	// Each item defines a prerequisite where a condition must evaluate against a parent feature's value (identified by id).
+	ParentConditions []ParentCondition `json:"parentConditions,omitempty"`
+	// Adds the experiment to a namespace
+	Namespace *Namespace `json:"namespace,omitempty"`
+	// All users included in the experiment will be forced into the specific variation index
+	Force *int `json:"force,omitempty"`
+	// What user attribute should be used to assign variations (defaults to id)
+	HashAttribute string `json:"hashAttribute,omitempty"`
+	// When using sticky bucketing, can be used as a fallback to assign variations
+	FallbackAttribute string `json:"fallbackAttribute,omitempty"`
+	// The hash version to use (default to 1)
+	HashVersion int `json:"hashVersion,omitempty"`
+	// Meta info about the variations
+	Meta []VariationMeta `json:"meta,omitempty"`
+	// Array of filters to apply
+	Filters []Filter `json:"filters,omitempty"`
+	// The hash seed to use
+	Seed string `json:"seed,omitempty"`
+	// Human-readable name for the experiment
+	Name string `json:"name,omitempty"`
+	// Id of the current experiment phase
+	Phase string `json:"phase,omitempty"`
+	// If true, sticky bucketing will be disabled for this experiment.
+	// (Note: sticky bucketing is only available if a StickyBucketingService is provided in the Context)
+	DisableStickyBucketing bool `json:"disableStickyBucketing,omitempty"`
+	// An sticky bucket version number that can be used to force a re-bucketing of users (default to 0)
+	BucketVersion int `json:"bucketVersion,omitempty"`
+	// Any users with a sticky bucket version less than this will be excluded from the experiment
+	MinBucketVersion int `json:"minBucketVersion,omitempty"`
+	// Only users in any of the groups are included, see [Options.Groups]
+	Groups []string `json:"groups,omitempty"`
+	// Draft experiments run only when forced via query string or forced variations,
+	// stopped ones return the released variation set by Force to everyone
+	Status ExperimentStatus `json:"status,omitempty"`
+}
+
+// NewExperiment creates an experiment with default settings: active,
+// but all other fields empty.
+func NewExperiment(key string) *Experiment {
+	return &Experiment{
+		Key: key,
+	}
+}
+
+func experimentFromFeatureRule(featureId string, rule *FeatureRule) *Experiment {
+	expKey := rule.Key
+	if expKey == "" {
+		expKey = featureId
+	}
+
+	exp := Experiment{
+		Key:                    expKey,
+		Variations:             rule.Variations,
+		Coverage:               rule.Coverage,
+		Weights:                rule.Weights,
+		HashAttribute:          rule.HashAttribute,
+		FallbackAttribute:      rule.FallbackAttribute,
+		Namespace:              rule.Namespace,
+		Meta:                   rule.Meta,
+		Ranges:                 rule.Ranges,
+		Name:                   rule.Name,
+		Phase:                  rule.Phase,
+		Seed:                   rule.Seed,
+		HashVersion:            rule.HashVersion,
+		Filters:                rule.Filters,
+		Condition:              rule.Condition,
+		ParentConditions:       rule.ParentConditions,
+		DisableStickyBucketing: rule.DisableStickyBucketing,
+		BucketVersion:          rule.BucketVersion,
+		MinBucketVersion:       rule.MinBucketVersion,
+		Status:                 rule.Status,
+	}
+	// Force of a rule experiment only releases the variation, rules force values instead
+	if rule.Status == StoppedStatus {
+		exp.Force = rule.ReleasedVariation
+	}
+	return &exp
+}
+
+func (e *Experiment) getCoverage() float64 {
+	if e.Coverage == nil {
+		return 1.0
+	}
+	return *e.Coverage
+}
+
+func (e *Experiment) getSeed() string {
+	if e.Seed == "" {
+		return e.Key
+	}
+	return e.Seed
+}
+
+func (e *Experiment) getActive() bool {
+	if e.Active == nil {
+		return true
+	}
+	return *e.Active
+}
+
+// variationKey returns the meta key of the variation, defaults to the variation index.
+func (e *Experiment) variationKey(variationId int) string {
+	if variationId >= 0 && variationId < len(e.Meta) && e.Meta[variationId].Key != "" {
+		return e.Meta[variationId].Key
+	}
+	return strconv.Itoa(variationId)
+}
+
+// VariationIndexByKey returns index of the variation with the key, or -1 if there is none.
+// Variations without meta key are matched by their index, like [ExperimentResult.Key].
+func (e *Experiment) VariationIndexByKey(key string) int {
+	for i := range e.Variations {
+		if e.variationKey(i) == key {
+			return i
+		}
+	}
+	return -1
+}
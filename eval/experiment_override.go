@@ -0,0 +1,106 @@
+package eval
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+// ExperimentOverride changes settings of the experiment without changing the payload,
+// e.g. from an operational override file. Empty fields keep the experiment settings.
+type ExperimentOverride struct {
+	// Targeting condition used instead of the experiment one
+	Condition *Condition `json:"condition,omitempty"`
+	// How to weight traffic between variations. Must add to 1.
+	Weights []float64 `json:"weights,omitempty"`
+	// If set to false, always return the control (first variation)
+	Active *bool `json:"active,omitempty"`
+	// Status of the experiment, see [Experiment.Status]
+	Status ExperimentStatus `json:"status,omitempty"`
+	// All users included in the experiment will be forced into the variation index
+	Force *int `json:"force,omitempty"`
+	// What percent of users should be included in the experiment (between 0 and 1, inclusive)
+	Coverage *float64 `json:"coverage,omitempty"`
+	// Only users in any of the groups, see [Options.Groups], are included
+	Groups []string `json:"groups,omitempty"`
+	// Adds the experiment to a namespace
+	Namespace *Namespace `json:"namespace,omitempty"`
+	// Regular expression the current page URL must match
+	Url string `json:"url,omitempty"`
+
+	urlRegexp *regexp.Regexp
+}
+
+// ExperimentOverrides maps experiment keys to their overrides.
+type ExperimentOverrides map[string]ExperimentOverride
+
+// Compile returns copy of the overrides with URL patterns compiled, so evaluations
+// don't compile them every time. Returns error if a pattern is invalid.
+func (o ExperimentOverrides) Compile() (ExperimentOverrides, error) {
+	res := make(ExperimentOverrides, len(o))
+	for key, override := range o {
+		if override.Url != "" && override.urlRegexp == nil {
+			re, err := regexp.Compile(override.Url)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid url of experiment %q override: %w", key, err)
+			}
+			override.urlRegexp = re
+		}
+		res[key] = override
+	}
+	return res, nil
+}
+
+// applyOverride returns copy of the experiment with the override applied
+// and the URL pattern, if any.
+func (e *Evaluator) applyOverride(exp *Experiment) (*Experiment, *regexp.Regexp) {
+	o, ok := e.opts.Overrides[exp.Key]
+	if !ok {
+		return exp, nil
+	}
+	res := *exp
+	if o.Condition != nil {
+		res.Condition = *o.Condition
+	}
+	if o.Weights != nil {
+		res.Weights = o.Weights
+	}
+	if o.Active != nil {
+		res.Active = o.Active
+	}
+	if o.Status != "" {
+		res.Status = o.Status
+	}
+	if o.Force != nil {
+		res.Force = o.Force
+	}
+	if o.Coverage != nil {
+		res.Coverage = o.Coverage
+	}
+	if o.Groups != nil {
+		res.Groups = o.Groups
+	}
+	if o.Namespace != nil {
+		res.Namespace = o.Namespace
+	}
+	re := o.urlRegexp
+	if re == nil && o.Url != "" {
+		var err error
+		if re, err = regexp.Compile(o.Url); err != nil {
+			e.logger.Warn("Invalid experiment override url", "id", exp.Key, "error", err)
+		}
+	}
+	return &res, re
+}
+
+// urlMatches reports whether the full URL or its path with query matches the pattern.
+func urlMatches(re *regexp.Regexp, u *url.URL) bool {
+	if u == nil {
+		return false
+	}
+	if re.MatchString(u.String()) {
+		return true
+	}
+	path := url.URL{Path: u.Path, RawPath: u.RawPath, RawQuery: u.RawQuery, Fragment: u.Fragment}
+	return re.MatchString(path.String())
+}
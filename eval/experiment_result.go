@@ -0,0 +1,89 @@
+package eval
+
+import "strings"
+
+type ExperimentResult struct {
+	// Whether or not the user is part of the experiment
+	InExperiment bool `json:"inExperiment"`
+	// The array index of the assigned variation
+	VariationId int `json:"variationId"`
+	// The array value of the assigned variation
+	Value FeatureValue `json:"value"`
+	// If a hash was used to assign a variation
+	HashUsed bool `json:"hashUsed"`
+	// The user attribute used to assign a variation
+	HashAttribute string `json:"hashAttribute"`
+	// The value of hash attribute
+	HashValue string `json:"hashValue"`
+	// The id of the feature (if any) that the experiment came from
+	FeatureId string `json:"featureId"`
+	// The unique key for the assigned variation
+	Key string `json:"key"`
+	// The hash value used to assign a variation (float from 0 to 1)
+	Bucket *float64 `json:"bucket,omitempty"`
+	// The human-readable name of the assigned variation
+	Name string `json:"name,omitempty"`
+	// Used for holdout groups
+	Passthrough bool `json:"passthrough,omitempty"`
+	// If sticky bucketing was used to assign a variation
+	StickyBucketUsed bool `json:"stickyBucketUsed,omitempty"`
+	// Why the user was assigned the variation or excluded from the experiment
+	Reason ExperimentReason `json:"reason,omitempty"`
+}
+
+// ExperimentReason is a machine-readable reason of the experiment result. Exclusion
+// reasons start with "excluded:", so exposure analysis can tell exclusion types apart.
+type ExperimentReason string
+
+// ExperimentReason values.
+const (
+	// Assigned by hashing
+	AssignedReason ExperimentReason = "assigned"
+	// Assigned the variation saved by sticky bucketing
+	StickyBucketReason ExperimentReason = "assigned:stickyBucket"
+	// Forced via URL query string
+	ForcedQueryStringReason ExperimentReason = "forced:querystring"
+	// Forced via forced variations of the client
+	ForcedVariationReason ExperimentReason = "forced:variation"
+	// Forced by the experiment force setting
+	ForcedRuleReason ExperimentReason = "forced:rule"
+	// Released variation of the stopped experiment
+	ReleasedReason ExperimentReason = "released"
+
+	ExcludedInvalidReason              ExperimentReason = "excluded:invalid"
+	ExcludedDisabledReason             ExperimentReason = "excluded:disabled"
+	ExcludedDraftReason                ExperimentReason = "excluded:draft"
+	ExcludedStoppedReason              ExperimentReason = "excluded:stopped"
+	ExcludedInactiveReason             ExperimentReason = "excluded:inactive"
+	ExcludedMissingHashAttributeReason ExperimentReason = "excluded:missingHashAttribute"
+	ExcludedFilteredReason             ExperimentReason = "excluded:filtered"
+	ExcludedNamespaceReason            ExperimentReason = "excluded:namespace"
+	ExcludedConditionReason            ExperimentReason = "excluded:condition"
+	ExcludedGroupsReason               ExperimentReason = "excluded:groups"
+	ExcludedPrerequisiteReason         ExperimentReason = "excluded:prerequisite"
+	ExcludedUrlReason                  ExperimentReason = "excluded:url"
+	ExcludedHashVersionReason          ExperimentReason = "excluded:hashVersion"
+	ExcludedStickyBucketReason         ExperimentReason = "excluded:stickyBucketVersion"
+	ExcludedCoverageReason             ExperimentReason = "excluded:coverage"
+	ExcludedQaModeReason               ExperimentReason = "excluded:qaMode"
+)
+
+// Excluded reports whether the reason is an exclusion from the experiment.
+func (r ExperimentReason) Excluded() bool {
+	return strings.HasPrefix(string(r), "excluded:")
+}
+
+// DecodeValue json-decodes the assigned variation value into target, which must be a pointer.
+// Returns [ErrInvalidFeatureValue] if the value doesn't match the target type.
+func (res *ExperimentResult) DecodeValue(target any) error {
+	return decodeValue(res.Value, target)
+}
+
+// VariationName returns human-readable name of the assigned variation,
+// falls back to the variation key.
+func (res *ExperimentResult) VariationName() string {
+	if res.Name != "" {
+		return res.Name
+	}
+	return res.Key
+}
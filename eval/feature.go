@@ -0,0 +1,60 @@
+package eval
+
+// Feature has a default value plus rules than can override the
+// default.
+type Feature struct {
+	// DefaultValue is optional default value
+	DefaultValue FeatureValue `json:"defaultValue"`
+	//Rules determine when and how the [DefaultValue] gets overridden
+	Rules []FeatureRule `json:"rules"`
+}
+
+// Map of [Feature]. Keys are string ids for the features.
+// Values are pointers to [Feature] structs.
+type FeatureMap map[string]*Feature
+
+// Clone deep copies the features. Values decoded from JSON are copied,
+// other values set programmatically may still be shared.
+func (m FeatureMap) Clone() FeatureMap {
+	if m == nil {
+		return nil
+	}
+	res := make(FeatureMap, len(m))
+	for key, f := range m {
+		res[key] = f.clone()
+	}
+	return res
+}
+
+// clone deep copies the feature. Values decoded from JSON are copied,
+// other values set programmatically may still be shared.
+func (f *Feature) clone() *Feature {
+	if f == nil {
+		return nil
+	}
+	res := Feature{
+		DefaultValue: jsonDeepCopy(f.DefaultValue),
+	}
+	if f.Rules != nil {
+		res.Rules = make([]FeatureRule, len(f.Rules))
+		for i := range f.Rules {
+			res.Rules[i] = f.Rules[i].clone()
+		}
+	}
+	return &res
+}
+
+// NewFeature creates feature with the default value and no rules.
+func NewFeature(defaultValue FeatureValue) *Feature {
+	return &Feature{
+		DefaultValue: defaultValue,
+	}
+}
+
+// WithRules appends copies of the rules to the feature.
+func (f *Feature) WithRules(rules ...*FeatureRule) *Feature {
+	for _, rule := range rules {
+		f.Rules = append(f.Rules, *rule)
+	}
+	return f
+}
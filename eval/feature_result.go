@@ -0,0 +1,117 @@
+package eval
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// FeatureResult is the result of evaluating a feature.
+type FeatureResult struct {
+	RuleId           string              `json:"ruleId"`
+	Value            FeatureValue        `json:"value"`
+	Source           FeatureResultSource `json:"source"`
+	On               bool                `json:"on"`
+	Off              bool                `json:"off"`
+	Experiment       *Experiment         `json:"experiment,omitempty"`
+	ExperimentResult *ExperimentResult   `json:"experimentResult,omitempty"`
+	// Passthrough assignments of earlier experiment rules, e.g. holdout groups,
+	// after which evaluation continued to the rule that resolved the value.
+	Passthrough []PassthroughAssignment `json:"passthrough,omitempty"`
+}
+
+// PassthroughAssignment is an assignment to a passthrough variation of the experiment rule.
+type PassthroughAssignment struct {
+	Experiment       *Experiment       `json:"experiment"`
+	ExperimentResult *ExperimentResult `json:"experimentResult"`
+}
+
+// FeatureResultSource is an enumerated type representing the source
+// of a FeatureResult.
+type FeatureResultSource string
+
+// FeatureResultSource values.
+const (
+	UnknownFeatureResultSource     FeatureResultSource = "unknownFeature"
+	DefaultsResultSource           FeatureResultSource = "defaults"
+	DefaultValueResultSource       FeatureResultSource = "defaultValue"
+	ForceResultSource              FeatureResultSource = "force"
+	ExperimentResultSource         FeatureResultSource = "experiment"
+	OverrideResultSource           FeatureResultSource = "override"
+	PrerequisiteResultSource       FeatureResultSource = "prerequisite"
+	CyclicPrerequisiteResultSource FeatureResultSource = "cyclicPrerequisite"
+	TimeoutResultSource            FeatureResultSource = "timeout"
+	StalePayloadResultSource       FeatureResultSource = "stalePayload"
+)
+
+func getFeatureResult(
+	v FeatureValue,
+	source FeatureResultSource,
+	ruleId string,
+	experiment *Experiment,
+	experimentResult *ExperimentResult,
+) *FeatureResult {
+	on := truthy(v)
+	res := &FeatureResult{
+		Value:            v,
+		Source:           source,
+		On:               on,
+		Off:              !on,
+		Experiment:       experiment,
+		ExperimentResult: experimentResult,
+		RuleId:           ruleId,
+	}
+	return res
+}
+
+func (res *FeatureResult) InExperiment() bool {
+	return res.Experiment != nil &&
+		res.ExperimentResult != nil &&
+		res.ExperimentResult.InExperiment
+}
+
+// BoolValue returns the feature value if it is a boolean, otherwise def.
+func (res *FeatureResult) BoolValue(def bool) bool {
+	if v, ok := res.Value.(bool); ok {
+		return v
+	}
+	return def
+}
+
+// IntValue returns the feature value if it is a whole number, otherwise def.
+// JSON numbers are decoded as float64 or json.Number, so those are converted
+// when they have no fractional part.
+func (res *FeatureResult) IntValue(def int) int {
+	switch v := res.Value.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		if v == math.Trunc(v) && v >= math.MinInt && v < math.MaxInt {
+			return int(v)
+		}
+	case json.Number:
+		if i, err := strconv.ParseInt(string(v), 10, 0); err == nil {
+			return int(i)
+		}
+		if f, err := v.Float64(); err == nil && f == math.Trunc(f) && f >= math.MinInt && f < math.MaxInt {
+			return int(f)
+		}
+	}
+	return def
+}
+
+// StringValue returns the feature value if it is a string, otherwise def.
+func (res *FeatureResult) StringValue(def string) string {
+	if v, ok := res.Value.(string); ok {
+		return v
+	}
+	return def
+}
+
+// DecodeValue json-decodes the feature value into target, which must be a pointer.
+// Returns [ErrInvalidFeatureValue] if the value doesn't match the target type.
+func (res *FeatureResult) DecodeValue(target any) error {
+	return decodeValue(res.Value, target)
+}
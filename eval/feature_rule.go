@@ -0,0 +1,145 @@
+package eval
+
+import (
+	"slices"
+
+	"github.com/growthbook/growthbook-golang/internal/condition"
+)
+
+type FeatureRule struct {
+	// Optional rule id, reserved for future use
+	Id string `json:"id"`
+	// Optional targeting condition
+	Condition condition.Base `json:"condition"`
+	// Each item defines a prerequisite where a condition must evaluate against a parent feature's value (identified by id).
+	// If gate is true, then this is a blocking feature-level prerequisite; otherwise it applies to the current rule only.
+	ParentConditions []ParentCondition `json:"parentConditions"`
+	// What percent of users should be included in the experiment (between 0 and 1, inclusive)
+	Coverage *float64 `json:"coverage"`
+	// Immediately force a specific value (ignore every other option besides condition and coverage)
+	Force FeatureValue `json:"force"`
+	// Run an experiment (A/B test) and randomly choose between these variations
+	Variations []FeatureValue `json:"variations"`
+	// The globally unique tracking key for the experiment (default to the feature key)
+	Key string `json:"key"`
+	// How to weight traffic between variations. Must add to 1.
+	Weights []float64 `json:"weights"`
+	// Adds the experiment to a namespace
+	Namespace *Namespace `json:"namespace"`
+	// What user attribute should be used to assign variations (defaults to id)
+	HashAttribute string `json:"hashAttribute"`
+	// When using sticky bucketing, can be used as a fallback to assign variations
+	FallbackAttribute string `json:"fallbackAttribute"`
+	// The hash version to use (default to 1)
+	HashVersion int `json:"hashVersion"`
+	// A more precise version of coverage
+	Range *BucketRange `json:"range"`
+	// Ranges for experiment variations
+	Ranges []BucketRange `json:"ranges"`
+	// Meta info about the experiment variations
+	Meta []VariationMeta `json:"meta"`
+	// Slice of filters to apply to the rule
+	Filters []Filter `json:"filters"`
+	// Seed to use for hashing
+	Seed string `json:"seed"`
+	//Human-readable name for the experiment
+	Name string `json:"name"`
+	// The phase id of the experiment
+	Phase string `json:"phase"`
+	// If true, sticky bucketing will be disabled for this experiment
+	DisableStickyBucketing bool `json:"disableStickyBucketing"`
+	// Sticky bucket version number that can be used to force a re-bucketing of users
+	BucketVersion int `json:"bucketVersion"`
+	// Any users with a sticky bucket version less than this will be excluded from the experiment
+	MinBucketVersion int `json:"minBucketVersion"`
+	// Status of the experiment, see [Experiment.Status]
+	Status ExperimentStatus `json:"status"`
+	// Variation served to everyone once the experiment is stopped
+	ReleasedVariation *int `json:"releasedVariation"`
+
+	// Ranges computed from coverage and weights by [FeatureMap.PrecomputeRanges]
+	ranges []BucketRange
+}
+
+func (r *FeatureRule) clone() FeatureRule {
+	res := *r
+	res.ParentConditions = slices.Clone(r.ParentConditions)
+	res.Coverage = clonePtr(r.Coverage)
+	res.ReleasedVariation = clonePtr(r.ReleasedVariation)
+	res.Force = jsonDeepCopy(r.Force)
+	if r.Variations != nil {
+		res.Variations = make([]FeatureValue, len(r.Variations))
+		for i, v := range r.Variations {
+			res.Variations[i] = jsonDeepCopy(v)
+		}
+	}
+	res.Weights = slices.Clone(r.Weights)
+	res.Namespace = clonePtr(r.Namespace)
+	res.Range = clonePtr(r.Range)
+	res.Ranges = slices.Clone(r.Ranges)
+	res.ranges = nil
+	res.Meta = slices.Clone(r.Meta)
+	if r.Filters != nil {
+		res.Filters = make([]Filter, len(r.Filters))
+		for i, f := range r.Filters {
+			f.Ranges = slices.Clone(f.Ranges)
+			res.Filters[i] = f
+		}
+	}
+	return res
+}
+
+// NewFeatureRule creates empty rule. Use With* methods to set it up.
+func NewFeatureRule() *FeatureRule {
+	return &FeatureRule{}
+}
+
+// WithId sets rule id.
+func (r *FeatureRule) WithId(id string) *FeatureRule {
+	r.Id = id
+	return r
+}
+
+// WithCondition sets targeting condition.
+func (r *FeatureRule) WithCondition(cond Condition) *FeatureRule {
+	r.Condition = cond
+	return r
+}
+
+// WithParentCondition adds prerequisite condition evaluated against the parent feature value.
+func (r *FeatureRule) WithParentCondition(id string, cond Condition, gate bool) *FeatureRule {
+	r.ParentConditions = append(r.ParentConditions, ParentCondition{Id: id, Condition: cond, Gate: gate})
+	return r
+}
+
+// WithForce makes rule force the value.
+func (r *FeatureRule) WithForce(value FeatureValue) *FeatureRule {
+	r.Force = value
+	return r
+}
+
+// WithRollout limits rule to the coverage part of users, hashed by the attribute.
+func (r *FeatureRule) WithRollout(coverage float64, hashAttribute string) *FeatureRule {
+	r.Coverage = &coverage
+	r.HashAttribute = hashAttribute
+	return r
+}
+
+// WithExperiment makes rule run experiment with the key between the variations.
+func (r *FeatureRule) WithExperiment(key string, variations ...FeatureValue) *FeatureRule {
+	r.Key = key
+	r.Variations = variations
+	return r
+}
+
+// WithWeights sets weights of the experiment variations.
+func (r *FeatureRule) WithWeights(weights ...float64) *FeatureRule {
+	r.Weights = weights
+	return r
+}
+
+// WithHashAttribute sets user attribute used to assign variations.
+func (r *FeatureRule) WithHashAttribute(hashAttribute string) *FeatureRule {
+	r.HashAttribute = hashAttribute
+	return r
+}
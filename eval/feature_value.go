@@ -0,0 +1,61 @@
+package eval
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// FeatureValue is a wrapper around an arbitrary type representing the
+// value of a feature.
+type FeatureValue any
+
+// This function imitates Javascript's "truthiness" evaluation for Go
+// values of unknown type.
+func truthy(v FeatureValue) bool {
+	if v == nil {
+		return false
+	}
+	switch r := v.(type) {
+	case string:
+		return r != ""
+	case bool:
+		return r
+	case int:
+		return r != 0
+	case uint:
+		return r != 0
+	case float32:
+		return r != 0
+	case float64:
+		return r != 0
+	case json.Number:
+		f, err := r.Float64()
+		return err != nil || f != 0
+	}
+	return true
+}
+
+// ErrInvalidFeatureValue is returned when a feature value can't be decoded into the target type.
+var ErrInvalidFeatureValue = errors.New("Invalid feature value")
+
+// decodeValue json-decodes the value into target. Unknown fields are rejected.
+// If target has Validate() error method, it is called after decoding.
+func decodeValue(v FeatureValue, target any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidFeatureValue, err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(target); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidFeatureValue, err)
+	}
+	if validator, ok := target.(interface{ Validate() error }); ok {
+		if err := validator.Validate(); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidFeatureValue, err)
+		}
+	}
+	return nil
+}
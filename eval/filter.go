@@ -0,0 +1,12 @@
+package eval
+
+// Filter represents a filter condition for experiment mutual
+// exclusion.
+type Filter struct {
+	Seed      string        `json:"seed"`
+	Ranges    []BucketRange `json:"ranges"`
+	Attribute string        `json:"attribute,omitempty"`
+	// Attribute hashed if Attribute is missing, e.g. device id for anonymous users
+	FallbackAttribute string `json:"fallbackAttribute,omitempty"`
+	HashVersion       int    `json:"hashVersion,omitempty"`
+}
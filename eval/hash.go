@@ -0,0 +1,12 @@
+package eval
+
+import "github.com/growthbook/growthbook-golang/hashutil"
+
+// Main hash function. Default version is 1.
+func hash(seed string, hashValue string, version int) *float64 {
+	v, ok := hashutil.Hash(seed, hashValue, version)
+	if !ok {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,88 @@
+package eval
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/growthbook/growthbook-golang/hashutil"
+)
+
+// LintCode identifies kind of the [LintFinding].
+type LintCode string
+
+const (
+	// Rule is never evaluated, because an earlier rule forces value for everyone
+	UnreachableRuleLint LintCode = "unreachableRule"
+	// Rule depends on attribute missing from the schema
+	UnknownAttributeLint LintCode = "unknownAttribute"
+	// Experiment weights don't match variations or don't add up to 1
+	InvalidWeightsLint LintCode = "invalidWeights"
+	// Rule condition compares attribute with value of type different from the schema
+	AttributeTypeLint LintCode = "attributeType"
+)
+
+// LintFinding is a problem found in the features payload.
+type LintFinding struct {
+	Feature string   `json:"feature"`
+	Rule    int      `json:"rule"`
+	RuleId  string   `json:"ruleId,omitempty"`
+	Code    LintCode `json:"code"`
+	Message string   `json:"message"`
+}
+
+// Lint inspects features for unreachable rules, experiments with invalid weights
+// and, if schema is not nil, rules depending on attributes missing from the schema
+// or comparing attributes with values of other types, see [AttributeSchema.CheckTypes].
+// Findings are sorted by feature key and rule index.
+func Lint(features FeatureMap, schema AttributeSchema) []LintFinding {
+	var res []LintFinding
+	keys := make([]string, 0, len(features))
+	for key := range features {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		feature := features[key]
+		if feature == nil {
+			continue
+		}
+		shadowedBy := -1
+		for i := range feature.Rules {
+			rule := &feature.Rules[i]
+			add := func(code LintCode, format string, args ...any) {
+				res = append(res, LintFinding{key, i, rule.Id, code, fmt.Sprintf(format, args...)})
+			}
+
+			if shadowedBy >= 0 {
+				add(UnreachableRuleLint, "rule is shadowed by rule %d that forces value for everyone", shadowedBy)
+			} else if rule.forcesEveryone() {
+				shadowedBy = i
+			}
+
+			if len(rule.Variations) > 0 && len(rule.Weights) > 0 && !hashutil.ValidWeights(len(rule.Variations), rule.Weights) {
+				add(InvalidWeightsLint, "weights %v don't match %d variations or don't add up to 1", rule.Weights, len(rule.Variations))
+			}
+
+			if schema != nil {
+				attrs := rule.attributes()
+				slices.Sort(attrs)
+				for _, attr := range slices.Compact(attrs) {
+					if !schema.has(attr) {
+						add(UnknownAttributeLint, "attribute %q is not in the schema", attr)
+					}
+				}
+				for _, msg := range schema.CheckTypes(rule.Condition) {
+					add(AttributeTypeLint, "%s", msg)
+				}
+			}
+		}
+	}
+	return res
+}
+
+// forcesEveryone reports whether the rule forces value without any targeting.
+func (r *FeatureRule) forcesEveryone() bool {
+	return r.Force != nil && r.Condition.IsEmpty() && len(r.ParentConditions) == 0 &&
+		len(r.Filters) == 0 && r.Coverage == nil && r.Range == nil
+}
@@ -0,0 +1,52 @@
+package eval
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLint(t *testing.T) {
+	var features FeatureMap
+	err := json.Unmarshal([]byte(`{
+	  "b": {"defaultValue": 0, "rules": [
+	    {"id": "r0", "condition": {"country": "US"}, "force": 1},
+	    {"id": "r1", "force": 2},
+	    {"id": "r2", "condition": {"user.plan": "pro"}, "force": 3}
+	  ]},
+	  "a": {"defaultValue": 0, "rules": [
+	    {"force": 1, "coverage": 0.5},
+	    {"key": "exp", "variations": [0, 1], "weights": [0.5, 0.6], "hashAttribute": "deviceId"}
+	  ]}
+	}`), &features)
+	require.Nil(t, err)
+
+	findings := Lint(features, AttributeSchema{"id": "string", "user": "object"})
+	codes := []LintCode{}
+	for _, f := range findings {
+		codes = append(codes, f.Code)
+	}
+	require.Equal(t, []LintCode{InvalidWeightsLint, UnknownAttributeLint, UnknownAttributeLint, UnreachableRuleLint}, codes)
+	require.Equal(t, LintFinding{"a", 1, "", UnknownAttributeLint, `attribute "deviceId" is not in the schema`}, findings[1])
+	require.Equal(t, LintFinding{"b", 0, "r0", UnknownAttributeLint, `attribute "country" is not in the schema`}, findings[2])
+	require.Equal(t, "b", findings[3].Feature)
+	require.Equal(t, 2, findings[3].Rule)
+
+	require.Len(t, Lint(features, nil), 2)
+}
+
+func TestLintAttributeTypes(t *testing.T) {
+	var features FeatureMap
+	err := json.Unmarshal([]byte(`{
+	  "f": {"defaultValue": 0, "rules": [
+	    {"condition": {"age": {"$gt": "18"}, "tags": "beta"}, "force": 1}
+	  ]}
+	}`), &features)
+	require.Nil(t, err)
+
+	findings := Lint(features, AttributeSchema{"id": "string", "age": "number", "tags": "array"})
+	require.Equal(t, []LintFinding{
+		{"f", 0, "", AttributeTypeLint, `attribute "age" of type number is compared with string`},
+	}, findings)
+}
@@ -1,11 +1,11 @@
-package growthbook
+package eval
 
 // VariationMeta info about an experiment variation.
 type VariationMeta struct {
 	// Key is a unique key for this variation.
-	Key string `json:"key"`
+	Key string `json:"key,omitempty"`
 	// Name is a human-readable name for this variation.
-	Name string `json:"name"`
+	Name string `json:"name,omitempty"`
 	// Passthrough used to implement holdout groups
-	Passthrough bool `json:"passthrough"`
+	Passthrough bool `json:"passthrough,omitempty"`
 }
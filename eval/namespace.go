@@ -0,0 +1,8 @@
+package eval
+
+import "github.com/growthbook/growthbook-golang/hashutil"
+
+// Namespace specifies what part of a namespace an experiment
+// includes. If two experiments are in the same namespace and their
+// ranges don't overlap, they wil be mutually exclusive.
+type Namespace = hashutil.Namespace
@@ -1,9 +1,9 @@
-package growthbook
+package eval
 
 import "github.com/growthbook/growthbook-golang/internal/condition"
 
 type ParentCondition struct {
 	Id        string         `json:"id"`
 	Condition condition.Base `json:"condition"`
-	Gate      bool           `json:"gate"`
+	Gate      bool           `json:"gate,omitempty"`
 }
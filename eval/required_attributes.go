@@ -0,0 +1,54 @@
+package eval
+
+import "slices"
+
+// RequiredAttributes returns sorted attribute paths the feature depends on: referenced
+// by rule conditions, used for hashing by rollouts, experiments and filters, including
+// attributes required by prerequisite features. Returns nil for unknown features.
+func (m FeatureMap) RequiredAttributes(key string) []string {
+	var res []string
+	m.collectRequiredAttributes(key, map[string]bool{}, &res)
+	slices.Sort(res)
+	return slices.Compact(res)
+}
+
+func (m FeatureMap) collectRequiredAttributes(key string, visited map[string]bool, res *[]string) {
+	feature := m[key]
+	if feature == nil || visited[key] {
+		return
+	}
+	visited[key] = true
+
+	for i := range feature.Rules {
+		rule := &feature.Rules[i]
+		*res = append(*res, rule.attributes()...)
+		for _, parent := range rule.ParentConditions {
+			m.collectRequiredAttributes(parent.Id, visited, res)
+		}
+	}
+}
+
+// attributes returns attributes the rule depends on, excluding ones of prerequisite features.
+func (r *FeatureRule) attributes() []string {
+	res := r.Condition.Attributes()
+	for _, filter := range r.Filters {
+		res = append(res, hashAttribute(filter.Attribute))
+		if filter.FallbackAttribute != "" {
+			res = append(res, filter.FallbackAttribute)
+		}
+	}
+	if len(r.Variations) > 0 || r.Coverage != nil || r.Range != nil {
+		res = append(res, hashAttribute(r.HashAttribute))
+		if r.FallbackAttribute != "" {
+			res = append(res, r.FallbackAttribute)
+		}
+	}
+	return res
+}
+
+func hashAttribute(attr string) string {
+	if attr == "" {
+		return "id"
+	}
+	return attr
+}
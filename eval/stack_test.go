@@ -1,4 +1,4 @@
-package growthbook
+package eval
 
 import (
 	"testing"
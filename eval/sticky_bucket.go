@@ -0,0 +1,197 @@
+package eval
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// StickyBucketAssignments maps experiment key with bucket version ("key__version")
+// to the assigned variation key.
+type StickyBucketAssignments map[string]string
+
+// StickyBucketAssignmentDoc keeps all sticky assignments of a single attribute value.
+type StickyBucketAssignmentDoc struct {
+	AttributeName  string                  `json:"attributeName"`
+	AttributeValue string                  `json:"attributeValue"`
+	Assignments    StickyBucketAssignments `json:"assignments"`
+}
+
+// StickyBucketService persists experiment assignments, so users keep their
+// variation when experiment targeting or traffic allocation changes.
+type StickyBucketService interface {
+	// GetAssignments returns assignments doc for the attribute value or nil if there is none.
+	GetAssignments(ctx context.Context, attributeName string, attributeValue string) (*StickyBucketAssignmentDoc, error)
+	// SaveAssignments stores assignments doc, replacing the previous one.
+	SaveAssignments(ctx context.Context, doc *StickyBucketAssignmentDoc) error
+}
+
+// MemoryStickyBucketService is a thread-safe in-memory [StickyBucketService].
+type MemoryStickyBucketService struct {
+	mu   sync.RWMutex
+	docs map[string]*StickyBucketAssignmentDoc
+}
+
+var _ StickyBucketService = &MemoryStickyBucketService{}
+
+// NewMemoryStickyBucketService creates empty in-memory service.
+func NewMemoryStickyBucketService() *MemoryStickyBucketService {
+	return &MemoryStickyBucketService{
+		docs: map[string]*StickyBucketAssignmentDoc{},
+	}
+}
+
+func (s *MemoryStickyBucketService) GetAssignments(_ context.Context, attributeName string, attributeValue string) (*StickyBucketAssignmentDoc, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	doc, ok := s.docs[StickyBucketKey(attributeName, attributeValue)]
+	if !ok {
+		return nil, nil
+	}
+	return doc.Clone(), nil
+}
+
+func (s *MemoryStickyBucketService) SaveAssignments(_ context.Context, doc *StickyBucketAssignmentDoc) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.docs[StickyBucketKey(doc.AttributeName, doc.AttributeValue)] = doc.Clone()
+	return nil
+}
+
+// Len returns number of stored assignments docs.
+func (s *MemoryStickyBucketService) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.docs)
+}
+
+// Docs returns copies of all stored assignments docs, sorted by attribute name and value,
+// e.g. to export them with other services.
+func (s *MemoryStickyBucketService) Docs() []*StickyBucketAssignmentDoc {
+	s.mu.RLock()
+	res := make([]*StickyBucketAssignmentDoc, 0, len(s.docs))
+	for _, doc := range s.docs {
+		res = append(res, doc.Clone())
+	}
+	s.mu.RUnlock()
+	slices.SortFunc(res, func(a, b *StickyBucketAssignmentDoc) int {
+		return cmp.Or(cmp.Compare(a.AttributeName, b.AttributeName), cmp.Compare(a.AttributeValue, b.AttributeValue))
+	})
+	return res
+}
+
+// Clone returns copy of the doc.
+func (doc *StickyBucketAssignmentDoc) Clone() *StickyBucketAssignmentDoc {
+	res := *doc
+	res.Assignments = maps.Clone(doc.Assignments)
+	return &res
+}
+
+// StickyBucketKey returns unique key of the attribute assignments doc.
+func StickyBucketKey(attributeName string, attributeValue string) string {
+	return attributeName + "||" + attributeValue
+}
+
+func stickyBucketExperimentKey(expKey string, bucketVersion int) string {
+	return expKey + "__" + strconv.Itoa(bucketVersion)
+}
+
+func (e *Evaluator) stickyBucketingEnabled(exp *Experiment) bool {
+	return e.opts.StickyBucketService != nil && !exp.DisableStickyBucketing
+}
+
+// fallbackAttribute is used for hashing only together with sticky bucketing.
+func (e *Evaluator) fallbackAttribute(exp *Experiment) string {
+	if !e.stickyBucketingEnabled(exp) {
+		return ""
+	}
+	return exp.FallbackAttribute
+}
+
+// getStickyBucketDoc loads assignments doc once per evaluation.
+func (e *Evaluator) getStickyBucketDoc(attributeName string, attributeValue string) *StickyBucketAssignmentDoc {
+	if attributeValue == "" {
+		return nil
+	}
+	key := StickyBucketKey(attributeName, attributeValue)
+	if doc, ok := e.stickyDocs[key]; ok {
+		return doc
+	}
+	doc, err := e.opts.StickyBucketService.GetAssignments(e.ctx, attributeName, attributeValue)
+	if err != nil {
+		logKey := key
+		if e.opts.RedactAttribute != nil {
+			logKey = StickyBucketKey(attributeName, e.opts.RedactAttribute(attributeName, attributeValue))
+		}
+		e.logger.Warn("Error loading sticky bucket assignments", "key", logKey, "error", err)
+		doc = nil
+	}
+	if e.stickyDocs == nil {
+		e.stickyDocs = map[string]*StickyBucketAssignmentDoc{}
+	}
+	e.stickyDocs[key] = doc
+	return doc
+}
+
+// getStickyBucketAssignments merges fallback attribute assignments with
+// hash attribute ones. Hash attribute assignments take precedence.
+func (e *Evaluator) getStickyBucketAssignments(exp *Experiment) StickyBucketAssignments {
+	res := StickyBucketAssignments{}
+	if exp.FallbackAttribute != "" {
+		attr, value := e.getHashAttribute(exp.FallbackAttribute, "")
+		if doc := e.getStickyBucketDoc(attr, value); doc != nil {
+			maps.Copy(res, doc.Assignments)
+		}
+	}
+	attr, value := e.getHashAttribute(exp.HashAttribute, "")
+	if doc := e.getStickyBucketDoc(attr, value); doc != nil {
+		maps.Copy(res, doc.Assignments)
+	}
+	return res
+}
+
+// getStickyBucketVariation returns the stored variation or -1 if there is none.
+// blocked is true when the user has an assignment from bucket version below exp.MinBucketVersion.
+func (e *Evaluator) getStickyBucketVariation(exp *Experiment) (variation int, blocked bool) {
+	assignments := e.getStickyBucketAssignments(exp)
+	for v := 0; v < exp.MinBucketVersion; v++ {
+		if _, ok := assignments[stickyBucketExperimentKey(exp.Key, v)]; ok {
+			return -1, true
+		}
+	}
+	varKey, ok := assignments[stickyBucketExperimentKey(exp.Key, exp.BucketVersion)]
+	if !ok {
+		return -1, false
+	}
+	return exp.VariationIndexByKey(varKey), false
+}
+
+func (e *Evaluator) saveStickyBucketAssignment(exp *Experiment, res *ExperimentResult) {
+	if res.HashValue == "" {
+		return
+	}
+	expKey := stickyBucketExperimentKey(exp.Key, exp.BucketVersion)
+	doc := e.getStickyBucketDoc(res.HashAttribute, res.HashValue)
+	if doc != nil && doc.Assignments[expKey] == res.Key {
+		return
+	}
+
+	newDoc := &StickyBucketAssignmentDoc{
+		AttributeName:  res.HashAttribute,
+		AttributeValue: res.HashValue,
+		Assignments:    StickyBucketAssignments{},
+	}
+	if doc != nil {
+		maps.Copy(newDoc.Assignments, doc.Assignments)
+	}
+	newDoc.Assignments[expKey] = res.Key
+	e.stickyDocs[StickyBucketKey(res.HashAttribute, res.HashValue)] = newDoc
+
+	err := e.opts.StickyBucketService.SaveAssignments(e.ctx, newDoc)
+	if err != nil {
+		e.logger.Warn("Error saving sticky bucket assignments", "id", exp.Key, "error", err)
+	}
+}
@@ -1,6 +1,7 @@
-package growthbook
+package eval
 
 import (
+	"encoding/json"
 	"net/url"
 	"regexp"
 	"strconv"
@@ -34,6 +35,31 @@ func getQueryStringOverride(id string, url *url.URL, numVariations int) (int, bo
 	return vi, true
 }
 
+// Prefix of URL query string parameters that force feature values in dev mode.
+const featureOverridePrefix = "gb~"
+
+// Checks if a feature value is being forced via a URL query string.
+//
+// As an example, if the key is "my-flag" and url is
+// http://localhost/?gb~my-flag=true, this function returns true.
+// Values are parsed as JSON, and used as raw strings if parsing fails.
+func getQueryStringFeatureOverride(key string, url *url.URL) (FeatureValue, bool) {
+	if url == nil {
+		return nil, false
+	}
+
+	v, ok := url.Query()[featureOverridePrefix+key]
+	if !ok || len(v) != 1 {
+		return nil, false
+	}
+
+	var res FeatureValue
+	if err := json.Unmarshal([]byte(v[0]), &res); err != nil {
+		return v[0], true
+	}
+	return res, true
+}
+
 var (
 	versionStripRe = regexp.MustCompile(`(^v|\+.*$)`)
 	versionSplitRe = regexp.MustCompile(`[-.]`)
@@ -72,3 +98,31 @@ func if0(v1 int, v2 int) int {
 	}
 	return v1
 }
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+// jsonDeepCopy copies maps and slices of values decoded from JSON.
+func jsonDeepCopy(val any) any {
+	switch v := val.(type) {
+	case map[string]any:
+		res := make(map[string]any, len(v))
+		for k, item := range v {
+			res[k] = jsonDeepCopy(item)
+		}
+		return res
+	case []any:
+		res := make([]any, len(v))
+		for i, item := range v {
+			res[i] = jsonDeepCopy(item)
+		}
+		return res
+	default:
+		return val
+	}
+}
@@ -0,0 +1,128 @@
+package eval
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Validate returns problems of the feature rules, prefixed with the rule index,
+// or nil if there are none. Intended for tools that author payloads programmatically.
+func (f *Feature) Validate() []error {
+	var res []error
+	for i := range f.Rules {
+		for _, err := range f.Rules[i].Validate() {
+			res = append(res, fmt.Errorf("rule %d: %w", i, err))
+		}
+	}
+	return res
+}
+
+// Validate returns problems of the rule, e.g. experiment without enough variations,
+// weights not matching variations or invalid ranges, or nil if there are none.
+func (r *FeatureRule) Validate() []error {
+	var res []error
+	switch {
+	case r.Force != nil && len(r.Variations) > 0:
+		res = append(res, errors.New("rule has both force value and variations"))
+	case r.Force == nil && len(r.Variations) == 0 && !r.isGate():
+		res = append(res, errors.New("rule has neither force value nor variations"))
+	}
+	if r.Range != nil {
+		res = append(res, validateRange("range", *r.Range)...)
+	}
+	if len(r.Variations) > 0 {
+		res = append(res, experimentFromFeatureRule("", r).validate()...)
+	} else {
+		res = append(res, validateCoverage(r.Coverage)...)
+	}
+	return res
+}
+
+// Validate returns problems of the experiment, e.g. missing key or variations,
+// weights not matching variations or invalid ranges, or nil if there are none.
+func (e *Experiment) Validate() []error {
+	var res []error
+	if e.Key == "" {
+		res = append(res, errors.New("experiment has no key"))
+	}
+	res = append(res, e.validate()...)
+	if e.Force != nil && (*e.Force < 0 || *e.Force >= len(e.Variations)) {
+		res = append(res, fmt.Errorf("forced variation %d is out of %d variations", *e.Force, len(e.Variations)))
+	}
+	return res
+}
+
+// validate checks settings experiments share with experiment rules.
+func (e *Experiment) validate() []error {
+	var res []error
+	n := len(e.Variations)
+	if n < 2 {
+		res = append(res, fmt.Errorf("experiment has %d variations, at least 2 are required", n))
+	}
+	if len(e.Weights) > 0 {
+		total := 0.0
+		for _, w := range e.Weights {
+			if w < 0 || math.IsNaN(w) {
+				res = append(res, fmt.Errorf("weight %v is negative", w))
+			}
+			total += w
+		}
+		if len(e.Weights) != n {
+			res = append(res, fmt.Errorf("%d weights don't match %d variations", len(e.Weights), n))
+		} else if total < 0.99 || total > 1.01 {
+			res = append(res, fmt.Errorf("weights %v add up to %v instead of 1", e.Weights, total))
+		}
+	}
+	res = append(res, validateCoverage(e.Coverage)...)
+	if e.HashVersion < 0 || e.HashVersion > 2 {
+		res = append(res, fmt.Errorf("hash version %d is not 1 or 2", e.HashVersion))
+	}
+	if len(e.Ranges) > 0 {
+		if len(e.Ranges) != n {
+			res = append(res, fmt.Errorf("%d ranges don't match %d variations", len(e.Ranges), n))
+		}
+		for i, r := range e.Ranges {
+			res = append(res, validateRange(fmt.Sprintf("range %d", i), r)...)
+			for j := range i {
+				if o := e.Ranges[j]; r.Min < o.Max && o.Min < r.Max {
+					res = append(res, fmt.Errorf("range %d overlaps range %d", i, j))
+				}
+			}
+		}
+	}
+	if ns := e.Namespace; ns != nil {
+		if ns.Id == "" {
+			res = append(res, errors.New("namespace has no id"))
+		}
+		res = append(res, validateRange("namespace range", BucketRange{Min: ns.Start, Max: ns.End})...)
+	}
+	if len(e.Meta) > 0 && len(e.Meta) != n {
+		res = append(res, fmt.Errorf("%d variation meta entries don't match %d variations", len(e.Meta), n))
+	}
+	return res
+}
+
+// isGate reports whether the rule is a feature-level prerequisite, which has no value.
+func (r *FeatureRule) isGate() bool {
+	for _, p := range r.ParentConditions {
+		if p.Gate {
+			return true
+		}
+	}
+	return false
+}
+
+func validateCoverage(coverage *float64) []error {
+	if coverage != nil && !(*coverage >= 0 && *coverage <= 1) {
+		return []error{fmt.Errorf("coverage %v is not between 0 and 1", *coverage)}
+	}
+	return nil
+}
+
+func validateRange(name string, r BucketRange) []error {
+	if !(r.Min >= 0 && r.Min <= r.Max && r.Max <= 1) {
+		return []error{fmt.Errorf("%s [%v, %v) is not a range within [0, 1]", name, r.Min, r.Max)}
+	}
+	return nil
+}
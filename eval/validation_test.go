@@ -0,0 +1,67 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func errorStrings(errs []error) []string {
+	var res []string
+	for _, err := range errs {
+		res = append(res, err.Error())
+	}
+	return res
+}
+
+func TestFeatureValidate(t *testing.T) {
+	valid := NewFeature(0).WithRules(
+		NewFeatureRule().WithForce(1).WithRollout(0.5, "id"),
+		NewFeatureRule().WithExperiment("exp", 0, 1).WithWeights(0.4, 0.6),
+		&FeatureRule{ParentConditions: []ParentCondition{{Id: "parent", Gate: true}}},
+	)
+	require.Empty(t, valid.Validate())
+
+	coverage := 1.5
+	invalid := NewFeature(0).WithRules(
+		&FeatureRule{},
+		&FeatureRule{Force: 1, Variations: []FeatureValue{0, 1}},
+		&FeatureRule{Variations: []FeatureValue{0}, Weights: []float64{0.5, 0.5}, HashVersion: 3, Coverage: &coverage},
+		&FeatureRule{
+			Variations: []FeatureValue{0, 1},
+			Ranges:     []BucketRange{{Min: 0, Max: 0.6}, {Min: 0.5, Max: 1.2}},
+			Namespace:  &Namespace{Start: 0.5, End: 0.2},
+		},
+	)
+	require.Equal(t, []string{
+		"rule 0: rule has neither force value nor variations",
+		"rule 1: rule has both force value and variations",
+		"rule 2: experiment has 1 variations, at least 2 are required",
+		"rule 2: 2 weights don't match 1 variations",
+		"rule 2: coverage 1.5 is not between 0 and 1",
+		"rule 2: hash version 3 is not 1 or 2",
+		"rule 3: range 1 [0.5, 1.2) is not a range within [0, 1]",
+		"rule 3: range 1 overlaps range 0",
+		"rule 3: namespace has no id",
+		"rule 3: namespace range [0.5, 0.2) is not a range within [0, 1]",
+	}, errorStrings(invalid.Validate()))
+}
+
+func TestExperimentValidate(t *testing.T) {
+	exp := NewExperiment("exp")
+	exp.Variations = []FeatureValue{"a", "b"}
+	require.Empty(t, exp.Validate())
+
+	force := 2
+	exp = NewExperiment("")
+	exp.Variations = []FeatureValue{"a", "b"}
+	exp.Weights = []float64{0.7, 0.7}
+	exp.Meta = []VariationMeta{{Key: "a"}}
+	exp.Force = &force
+	require.Equal(t, []string{
+		"experiment has no key",
+		"weights [0.7 0.7] add up to 1.4 instead of 1",
+		"1 variation meta entries don't match 2 variations",
+		"forced variation 2 is out of 2 variations",
+	}, errorStrings(exp.Validate()))
+}
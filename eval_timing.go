@@ -0,0 +1,111 @@
+package growthbook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// ServerTimingMetric is the metric name of GrowthBook evaluation in Server-Timing header.
+const ServerTimingMetric = "growthbook"
+
+type evalTimingKey struct{}
+
+// EvalTiming accumulates time spent in GrowthBook evaluation and number of evaluations,
+// e.g. during a request. It's safe for concurrent use.
+type EvalTiming struct {
+	duration    atomic.Int64
+	evaluations atomic.Int64
+}
+
+// WithEvalTiming returns context that accumulates [Client.EvalFeature] and
+// [Client.RunExperiment] calls made with it, including nested contexts, into timing.
+func WithEvalTiming(ctx context.Context) (context.Context, *EvalTiming) {
+	t := &EvalTiming{}
+	return context.WithValue(ctx, evalTimingKey{}, t), t
+}
+
+// EvalTimingFromContext returns timing of the context, or nil if there is none.
+func EvalTimingFromContext(ctx context.Context) *EvalTiming {
+	if ctx == nil {
+		return nil
+	}
+	t, _ := ctx.Value(evalTimingKey{}).(*EvalTiming)
+	return t
+}
+
+// Duration returns total time spent in evaluation, including callbacks called by it.
+func (t *EvalTiming) Duration() time.Duration {
+	return time.Duration(t.duration.Load())
+}
+
+// Evaluations returns number of evaluated features and experiments.
+func (t *EvalTiming) Evaluations() int {
+	return int(t.evaluations.Load())
+}
+
+// ServerTiming returns Server-Timing header value, e.g. `growthbook;dur=0.125;desc="3 evaluations"`.
+func (t *EvalTiming) ServerTiming() string {
+	ms := float64(t.Duration()) / float64(time.Millisecond)
+	return fmt.Sprintf(`%s;dur=%s;desc="%d evaluations"`, ServerTimingMetric, strconv.FormatFloat(ms, 'f', 3, 64), t.Evaluations())
+}
+
+func (t *EvalTiming) add(start time.Time) {
+	t.duration.Add(int64(time.Since(start)))
+	t.evaluations.Add(1)
+}
+
+// EvalTimingCallback is called with the request and its evaluation timing after the request is handled.
+type EvalTimingCallback func(r *http.Request, timing *EvalTiming)
+
+// EvalTimingMiddleware returns middleware that accumulates evaluation timing of every request,
+// adds it to the response Server-Timing header, unless the handler writes the header before
+// evaluating, and then calls the callback, if it's not nil, e.g. to record metrics per endpoint.
+// Handlers must evaluate with the request context.
+func (client *Client) EvalTimingMiddleware(cb EvalTimingCallback) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, timing := WithEvalTiming(r.Context())
+			r = r.WithContext(ctx)
+			tw := &timingWriter{ResponseWriter: w, timing: timing}
+			next.ServeHTTP(tw, r)
+			tw.writeTiming()
+			if cb != nil {
+				client.callback(ctx, "evalTiming", func() { cb(r, timing) })
+			}
+		})
+	}
+}
+
+// timingWriter adds Server-Timing header right before the response header is written.
+type timingWriter struct {
+	http.ResponseWriter
+	timing  *EvalTiming
+	written bool
+}
+
+func (w *timingWriter) writeTiming() {
+	if w.written {
+		return
+	}
+	w.written = true
+	w.Header().Add("Server-Timing", w.timing.ServerTiming())
+}
+
+func (w *timingWriter) WriteHeader(code int) {
+	w.writeTiming()
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *timingWriter) Write(b []byte) (int, error) {
+	w.writeTiming()
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets [http.ResponseController] reach the original writer.
+func (w *timingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,54 @@
+package growthbook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvalTiming(t *testing.T) {
+	client, err := NewClient(context.TODO(), WithFeatures(FeatureMap{"a": {DefaultValue: 1}}))
+	require.Nil(t, err)
+
+	client.EvalFeature(context.TODO(), "a")
+	ctx, timing := WithEvalTiming(context.TODO())
+	client.EvalFeature(ctx, "a")
+	client.RunExperiment(context.WithValue(ctx, requestIdKey{}, "r1"), &Experiment{Key: "exp", Variations: []FeatureValue{0, 1}})
+	require.Equal(t, 2, timing.Evaluations())
+	require.Positive(t, timing.Duration())
+	require.Same(t, timing, EvalTimingFromContext(ctx))
+	require.Nil(t, EvalTimingFromContext(context.TODO()))
+	require.Regexp(t, `^growthbook;dur=\d+\.\d{3};desc="2 evaluations"$`, timing.ServerTiming())
+}
+
+func TestEvalTimingMiddleware(t *testing.T) {
+	client, err := NewClient(context.TODO(), WithFeatures(FeatureMap{"a": {DefaultValue: 1}}))
+	require.Nil(t, err)
+
+	var endpoint string
+	var evaluations int
+	mw := client.EvalTimingMiddleware(func(r *http.Request, timing *EvalTiming) {
+		endpoint = r.URL.Path
+		evaluations = timing.Evaluations()
+	})
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client.EvalFeature(r.Context(), "a")
+		client.EvalFeature(r.Context(), "b")
+		_, _ = w.Write([]byte("ok"))
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/checkout", nil))
+	require.Equal(t, "/checkout", endpoint)
+	require.Equal(t, 2, evaluations)
+	require.True(t, strings.HasSuffix(w.Result().Header.Get("Server-Timing"), `desc="2 evaluations"`))
+
+	// Header is added even if the handler doesn't write the response
+	w = httptest.NewRecorder()
+	mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, `desc="0 evaluations"`, strings.SplitN(w.Result().Header.Get("Server-Timing"), ";", 3)[2])
+}
@@ -0,0 +1,100 @@
+package growthbook
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// experimentMemo keeps results of inline experiments run by a single client instance.
+// Client attributes don't change during its life, so results depend only on the
+// experiment and the payload version.
+type experimentMemo struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]memoEntry
+}
+
+type memoEntry struct {
+	exp     *Experiment
+	version uint64
+	res     *ExperimentResult
+}
+
+// WithExperimentMemo makes [Client.RunExperiment] reuse results of repeated runs of
+// the same experiment, skipping hashing and condition checks. Results are kept for
+// up to size experiments per client instance and invalidated when the payload or
+// runtime forced variations change. Child clients, e.g. with other attributes, start
+// with an empty memo. Experiments are matched by pointer, so create a new
+// [Experiment] instead of modifying one that was already run.
+func WithExperimentMemo(size int) ClientOption {
+	return func(c *Client) error {
+		if size < 0 {
+			return errors.New("Experiment memo size must not be negative")
+		}
+		c.experimentMemo = newExperimentMemo(size)
+		return nil
+	}
+}
+
+// newExperimentMemo returns nil for zero size, which disables memoization.
+func newExperimentMemo(size int) *experimentMemo {
+	if size == 0 {
+		return nil
+	}
+	return &experimentMemo{size: size, entries: map[string]memoEntry{}}
+}
+
+func (m *experimentMemo) get(exp *Experiment, version uint64) *ExperimentResult {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[exp.Key]
+	if !ok || e.exp != exp || e.version != version {
+		return nil
+	}
+	// Callers may modify the result
+	res := *e.res
+	return &res
+}
+
+func (m *experimentMemo) put(exp *Experiment, version uint64, res *ExperimentResult) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.entries[exp.Key]; !ok && len(m.entries) >= m.size {
+		// Evict an arbitrary entry, stale ones are replaced anyway
+		for key := range m.entries {
+			delete(m.entries, key)
+			break
+		}
+	}
+	stored := *res
+	m.entries[exp.Key] = memoEntry{exp, version, &stored}
+}
+
+// memoizedExperiment returns result of the previous run with the same payload version, if any.
+func (client *Client) memoizedExperiment(ctx context.Context, exp *Experiment) (res *ExperimentResult, version uint64, ok bool) {
+	if client.experimentMemo == nil {
+		return nil, 0, false
+	}
+	if _, pinned := client.pinnedSnapshot(ctx); pinned {
+		return nil, 0, false
+	}
+	if l := client.data.light.Load(); l != nil {
+		if err := client.refreshLightweight(ctx, l); err != nil {
+			client.logger.Error("Error loading features", "error", err)
+		}
+	}
+	version = client.data.version.Load()
+	res = client.experimentMemo.get(exp, version)
+	return res, version, true
+}
+
+func (client *Client) memoLen() int {
+	m := client.experimentMemo
+	if m == nil {
+		return 0
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
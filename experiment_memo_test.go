@@ -0,0 +1,58 @@
+package growthbook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExperimentMemo(t *testing.T) {
+	ctx := context.TODO()
+	var tracked int
+	client, err := NewClient(ctx,
+		WithJsonFeatures(`{}`),
+		WithAttributes(Attributes{"id": "1"}),
+		WithExperimentMemo(2),
+		WithExperimentCallback(func(context.Context, *Experiment, *ExperimentResult, any) { tracked++ }),
+	)
+	require.Nil(t, err)
+	exp := &Experiment{Key: "exp", Variations: []FeatureValue{0, 1, 2}}
+	res := client.RunExperiment(ctx, exp)
+	require.True(t, res.InExperiment)
+	require.Equal(t, 1, client.memoLen())
+
+	// Modified experiment isn't noticed until invalidation
+	force := (res.VariationId + 1) % 3
+	exp.Force = &force
+	memoized := client.RunExperiment(ctx, exp)
+	require.Equal(t, res, memoized)
+	require.NotSame(t, res, memoized)
+	require.Equal(t, 2, tracked)
+
+	require.Nil(t, client.SetForcedVariation("other", 1))
+	require.Equal(t, force, client.RunExperiment(ctx, exp).VariationId)
+
+	exp.Force = nil
+	client.SetFeature("feature", &Feature{DefaultValue: true})
+	require.Equal(t, res.VariationId, client.RunExperiment(ctx, exp).VariationId)
+
+	t.Run("Another experiment instance", func(t *testing.T) {
+		other := &Experiment{Key: "exp", Variations: []FeatureValue{0, 1, 2}, Force: &force}
+		require.Equal(t, force, client.RunExperiment(ctx, other).VariationId)
+	})
+
+	t.Run("Bounded size", func(t *testing.T) {
+		for _, key := range []string{"a", "b", "c"} {
+			client.RunExperiment(ctx, &Experiment{Key: key, Variations: []FeatureValue{0, 1}})
+		}
+		require.Equal(t, 2, client.memoLen())
+	})
+
+	t.Run("Child clients have own memo", func(t *testing.T) {
+		child, _ := client.WithAttributes(Attributes{"id": "2"})
+		require.Zero(t, child.memoLen())
+		child.RunExperiment(ctx, exp)
+		require.Equal(t, 1, child.memoLen())
+	})
+}
@@ -0,0 +1,99 @@
+package growthbook
+
+import (
+	"context"
+	"sync"
+)
+
+// ExperimentRecord is a single experiment assignment captured by the client.
+type ExperimentRecord struct {
+	// The experiment key
+	Key string `json:"key"`
+	// Whether or not the user was part of the experiment
+	InExperiment bool `json:"inExperiment"`
+	// The array index of the assigned variation
+	VariationId int `json:"variationId"`
+	// The user attribute used to assign a variation
+	HashAttribute string `json:"hashAttribute"`
+	// The value of hash attribute
+	HashValue string `json:"hashValue"`
+	// The hash value used to assign a variation (float from 0 to 1)
+	Bucket *float64 `json:"bucket,omitempty"`
+}
+
+// ExperimentRecordStore stores experiment assignments made during a QA session.
+type ExperimentRecordStore interface {
+	// Record saves single experiment assignment.
+	Record(ctx context.Context, rec ExperimentRecord) error
+	// Records returns all saved assignments in the order they were recorded.
+	Records(ctx context.Context) ([]ExperimentRecord, error)
+}
+
+// MemoryExperimentRecordStore is a thread-safe in-memory [ExperimentRecordStore].
+type MemoryExperimentRecordStore struct {
+	mu      sync.Mutex
+	records []ExperimentRecord
+}
+
+var _ ExperimentRecordStore = &MemoryExperimentRecordStore{}
+
+// NewMemoryExperimentRecordStore creates empty in-memory store.
+func NewMemoryExperimentRecordStore() *MemoryExperimentRecordStore {
+	return &MemoryExperimentRecordStore{}
+}
+
+func (s *MemoryExperimentRecordStore) Record(_ context.Context, rec ExperimentRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, rec)
+	return nil
+}
+
+func (s *MemoryExperimentRecordStore) Records(_ context.Context) ([]ExperimentRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := make([]ExperimentRecord, len(s.records))
+	copy(res, s.records)
+	return res, nil
+}
+
+// ReplayForcedVariations converts recorded assignments into [ForcedVariationsMap].
+// The latest record wins for every experiment. Forced variations can't exclude
+// users, so experiments the user wasn't included in are not forced: on replay
+// they are evaluated normally and may include the replaying user.
+func ReplayForcedVariations(ctx context.Context, store ExperimentRecordStore) (ForcedVariationsMap, error) {
+	records, err := store.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := ForcedVariationsMap{}
+	for _, rec := range records {
+		if rec.InExperiment {
+			res[rec.Key] = rec.VariationId
+		} else {
+			delete(res, rec.Key)
+		}
+	}
+	return res, nil
+}
+
+func newExperimentRecord(exp *Experiment, res *ExperimentResult) ExperimentRecord {
+	return ExperimentRecord{
+		Key:           exp.Key,
+		InExperiment:  res.InExperiment,
+		VariationId:   res.VariationId,
+		HashAttribute: res.HashAttribute,
+		HashValue:     res.HashValue,
+		Bucket:        res.Bucket,
+	}
+}
+
+func (client *Client) recordExperiment(ctx context.Context, exp *Experiment, res *ExperimentResult) {
+	if client.experimentRecordStore == nil {
+		return
+	}
+	err := client.experimentRecordStore.Record(ctx, newExperimentRecord(exp, res))
+	if err != nil {
+		client.logger.Warn("Error recording experiment result", "id", exp.Key, "error", err)
+	}
+}
@@ -0,0 +1,85 @@
+package growthbook
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExperimentRecordReplay(t *testing.T) {
+	ctx := context.TODO()
+	featuresJSON := `{
+      "feature": {"defaultValue": 0, "rules": [{"key": "exp1", "variations": [0, 1, 2]}]}
+    }`
+	store := NewMemoryExperimentRecordStore()
+	client, err := NewClient(ctx,
+		WithJsonFeatures(featuresJSON),
+		WithAttributes(Attributes{"id": "42"}),
+		WithExperimentRecordStore(store),
+	)
+	require.Nil(t, err)
+
+	featureRes := client.EvalFeature(ctx, "feature")
+	require.True(t, featureRes.InExperiment())
+	expRes := client.RunExperiment(ctx, &Experiment{Key: "exp2", Variations: []FeatureValue{"a", "b"}})
+	require.True(t, expRes.InExperiment)
+	client.RunExperiment(ctx, &Experiment{Key: "exp3", Variations: []FeatureValue{"a"}})
+
+	records, err := store.Records(ctx)
+	require.Nil(t, err)
+	require.Len(t, records, 3)
+	require.Equal(t, "exp1", records[0].Key)
+	require.Equal(t, "42", records[0].HashValue)
+	require.NotNil(t, records[0].Bucket)
+
+	forced, err := ReplayForcedVariations(ctx, store)
+	require.Nil(t, err)
+	require.Equal(t, ForcedVariationsMap{
+		"exp1": featureRes.ExperimentResult.VariationId,
+		"exp2": expRes.VariationId,
+	}, forced)
+
+	replay, err := client.WithAttributes(Attributes{"id": "another user"})
+	require.Nil(t, err)
+	replay, err = replay.WithForcedVariations(forced)
+	require.Nil(t, err)
+	require.Equal(t, featureRes.Value, replay.EvalFeature(ctx, "feature").Value)
+}
+
+func TestExperimentRecordReplayNotIncluded(t *testing.T) {
+	ctx := context.TODO()
+	coverage := 0.5
+	exp := &Experiment{Key: "exp", Variations: []FeatureValue{1, 2}, Coverage: &coverage}
+	client, err := NewClient(ctx)
+	require.Nil(t, err)
+
+	// Ids of users in and out of the experiment
+	ids := map[bool]string{}
+	for i := 0; len(ids) < 2; i++ {
+		id := strconv.Itoa(i)
+		child, err := client.WithAttributes(Attributes{"id": id})
+		require.Nil(t, err)
+		ids[child.RunExperiment(ctx, exp).InExperiment] = id
+	}
+
+	store := NewMemoryExperimentRecordStore()
+	recorded, err := NewClient(ctx, WithExperimentRecordStore(store), WithAttributes(Attributes{"id": ids[false]}))
+	require.Nil(t, err)
+	require.False(t, recorded.RunExperiment(ctx, exp).InExperiment)
+	records, err := store.Records(ctx)
+	require.Nil(t, err)
+	require.Len(t, records, 1)
+
+	forced, err := ReplayForcedVariations(ctx, store)
+	require.Nil(t, err)
+	require.Empty(t, forced)
+
+	// The experiment is evaluated normally on replay
+	replay, err := client.WithAttributes(Attributes{"id": ids[true]})
+	require.Nil(t, err)
+	replay, err = replay.WithForcedVariations(forced)
+	require.Nil(t, err)
+	require.True(t, replay.RunExperiment(ctx, exp).InExperiment)
+}
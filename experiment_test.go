@@ -2,8 +2,10 @@ package growthbook
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"github.com/growthbook/growthbook-golang/hashutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -38,3 +40,133 @@ func TestExperimentWithMissingAttributeFails(t *testing.T) {
 	require.False(t, res.HashUsed)
 	require.Equal(t, 0, res.Value)
 }
+
+type buttonVariation struct {
+	Color string `json:"color"`
+	Size  int    `json:"size"`
+}
+
+func (v *buttonVariation) Validate() error {
+	if v.Size <= 0 {
+		return errors.New("size must be positive")
+	}
+	return nil
+}
+
+func TestExperimentResultDecodeValue(t *testing.T) {
+	exp := Experiment{
+		Key: "my-test",
+		Variations: []FeatureValue{
+			map[string]any{"color": "blue", "size": 10.0},
+			map[string]any{"color": "red", "size": 0.0},
+			map[string]any{"colour": "red", "size": 10.0},
+		},
+	}
+
+	c, _ := NewClient(context.TODO())
+
+	decode := func(force int) (buttonVariation, error) {
+		c, _ := c.WithForcedVariations(ForcedVariationsMap{"my-test": force})
+		var v buttonVariation
+		err := c.RunExperiment(context.TODO(), &exp).DecodeValue(&v)
+		return v, err
+	}
+
+	v, err := decode(0)
+	require.Nil(t, err)
+	require.Equal(t, buttonVariation{"blue", 10}, v)
+
+	_, err = decode(1)
+	require.ErrorIs(t, err, ErrInvalidFeatureValue)
+
+	_, err = decode(2)
+	require.ErrorIs(t, err, ErrInvalidFeatureValue)
+}
+
+func TestExperimentVariationNames(t *testing.T) {
+	exp := &Experiment{
+		Key:        "exp",
+		Variations: []FeatureValue{0, 1, 2},
+		Meta:       []VariationMeta{{Key: "control", Name: "Control"}, {Key: "treatment"}},
+		Weights:    []float64{1, 0, 0},
+	}
+	require.Equal(t, 0, exp.VariationIndexByKey("control"))
+	require.Equal(t, 1, exp.VariationIndexByKey("treatment"))
+	require.Equal(t, 2, exp.VariationIndexByKey("2"))
+	require.Equal(t, -1, exp.VariationIndexByKey("1"))
+
+	client, err := NewClient(context.TODO(), WithAttributes(Attributes{"id": "1"}))
+	require.Nil(t, err)
+	res := client.RunExperiment(context.TODO(), exp)
+	require.Equal(t, "control", res.Key)
+	require.Equal(t, "Control", res.VariationName())
+
+	res = &ExperimentResult{Key: "treatment"}
+	require.Equal(t, "treatment", res.VariationName())
+}
+
+func TestExperimentHashSeedOverride(t *testing.T) {
+	ctx := context.TODO()
+	exp := &Experiment{Key: "my-test", Variations: []FeatureValue{0, 1}}
+	c, err := NewClient(ctx, WithAttributes(Attributes{"id": "1"}))
+	require.Nil(t, err)
+
+	variation := func(seed string) int {
+		n, _ := hashutil.Hash(seed, "1", 1)
+		return hashutil.ChooseVariation(n, hashutil.GetBucketRanges(2, 1, nil))
+	}
+	require.Equal(t, variation("my-test"), c.RunExperiment(ctx, exp).VariationId)
+
+	for _, run := range []string{"run-1", "run-2", "run-3", "run-4"} {
+		qa, err := c.WithHashSeedOverride(func(expKey string) string {
+			if expKey == "my-test" {
+				return run
+			}
+			return ""
+		})
+		require.Nil(t, err)
+		res := qa.RunExperiment(ctx, exp)
+		require.True(t, res.InExperiment)
+		require.Equal(t, variation(run), res.VariationId)
+	}
+}
+
+func TestExperimentInt64Id(t *testing.T) {
+	exp := Experiment{
+		Key:        "my-test",
+		Variations: []FeatureValue{0, 1},
+		Condition:  MustCondition(map[string]any{"id": map[string]any{"$ne": int64(9007199254740992)}}),
+	}
+
+	c, _ := NewClient(context.TODO(), WithAttributes(Attributes{"id": int64(9007199254740993)}))
+	res := c.RunExperiment(context.TODO(), &exp)
+	require.True(t, res.InExperiment)
+	// Hashed like float64 id and JS SDK
+	require.Equal(t, "9007199254740992", res.HashValue)
+
+	exp.Condition = Condition{}
+	c, _ = c.WithAttributes(Attributes{"id": float64(9007199254740993)})
+	require.Equal(t, res.VariationId, c.RunExperiment(context.TODO(), &exp).VariationId)
+}
+
+func TestExperimentPassthroughTracking(t *testing.T) {
+	var tracked []string
+	client, err := NewClient(context.TODO(),
+		WithAttributes(Attributes{"id": "1"}),
+		WithJsonFeatures(`{"feature": {"defaultValue": 0, "rules": [
+		  {"key": "holdout", "variations": [1, 2], "hashVersion": 2, "ranges": [[0, 0.01], [0.01, 1.0]], "meta": [{}, {"passthrough": true}]},
+		  {"force": 5}
+		]}}`),
+		WithExperimentCallback(func(_ context.Context, exp *Experiment, res *ExperimentResult, _ any) {
+			tracked = append(tracked, exp.Key+":"+res.Key)
+		}),
+	)
+	require.Nil(t, err)
+
+	res := client.EvalFeature(context.TODO(), "feature")
+	require.Equal(t, 5.0, res.Value)
+	require.Equal(t, ForceResultSource, res.Source)
+	require.Len(t, res.Passthrough, 1)
+	require.True(t, res.Passthrough[0].ExperimentResult.Passthrough)
+	require.Equal(t, []string{"holdout:1"}, tracked)
+}
@@ -0,0 +1,63 @@
+package growthbook
+
+import (
+	"context"
+	"slices"
+)
+
+// EnrichExposureFunc returns fields added to the experiment exposure,
+// e.g. request id, region or build sha.
+type EnrichExposureFunc func(ctx context.Context, exp *Experiment, res *ExperimentResult) map[string]any
+
+type exposureFieldsKey struct{}
+
+// WithEnrichExposure adds function that enriches every experiment exposure passed to
+// the experiment callback. The callback reads fields with [ExposureFields].
+// Functions are called in order they were added, later fields override earlier ones.
+func WithEnrichExposure(enrich EnrichExposureFunc) ClientOption {
+	return func(c *Client) error {
+		c.enrichExposure = append(slices.Clip(c.enrichExposure), enrich)
+		return nil
+	}
+}
+
+// WithEnrichExposure creates child client that also enriches exposures with the function.
+func (c *Client) WithEnrichExposure(enrich EnrichExposureFunc) (*Client, error) {
+	return c.cloneWith(WithEnrichExposure(enrich))
+}
+
+// ExposureFields returns fields of the exposure added by [WithEnrichExposure] functions.
+// Use it in [ExperimentCallback] with the context it receives.
+func ExposureFields(ctx context.Context) map[string]any {
+	fields, _ := ctx.Value(exposureFieldsKey{}).(map[string]any)
+	return fields
+}
+
+// trackExperiment calls experiment callback with the exposure fields in the context.
+func (client *Client) trackExperiment(ctx context.Context, exp *Experiment, res *ExperimentResult) {
+	if client.experimentCallback == nil {
+		return
+	}
+	if len(client.exposureSampling) > 0 {
+		rate, tracked := client.sampleExposure(exp, res)
+		if !tracked {
+			return
+		}
+		if rate < 1 {
+			ctx = context.WithValue(ctx, exposureSampleRateKey{}, rate)
+		}
+	}
+	res = client.redactor.experimentResult(res)
+	if len(client.enrichExposure) > 0 {
+		fields := map[string]any{}
+		for _, enrich := range client.enrichExposure {
+			client.callback(ctx, "enrichExposure", func() {
+				for k, v := range enrich(ctx, exp, res) {
+					fields[k] = v
+				}
+			})
+		}
+		ctx = context.WithValue(ctx, exposureFieldsKey{}, fields)
+	}
+	client.callback(ctx, "experiment", func() { client.experimentCallback(ctx, exp, res, client.extraData) })
+}
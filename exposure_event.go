@@ -0,0 +1,106 @@
+package growthbook
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ExposureEventName is the event name of experiment exposures in GrowthBook analysis.
+const ExposureEventName = "Experiment Viewed"
+
+// ExposureEvent is an experiment exposure in the schema GrowthBook analysis queries
+// expect, so trackers don't have to hand-craft the payload.
+type ExposureEvent struct {
+	// Experiment key
+	ExperimentId string
+	// Key of the assigned variation
+	VariationId string
+	// Index of the assigned variation
+	VariationIndex int
+	// Feature the experiment came from, if any
+	FeatureId      string
+	HashAttribute  string
+	HashValue      string
+	Timestamp      time.Time
+	StickyBucketed bool
+	// Attributes included into the event
+	Attributes map[string]any
+}
+
+// NewExposureEvent creates exposure event of the experiment result, e.g. in [ExperimentCallback].
+// Only the listed top-level attributes are included into the event.
+func NewExposureEvent(exp *Experiment, res *ExperimentResult, attributes Attributes, include ...string) *ExposureEvent {
+	event := &ExposureEvent{
+		ExperimentId:   exp.Key,
+		VariationId:    res.Key,
+		VariationIndex: res.VariationId,
+		FeatureId:      res.FeatureId,
+		HashAttribute:  res.HashAttribute,
+		HashValue:      res.HashValue,
+		Timestamp:      time.Now(),
+		StickyBucketed: res.StickyBucketUsed,
+	}
+	for _, name := range include {
+		if v, ok := attributes[name]; ok {
+			if event.Attributes == nil {
+				event.Attributes = map[string]any{}
+			}
+			event.Attributes[name] = v
+		}
+	}
+	return event
+}
+
+type exposureEventJSON struct {
+	EventName      string         `json:"event_name"`
+	ExperimentId   string         `json:"experiment_id"`
+	VariationId    string         `json:"variation_id"`
+	VariationIndex int            `json:"variation_index"`
+	FeatureId      string         `json:"feature_id,omitempty"`
+	HashAttribute  string         `json:"hash_attribute"`
+	HashValue      string         `json:"hash_value"`
+	Timestamp      string         `json:"timestamp"`
+	StickyBucketed bool           `json:"sticky_bucketed,omitempty"`
+	Attributes     map[string]any `json:"attributes,omitempty"`
+}
+
+// MarshalJSON encodes the event with snake case fields, event name and
+// UTC timestamp with millisecond precision.
+func (e *ExposureEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(exposureEventJSON{
+		EventName:      ExposureEventName,
+		ExperimentId:   e.ExperimentId,
+		VariationId:    e.VariationId,
+		VariationIndex: e.VariationIndex,
+		FeatureId:      e.FeatureId,
+		HashAttribute:  e.HashAttribute,
+		HashValue:      e.HashValue,
+		Timestamp:      e.Timestamp.UTC().Format("2006-01-02T15:04:05.000Z"),
+		StickyBucketed: e.StickyBucketed,
+		Attributes:     e.Attributes,
+	})
+}
+
+// UnmarshalJSON decodes the event encoded by [ExposureEvent.MarshalJSON].
+func (e *ExposureEvent) UnmarshalJSON(data []byte) error {
+	var v exposureEventJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	ts, err := time.Parse(time.RFC3339Nano, v.Timestamp)
+	if err != nil {
+		return err
+	}
+	*e = ExposureEvent{
+		ExperimentId:   v.ExperimentId,
+		VariationId:    v.VariationId,
+		VariationIndex: v.VariationIndex,
+		FeatureId:      v.FeatureId,
+		HashAttribute:  v.HashAttribute,
+		HashValue:      v.HashValue,
+		Timestamp:      ts,
+		StickyBucketed: v.StickyBucketed,
+		Attributes:     v.Attributes,
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package growthbook
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExposureEvent(t *testing.T) {
+	var event *ExposureEvent
+	client, err := NewClient(context.TODO(),
+		WithJsonFeatures(`{"button": {"defaultValue": "blue", "rules": [{"key": "button-exp", "variations": ["blue", "green"], "meta": [{"key": "control"}, {"key": "treatment"}]}]}}`),
+		WithAttributes(Attributes{"id": "123", "country": "US", "email": "bob@example.com"}),
+		WithExperimentCallback(func(_ context.Context, exp *Experiment, res *ExperimentResult, _ any) {
+			event = NewExposureEvent(exp, res, Attributes{"id": "123", "country": "US", "email": "bob@example.com"}, "country", "plan")
+		}),
+	)
+	require.Nil(t, err)
+	res := client.EvalFeature(context.TODO(), "button")
+	require.NotNil(t, event)
+
+	event.Timestamp = time.Date(2024, 5, 1, 12, 0, 0, 123456789, time.FixedZone("CET", 3600))
+	data, err := json.Marshal(event)
+	require.Nil(t, err)
+	require.JSONEq(t, `{
+		"event_name": "Experiment Viewed",
+		"experiment_id": "button-exp",
+		"variation_id": "`+res.ExperimentResult.Key+`",
+		"variation_index": `+strconv.Itoa(res.ExperimentResult.VariationId)+`,
+		"feature_id": "button",
+		"hash_attribute": "id",
+		"hash_value": "123",
+		"timestamp": "2024-05-01T11:00:00.123Z",
+		"attributes": {"country": "US"}
+	}`, string(data))
+
+	var decoded ExposureEvent
+	require.Nil(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, event.Timestamp.Truncate(time.Millisecond).UTC(), decoded.Timestamp)
+	decoded.Timestamp = event.Timestamp
+	require.Equal(t, *event, decoded)
+}
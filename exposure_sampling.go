@@ -0,0 +1,54 @@
+package growthbook
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/growthbook/growthbook-golang/hashutil"
+)
+
+// ExposureSampling maps experiment keys to shares of exposures passed to the experiment
+// callback, from 0 to 1. The "*" key sets the rate of other experiments.
+type ExposureSampling map[string]float64
+
+type exposureSampleRateKey struct{}
+
+// WithExposureSampling tracks only a share of exposures of high-traffic experiments.
+// The decision is derived from the hash value, so the same user is either always
+// tracked or never. The callback reads the rate with [ExposureSampleRate]
+// to weight tracked events.
+func WithExposureSampling(rates ExposureSampling) ClientOption {
+	return func(c *Client) error {
+		for key, rate := range rates {
+			if rate < 0 || rate > 1 {
+				return fmt.Errorf("Exposure sample rate of %q must be between 0 and 1, got %v", key, rate)
+			}
+		}
+		c.exposureSampling = maps.Clone(rates)
+		return nil
+	}
+}
+
+// ExposureSampleRate returns sample rate of the exposure, 1 if it isn't sampled.
+// Use it in [ExperimentCallback] with the context it receives.
+func ExposureSampleRate(ctx context.Context) float64 {
+	if rate, ok := ctx.Value(exposureSampleRateKey{}).(float64); ok {
+		return rate
+	}
+	return 1
+}
+
+// sampleExposure returns sample rate of the experiment and whether the exposure is tracked.
+func (client *Client) sampleExposure(exp *Experiment, res *ExperimentResult) (float64, bool) {
+	rate, ok := client.exposureSampling[exp.Key]
+	if !ok {
+		rate, ok = client.exposureSampling["*"]
+	}
+	if !ok || rate >= 1 || res.HashValue == "" {
+		return 1, true
+	}
+	// Seed differs from the experiment one, so sampling doesn't correlate with variations
+	n, ok := hashutil.Hash(exp.Key+":exposure-sampling", res.HashValue, 2)
+	return rate, ok && n < rate
+}
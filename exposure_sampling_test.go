@@ -0,0 +1,46 @@
+package growthbook
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExposureSampling(t *testing.T) {
+	tracked := map[string]int{}
+	var rates []float64
+	cb := func(ctx context.Context, exp *Experiment, res *ExperimentResult, extra any) {
+		tracked[exp.Key]++
+		rates = append(rates, ExposureSampleRate(ctx))
+	}
+	client, err := NewClient(context.TODO(),
+		WithExperimentCallback(cb),
+		WithExposureSampling(ExposureSampling{"sampled": 0.1}),
+	)
+	require.Nil(t, err)
+
+	sampled := &Experiment{Key: "sampled", Variations: []FeatureValue{0, 1}}
+	full := &Experiment{Key: "full", Variations: []FeatureValue{0, 1}}
+	for i := 0; i < 1000; i++ {
+		child, err := client.WithAttributes(Attributes{"id": strconv.Itoa(i)})
+		require.Nil(t, err)
+		require.True(t, child.RunExperiment(context.TODO(), sampled).InExperiment)
+		child.RunExperiment(context.TODO(), full)
+	}
+	require.Equal(t, 1000, tracked["full"])
+	require.InDelta(t, 100, tracked["sampled"], 30)
+	require.Contains(t, rates, 0.1)
+	require.Contains(t, rates, 1.0)
+
+	// Decision is deterministic
+	before := tracked["sampled"]
+	child, _ := client.WithAttributes(Attributes{"id": "1"})
+	child.RunExperiment(context.TODO(), sampled)
+	child.RunExperiment(context.TODO(), sampled)
+	require.Contains(t, []int{before, before + 2}, tracked["sampled"])
+
+	_, err = NewClient(context.TODO(), WithExposureSampling(ExposureSampling{"*": 2}))
+	require.NotNil(t, err)
+}
@@ -0,0 +1,40 @@
+package growthbook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type requestIdKey struct{}
+
+func TestClientEnrichExposure(t *testing.T) {
+	var exposures []map[string]any
+	client, err := NewClient(context.TODO(),
+		WithAttributes(Attributes{"id": "1"}),
+		WithExperimentCallback(func(ctx context.Context, _ *Experiment, _ *ExperimentResult, _ any) {
+			exposures = append(exposures, ExposureFields(ctx))
+		}),
+		WithEnrichExposure(func(ctx context.Context, exp *Experiment, _ *ExperimentResult) map[string]any {
+			return map[string]any{"requestId": ctx.Value(requestIdKey{}), "region": "eu", "exp": exp.Key}
+		}),
+	)
+	require.Nil(t, err)
+	child, err := client.WithEnrichExposure(func(context.Context, *Experiment, *ExperimentResult) map[string]any {
+		return map[string]any{"region": "us"}
+	})
+	require.Nil(t, err)
+
+	exp := &Experiment{Key: "exp", Variations: []FeatureValue{0, 1}}
+	ctx := context.WithValue(context.TODO(), requestIdKey{}, "r1")
+	client.RunExperiment(ctx, exp)
+	child.RunExperiment(ctx, exp)
+
+	require.Equal(t, []map[string]any{
+		{"requestId": "r1", "region": "eu", "exp": "exp"},
+		{"requestId": "r1", "region": "us", "exp": "exp"},
+	}, exposures)
+	require.Len(t, client.enrichExposure, 1)
+	require.Nil(t, ExposureFields(context.TODO()))
+}
@@ -2,7 +2,7 @@ package growthbook
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -19,51 +19,123 @@ type FeatureApiResponse struct {
 	EncryptedFeatures string                `json:"encryptedFeatures"`
 	SseSupport        bool
 	Etag              string
+	// Raw response body, used as a base for incremental updates
+	body []byte
 }
 
 const userAgent = "Growhthbook Go SDK client"
 
+// CallFeatureApi loads features from the GrowthBook API. Concurrent calls with the same
+// etag share a single HTTP request and the returned response, which must not be modified.
+// If the client has features and [WithCoalescedFetchTimeout] is set, calls waiting for
+// the shared request longer than the timeout return not modified response instead.
 func (c *Client) CallFeatureApi(ctx context.Context, etag string) (*FeatureApiResponse, error) {
+	apiUrl := c.data.getApiUrl()
+	var maxWait time.Duration
+	c.data.mu.RLock()
+	if c.data.features != nil {
+		maxWait = c.data.fetchWait
+	}
+	c.data.mu.RUnlock()
+	resp, err := c.data.apiFlights.do(ctx, apiUrl+"|"+etag, apiUrl, maxWait, func(ctx context.Context) (*FeatureApiResponse, error) {
+		return c.callFeatureApi(ctx, apiUrl, etag)
+	})
+	if errors.Is(err, errFlightWait) {
+		c.logger.Warn("Features request is slow, using current features", "timeout", maxWait)
+		return &FeatureApiResponse{Status: http.StatusNotModified}, nil
+	}
+	return resp, err
+}
+
+// WithCoalescedFetchTimeout caps how long calls wait for the features request already
+// in flight, e.g. by polling and [Client.EnsureLoaded] at once. After the timeout they
+// keep current, possibly stale, features. Doesn't apply until features are loaded.
+func WithCoalescedFetchTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) error {
+		c.data.fetchWait = timeout
+		return nil
+	}
+}
+
+func (c *Client) callFeatureApi(ctx context.Context, apiUrl string, etag string) (*FeatureApiResponse, error) {
+	policy := c.data.getRetryPolicy()
+	if policy.Budget > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, policy.Budget)
+		defer cancel()
+	}
+	for retry := 0; ; retry++ {
+		resp, retryable, err := c.fetchFeatures(ctx, apiUrl, etag, policy.PerTryTimeout)
+		if err == nil || !retryable || retry >= policy.MaxRetries || ctx.Err() != nil {
+			return resp, err
+		}
+		delay := policy.retryDelay(retry)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return resp, err
+		}
+		c.logger.Warn("Error loading features, retrying", "retry", retry+1, "delay", delay, "error", err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, err
+		}
+	}
+}
+
+// fetchFeatures makes a single features API request. Reports whether failed request is worth retrying.
+func (c *Client) fetchFeatures(ctx context.Context, apiUrl string, etag string, timeout time.Duration) (*FeatureApiResponse, bool, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
 	apiResp := FeatureApiResponse{}
 
-	apiUrl := c.data.getApiUrl()
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiUrl, nil)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	setReqHeaders(req, etag)
-	resp, err := c.data.httpClient.Do(req)
+	resp, err := c.data.getHttpClient().Do(req)
 	if err != nil {
-		return nil, err
+		return nil, true, err
 	}
+	defer resp.Body.Close()
 
 	apiResp.Status = resp.StatusCode
 	apiResp.Etag = resp.Header.Get("etag")
 	apiResp.SseSupport = resp.Header.Get("x-sse-support") == "enabled"
+	if apiResp.SseSupport {
+		c.data.detectSseHost(req.URL, resp.Request.URL)
+	}
 
 	if resp.StatusCode == 304 {
-		return &apiResp, nil
+		c.markRefreshed()
+		return &apiResp, false, nil
 	}
 
 	if resp.StatusCode != 200 {
-		return &apiResp, fmt.Errorf("Error loading features, code: %d", resp.StatusCode)
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return &apiResp, retryable, fmt.Errorf("Error loading features, code: %d", resp.StatusCode)
 	}
 
-	defer resp.Body.Close()
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return &apiResp, err
+		return &apiResp, true, err
 	}
 
 	c.logger.Info("Loading features")
-	err = json.Unmarshal(body, &apiResp)
+	apiResp.body = body
+	err = c.unmarshalPayload(body, &apiResp)
 	if err != nil {
 		c.logger.Error("Error parsing features response", "error", err)
-		return &apiResp, err
+		return &apiResp, false, err
 	}
 
-	return &apiResp, err
+	return &apiResp, false, nil
 }
 
 func setReqHeaders(req *http.Request, etag string) {
@@ -1,7 +1,12 @@
 package growthbook
 
 import (
+	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -28,3 +33,101 @@ func TestJsonUnmarshaling(t *testing.T) {
 		},
 		apiResp)
 }
+
+func TestCallFeatureApiCoalescing(t *testing.T) {
+	ctx := context.TODO()
+	var count atomic.Int32
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count.Add(1)
+		<-release
+		w.Write([]byte(`{"features": {"foo": {"defaultValue": "api"}}}`))
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(ctx, WithHttpClient(ts.Client()), WithApiHost(ts.URL), WithClientKey("somekey"))
+	require.Nil(t, err)
+
+	var wg sync.WaitGroup
+	responses := make([]*FeatureApiResponse, 10)
+	for i := range responses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.CallFeatureApi(ctx, "")
+			require.Nil(t, err)
+			responses[i] = resp
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	require.Equal(t, int32(1), count.Load())
+	for _, resp := range responses {
+		require.Same(t, responses[0], resp)
+	}
+	apiUrl := ts.URL + "/api/features/somekey"
+	require.Equal(t, FetchStats{Fetches: 1, Coalesced: 9}, client.Stats().Fetches[apiUrl])
+
+	_, err = client.CallFeatureApi(ctx, "")
+	require.Nil(t, err)
+	require.Equal(t, int32(2), count.Load())
+}
+
+func TestCoalescedFetchTimeout(t *testing.T) {
+	ctx := context.TODO()
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte(`{"features": {"foo": {"defaultValue": "api"}}}`))
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	client, err := NewClient(ctx,
+		WithHttpClient(ts.Client()),
+		WithApiHost(ts.URL),
+		WithClientKey("somekey"),
+		WithJsonFeatures(`{"foo": {"defaultValue": "bootstrap"}}`),
+		WithCoalescedFetchTimeout(10*time.Millisecond),
+	)
+	require.Nil(t, err)
+
+	go client.CallFeatureApi(ctx, "")
+	require.Eventually(t, func() bool {
+		return client.Stats().Fetches[ts.URL+"/api/features/somekey"].Fetches == 1
+	}, time.Second, time.Millisecond)
+
+	start := time.Now()
+	resp, err := client.CallFeatureApi(ctx, "")
+	require.Nil(t, err)
+	require.Equal(t, http.StatusNotModified, resp.Status)
+	require.Less(t, time.Since(start), 500*time.Millisecond)
+	require.Equal(t, FetchStats{Fetches: 1, Coalesced: 1, StaleFallbacks: 1}, client.Stats().Fetches[ts.URL+"/api/features/somekey"])
+}
+
+func TestEnsureLoadedConcurrentCalls(t *testing.T) {
+	ctx := context.TODO()
+	ts := startServer(http.StatusOK, []byte(`{"features": {"foo": {"defaultValue": "api"}}}`))
+	defer ts.http.Close()
+	client, err := NewClient(ctx,
+		WithHttpClient(ts.http.Client()),
+		WithApiHost(ts.http.URL),
+		WithClientKey("somekey"),
+		WithPollDataSource(time.Hour),
+	)
+	require.Nil(t, err)
+	defer client.Close()
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.Nil(t, client.EnsureLoaded(ctx))
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), ts.count.Load())
+}
@@ -0,0 +1,47 @@
+package growthbook
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/growthbook/growthbook-golang/internal/condition"
+)
+
+// featureFallback resolves features missing from the client payload
+// from payloads of other clients, e.g. loaded with another environment key.
+type featureFallback struct {
+	prefix  string
+	sources []*Client
+}
+
+// WithFeatureFallback resolves features with the key prefix missing from the client payload
+// from the sources payloads, in order. Features are evaluated with the client attributes and
+// settings. Empty prefix matches all features. The first fallback with matching prefix is used.
+// Useful to move features between GrowthBook environments without breaking services.
+func WithFeatureFallback(prefix string, sources ...*Client) ClientOption {
+	return func(c *Client) error {
+		c.featureFallbacks = append(slices.Clip(c.featureFallbacks), featureFallback{prefix, sources})
+		return nil
+	}
+}
+
+// fallbackPayload returns payload of the first fallback source that has the feature.
+func (client *Client) fallbackPayload(key string) (FeatureMap, condition.SavedGroups, bool) {
+	for _, fb := range client.featureFallbacks {
+		if !strings.HasPrefix(key, fb.prefix) {
+			continue
+		}
+		for _, source := range fb.sources {
+			source.data.mu.RLock()
+			features, savedGroups := source.data.features, source.data.savedGroups
+			source.data.mu.RUnlock()
+			if features[key] == nil {
+				continue
+			}
+			client.logger.Debug("Feature resolved from fallback source", "id", key, "prefix", fb.prefix)
+			return features, savedGroups, true
+		}
+		return nil, nil, false
+	}
+	return nil, nil, false
+}
@@ -0,0 +1,40 @@
+package growthbook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureFallback(t *testing.T) {
+	ctx := context.TODO()
+	staging, err := NewClient(ctx, WithJsonFeatures(`{
+	  "new-feature": {"defaultValue": "staging", "rules": [{"condition": {"id": "1"}, "force": "staging-1"}]},
+	  "old-feature": {"defaultValue": "staging"},
+	  "parent": {"defaultValue": true},
+	  "child": {"defaultValue": false, "rules": [{"parentConditions": [{"id": "parent", "condition": {"value": true}}], "force": true}]}
+	}`))
+	require.Nil(t, err)
+	dev, err := NewClient(ctx, WithJsonFeatures(`{"new-dev": {"defaultValue": "dev"}}`))
+	require.Nil(t, err)
+
+	client, err := NewClient(ctx,
+		WithJsonFeatures(`{"old-feature": {"defaultValue": "production"}}`),
+		WithAttributes(Attributes{"id": "1"}),
+		WithFeatureFallback("new-", staging, dev),
+		WithFeatureFallback("child", staging),
+	)
+	require.Nil(t, err)
+
+	require.Equal(t, "production", client.EvalFeature(ctx, "old-feature").Value)
+	require.Equal(t, "staging-1", client.EvalFeature(ctx, "new-feature").Value)
+	require.Equal(t, "dev", client.EvalFeature(ctx, "new-dev").Value)
+	require.Equal(t, true, client.EvalFeature(ctx, "child").Value)
+	require.Equal(t, UnknownFeatureResultSource, client.EvalFeature(ctx, "parent").Source)
+	require.Equal(t, UnknownFeatureResultSource, client.EvalFeature(ctx, "new-missing").Source)
+
+	child, err := client.WithAttributes(Attributes{"id": "2"})
+	require.Nil(t, err)
+	require.Equal(t, "staging", child.EvalFeature(ctx, "new-feature").Value)
+}
@@ -0,0 +1,115 @@
+package growthbook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const defaultFeatureCookieMaxAge = 180 * 24 * time.Hour
+
+// FeatureHeader sets where [Client.FeatureHeadersMiddleware] puts value of the feature.
+type FeatureHeader struct {
+	// Feature key
+	Feature string
+	// Response header, e.g. "X-GB-Variant". Not set if empty.
+	Header string
+	// Cookie, e.g. for CDN to cache variants separately. Not set if empty.
+	Cookie string
+}
+
+type featureHeaders struct {
+	headers    []FeatureHeader
+	attributes func(*http.Request) Attributes
+	cookie     http.Cookie
+}
+
+// FeatureHeadersOption configures [Client.FeatureHeadersMiddleware].
+type FeatureHeadersOption func(*featureHeaders)
+
+// WithFeatureHeadersAttributes sets function returning attributes of the request's user.
+// By default features are evaluated with attributes of the client.
+func WithFeatureHeadersAttributes(attributes func(*http.Request) Attributes) FeatureHeadersOption {
+	return func(h *featureHeaders) {
+		h.attributes = attributes
+	}
+}
+
+// WithFeatureHeadersCookieAttributes sets Path, Domain, MaxAge, Expires, Secure,
+// HttpOnly and SameSite attributes of written cookies. Name and value are ignored.
+// Default is Path "/", 180 days MaxAge, HttpOnly and SameSite Lax.
+func WithFeatureHeadersCookieAttributes(cookie http.Cookie) FeatureHeadersOption {
+	return func(h *featureHeaders) {
+		h.cookie = cookie
+	}
+}
+
+// FeatureHeadersMiddleware returns middleware that evaluates features for every request and
+// puts their values into response headers and cookies before calling the next handler, so CDN
+// can cache and route responses by variant. Strings are written as is, other values as JSON;
+// features without value are skipped. Cookies are URL-encoded and written only if the request
+// has a different value. Use [CookieStickyBucketService] to keep users in their variations.
+func (client *Client) FeatureHeadersMiddleware(headers []FeatureHeader, opts ...FeatureHeadersOption) func(http.Handler) http.Handler {
+	h := &featureHeaders{
+		headers: headers,
+		cookie: http.Cookie{
+			Path:     "/",
+			MaxAge:   int(defaultFeatureCookieMaxAge / time.Second),
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h.apply(client, w, r)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (h *featureHeaders) apply(client *Client, w http.ResponseWriter, r *http.Request) {
+	if h.attributes != nil {
+		c, err := client.WithAttributes(h.attributes(r))
+		if err != nil {
+			client.logger.Error("Error setting feature headers attributes", "error", err)
+			return
+		}
+		client = c
+	}
+	for _, fh := range h.headers {
+		res := client.EvalFeature(r.Context(), fh.Feature)
+		if res.Value == nil {
+			continue
+		}
+		value, err := headerValue(res.Value)
+		if err != nil {
+			client.logger.Error("Error encoding feature header value", "key", fh.Feature, "error", err)
+			continue
+		}
+		if fh.Header != "" {
+			w.Header().Set(fh.Header, value)
+		}
+		if fh.Cookie != "" {
+			value = url.QueryEscape(value)
+			if old, err := r.Cookie(fh.Cookie); err == nil && old.Value == value {
+				continue
+			}
+			cookie := h.cookie
+			cookie.Name = fh.Cookie
+			cookie.Value = value
+			http.SetCookie(w, &cookie)
+		}
+	}
+}
+
+func headerValue(v any) (string, error) {
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	data, err := json.Marshal(v)
+	return string(data), err
+}
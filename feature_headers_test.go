@@ -0,0 +1,56 @@
+package growthbook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureHeadersMiddleware(t *testing.T) {
+	client, err := NewClient(context.TODO(), WithJsonFeatures(`{
+		"variant": {"defaultValue": "control", "rules": [{"condition": {"id": "2"}, "force": "treatment"}]},
+		"config": {"defaultValue": {"color": "red"}},
+		"missing": {}
+	}`))
+	require.Nil(t, err)
+
+	var handled bool
+	handler := client.FeatureHeadersMiddleware([]FeatureHeader{
+		{Feature: "variant", Header: "X-GB-Variant", Cookie: "gb_variant"},
+		{Feature: "config", Header: "X-GB-Config"},
+		{Feature: "missing", Header: "X-GB-Missing", Cookie: "gb_missing"},
+	}, WithFeatureHeadersAttributes(func(r *http.Request) Attributes {
+		return Attributes{"id": r.URL.Query().Get("id")}
+	}))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handled = true
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?id=2", nil))
+	require.True(t, handled)
+	resp := w.Result()
+	require.Equal(t, "treatment", resp.Header.Get("X-GB-Variant"))
+	require.Equal(t, `{"color":"red"}`, resp.Header.Get("X-GB-Config"))
+	require.Empty(t, resp.Header.Values("X-GB-Missing"))
+	cookies := resp.Cookies()
+	require.Len(t, cookies, 1)
+	require.Equal(t, "gb_variant", cookies[0].Name)
+	require.Equal(t, "treatment", cookies[0].Value)
+	require.Equal(t, "/", cookies[0].Path)
+
+	// Cookie with the same value is not written again
+	req := httptest.NewRequest(http.MethodGet, "/?id=2", nil)
+	req.AddCookie(&http.Cookie{Name: "gb_variant", Value: "treatment"})
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Empty(t, w.Result().Cookies())
+
+	req = httptest.NewRequest(http.MethodGet, "/?id=1", nil)
+	req.AddCookie(&http.Cookie{Name: "gb_variant", Value: "treatment"})
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(t, "control", w.Result().Cookies()[0].Value)
+}
@@ -0,0 +1,75 @@
+package growthbook
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureResultTypedValues(t *testing.T) {
+	t.Run("bool", func(t *testing.T) {
+		require.True(t, (&FeatureResult{Value: true}).BoolValue(false))
+		require.True(t, (&FeatureResult{Value: "true"}).BoolValue(true))
+		require.False(t, (&FeatureResult{Value: nil}).BoolValue(false))
+	})
+
+	t.Run("int", func(t *testing.T) {
+		require.Equal(t, 10, (&FeatureResult{Value: 10.0}).IntValue(0))
+		require.Equal(t, 10, (&FeatureResult{Value: 10}).IntValue(0))
+		require.Equal(t, -1, (&FeatureResult{Value: 10.5}).IntValue(-1))
+		require.Equal(t, -1, (&FeatureResult{Value: "10"}).IntValue(-1))
+	})
+
+	t.Run("string", func(t *testing.T) {
+		require.Equal(t, "blue", (&FeatureResult{Value: "blue"}).StringValue("red"))
+		require.Equal(t, "red", (&FeatureResult{Value: 1.0}).StringValue("red"))
+	})
+}
+
+func TestFeatureResultJSON(t *testing.T) {
+	featuresJSON := `{
+      "feature": {
+        "defaultValue": 0,
+        "rules": [{
+          "key": "exp",
+          "condition": {"country": "US"},
+          "variations": [0, 1],
+          "namespace": ["ns", 0, 1],
+          "meta": [{"key": "control"}, {"key": "treatment", "name": "Treatment"}]
+        }]
+      }
+    }`
+
+	client, err := NewClient(ctx,
+		WithJsonFeatures(featuresJSON),
+		WithAttributes(Attributes{"id": "1", "country": "US"}),
+	)
+	require.Nil(t, err)
+
+	t.Run("default value omits experiment", func(t *testing.T) {
+		data, err := json.Marshal(&FeatureResult{Value: 1.0, Source: DefaultValueResultSource, On: true})
+		require.Nil(t, err)
+		require.JSONEq(t, `{"ruleId": "", "value": 1, "source": "defaultValue", "on": true, "off": false}`, string(data))
+	})
+
+	t.Run("experiment result round-trip", func(t *testing.T) {
+		res := client.EvalFeature(ctx, "feature")
+		require.True(t, res.InExperiment())
+
+		data, err := json.Marshal(res)
+		require.Nil(t, err)
+
+		var raw map[string]any
+		require.Nil(t, json.Unmarshal(data, &raw))
+		exp := raw["experiment"].(map[string]any)
+		require.Equal(t, map[string]any{"country": "US"}, exp["condition"])
+		require.Equal(t, []any{"ns", 0.0, 1.0}, exp["namespace"])
+		require.NotContains(t, exp, "force")
+		require.NotContains(t, raw["experimentResult"], "stickyBucketUsed")
+
+		var decoded FeatureResult
+		require.Nil(t, json.Unmarshal(data, &decoded))
+		require.Equal(t, res, &decoded)
+	})
+}
@@ -3,7 +3,9 @@ package growthbook
 import (
 	"context"
 	"encoding/json"
+	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -487,3 +489,88 @@ func TestReturnsNullWhenHittingPrerequisiteCycle(t *testing.T) {
 	require.Nil(t, result.Value)
 	require.Equal(t, CyclicPrerequisiteResultSource, result.Source)
 }
+
+func TestFeatureQueryStringOverride(t *testing.T) {
+	featuresJson := `{
+		"flag": {"defaultValue": false},
+		"color": {"defaultValue": "blue"},
+		"config": {"defaultValue": {"size": 1}}
+	}`
+	rawUrl := "http://localhost/?gb~flag=true&gb~color=red&gb~config={\"size\":2}&gb~unknown=1"
+
+	client, _ := NewClient(ctx, WithJsonFeatures(featuresJson), WithUrl(rawUrl))
+
+	t.Run("ignored without dev mode", func(t *testing.T) {
+		require.Equal(t, false, client.EvalFeature(ctx, "flag").Value)
+	})
+
+	t.Run("forces values in dev mode", func(t *testing.T) {
+		dev, _ := client.WithDevMode(true)
+		result := dev.EvalFeature(ctx, "flag")
+		require.Equal(t, true, result.Value)
+		require.Equal(t, OverrideResultSource, result.Source)
+		require.Equal(t, "red", dev.EvalFeature(ctx, "color").Value)
+		require.Equal(t, map[string]any{"size": 2.0}, dev.EvalFeature(ctx, "config").Value)
+		require.Equal(t, 1.0, dev.EvalFeature(ctx, "unknown").Value)
+	})
+}
+
+func TestFeatureEvalTimeout(t *testing.T) {
+	featuresJson := `{"feature": {"defaultValue": "default", "rules": [{"force": "forced"}]}}`
+	client, _ := NewClient(ctx, WithJsonFeatures(featuresJson))
+	expired, cancel := context.WithDeadline(ctx, time.Now().Add(-time.Second))
+	defer cancel()
+
+	t.Run("ignores context deadline without timeout", func(t *testing.T) {
+		require.Equal(t, "forced", client.EvalFeature(expired, "feature").Value)
+	})
+
+	t.Run("returns default value when deadline exceeded", func(t *testing.T) {
+		logger, _ := testLogger(slog.LevelError, t)
+		client, _ := NewClient(ctx, WithJsonFeatures(featuresJson), WithEvalTimeout(time.Second), WithLogger(logger))
+		require.Equal(t, "forced", client.EvalFeature(ctx, "feature").Value)
+		result := client.EvalFeature(expired, "feature")
+		require.Equal(t, "default", result.Value)
+		require.Equal(t, TimeoutResultSource, result.Source)
+	})
+}
+
+func TestFeatureBuilder(t *testing.T) {
+	built := FeatureMap{
+		"feature": NewFeature("default").WithRules(
+			NewFeatureRule().
+				WithId("force").
+				WithCondition(MustCondition(map[string]any{"country": map[string]any{"$in": []string{"US", "CA"}}})).
+				WithForce("us"),
+			NewFeatureRule().
+				WithId("exp").
+				WithExperiment("exp", "a", "b").
+				WithWeights(0.5, 0.5).
+				WithRollout(0.8, "id"),
+		),
+	}
+	var parsed FeatureMap
+	err := json.Unmarshal([]byte(`{
+	  "feature": {
+	    "defaultValue": "default",
+	    "rules": [
+	      {"id": "force", "condition": {"country": {"$in": ["US", "CA"]}}, "force": "us"},
+	      {"id": "exp", "key": "exp", "variations": ["a", "b"], "weights": [0.5, 0.5], "coverage": 0.8, "hashAttribute": "id"}
+	    ]
+	  }
+	}`), &parsed)
+	require.Nil(t, err)
+
+	builtClient, err := NewClient(ctx, WithFeatures(built))
+	require.Nil(t, err)
+	parsedClient, err := NewClient(ctx, WithFeatures(parsed))
+	require.Nil(t, err)
+	for _, attrs := range []Attributes{{"country": "US"}, {"id": "1"}, {"id": "2"}, {"id": "3"}, {}} {
+		b, _ := builtClient.WithAttributes(attrs)
+		p, _ := parsedClient.WithAttributes(attrs)
+		require.Equal(t, p.EvalFeature(ctx, "feature"), b.EvalFeature(ctx, "feature"))
+	}
+
+	_, err = NewCondition(map[string]any{"$or": "invalid"})
+	require.Error(t, err)
+}
@@ -0,0 +1,47 @@
+package growthbook
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// featureUsage keeps last evaluation time of features. Shared between a client and its child clients.
+type featureUsage struct {
+	// Feature key to *atomic.Int64 with Unix time in nanoseconds
+	lastEval sync.Map
+}
+
+func newFeatureUsage() *featureUsage {
+	return &featureUsage{}
+}
+
+func (u *featureUsage) record(key string, t time.Time) {
+	v, ok := u.lastEval.Load(key)
+	if !ok {
+		v, _ = u.lastEval.LoadOrStore(key, &atomic.Int64{})
+	}
+	v.(*atomic.Int64).Store(t.UnixNano())
+}
+
+func (u *featureUsage) evaluatedAfter(key string, t time.Time) bool {
+	v, ok := u.lastEval.Load(key)
+	return ok && v.(*atomic.Int64).Load() >= t.UnixNano()
+}
+
+// UnusedFeatures returns sorted keys of features present in the current payload,
+// but not evaluated by the client and its child clients during the last since duration.
+// Evaluations are tracked since the client creation, so a window longer than the
+// client uptime reports features never evaluated. Useful for stale flags cleanup.
+func (client *Client) UnusedFeatures(since time.Duration) []string {
+	after := time.Now().Add(-since)
+	var res []string
+	for key := range client.data.getFeatures() {
+		if !client.usage.evaluatedAfter(key, after) {
+			res = append(res, key)
+		}
+	}
+	slices.Sort(res)
+	return res
+}
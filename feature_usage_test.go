@@ -0,0 +1,29 @@
+package growthbook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientUnusedFeatures(t *testing.T) {
+	ctx := context.TODO()
+	client, err := NewClient(ctx, WithJsonFeatures(`{
+	  "used": {"defaultValue": true},
+	  "stale": {"defaultValue": true},
+	  "unused": {"defaultValue": true}
+	}`))
+	require.Nil(t, err)
+	require.Equal(t, []string{"stale", "unused", "used"}, client.UnusedFeatures(time.Hour))
+
+	client.usage.record("stale", time.Now().Add(-2*time.Hour))
+	child, err := client.WithAttributes(Attributes{"id": "1"})
+	require.Nil(t, err)
+	child.EvalFeature(ctx, "used")
+	child.EvalFeature(ctx, "missing")
+
+	require.Equal(t, []string{"stale", "unused"}, client.UnusedFeatures(time.Hour))
+	require.Equal(t, []string{"unused"}, client.UnusedFeatures(3*time.Hour))
+}
@@ -0,0 +1,60 @@
+package growthbook
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// FeaturesChangeCallback is executed every time the data source loads payload with added,
+// removed or changed features. The diff also lists features affected through prerequisites.
+type FeaturesChangeCallback func(ctx context.Context, diff *FeaturesDiff)
+
+// WithFeaturesChangeCallback sets callback executed on payload changes, e.g. to invalidate
+// caches of evaluated features. Caches of [FeaturesDiff.Affected] features must be invalidated
+// too, as their values depend on the changed ones through parentConditions.
+func WithFeaturesChangeCallback(cb FeaturesChangeCallback) ClientOption {
+	return func(c *Client) error {
+		c.changeCallback = cb
+		return nil
+	}
+}
+
+// notifyChange sends the diff of the payload update to the change webhook and callback.
+func (client *Client) notifyChange(ctx context.Context, old FeatureMap, new FeatureMap, dateUpdated time.Time) {
+	// Initial load is not a change
+	if old == nil || (client.webhook == nil && client.changeCallback == nil) {
+		return
+	}
+	client.data.mu.RLock()
+	diff := &FeaturesDiff{ClientKey: client.data.clientKey, DateUpdated: dateUpdated}
+	client.data.mu.RUnlock()
+	diff.Added, diff.Removed, diff.Changed = diffFeatures(old, new)
+	if diff.empty() {
+		return
+	}
+	diff.Affected = affectedFeatures(new, diff)
+	if client.webhook != nil {
+		client.webhook.notify(client, diff)
+	}
+	if client.changeCallback != nil {
+		client.callback(ctx, "featuresChange", func() { client.changeCallback(ctx, diff) })
+	}
+}
+
+// affectedFeatures returns sorted keys of features that depend on the added, removed
+// or changed ones, directly or transitively, and are not changed themselves.
+func affectedFeatures(features FeatureMap, diff *FeaturesDiff) []string {
+	direct := slices.Concat(diff.Added, diff.Removed, diff.Changed)
+	graph := newDependencyGraph(features)
+	var res []string
+	for _, key := range direct {
+		for _, dep := range graph.Dependents(key) {
+			if !slices.Contains(direct, dep) {
+				res = append(res, dep)
+			}
+		}
+	}
+	slices.Sort(res)
+	return slices.Compact(res)
+}
@@ -0,0 +1,50 @@
+package growthbook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeaturesChangeCallbackAffected(t *testing.T) {
+	var diffs []*FeaturesDiff
+	client, err := NewClient(context.TODO(), WithFeaturesChangeCallback(func(ctx context.Context, diff *FeaturesDiff) {
+		diffs = append(diffs, diff)
+	}))
+	require.Nil(t, err)
+	require.Contains(t, client.EffectiveConfig().Callbacks, "featuresChange")
+
+	payload := func(a string) string {
+		return `{"features": {` + a + `
+			"b": {"defaultValue": 0, "rules": [{"parentConditions": [{"id": "a", "condition": {"value": true}}], "force": 1}]},
+			"c": {"defaultValue": 0, "rules": [{"parentConditions": [{"id": "b", "condition": {"value": 1}}], "force": 1}]},
+			"d": {"defaultValue": 0}
+		}}`
+	}
+	require.Nil(t, client.UpdateFromApiResponseJSON(payload(`"a": {"defaultValue": true},`)))
+	require.Empty(t, diffs)
+
+	require.Nil(t, client.UpdateFromApiResponseJSON(payload(`"a": {"defaultValue": false},`)))
+	require.Len(t, diffs, 1)
+	require.Equal(t, []string{"a"}, diffs[0].Changed)
+	require.Equal(t, []string{"b", "c"}, diffs[0].Affected)
+
+	require.Nil(t, client.UpdateFromApiResponseJSON(payload("")))
+	require.Len(t, diffs, 2)
+	require.Equal(t, []string{"a"}, diffs[1].Removed)
+	require.Equal(t, []string{"b", "c"}, diffs[1].Affected)
+
+	require.Nil(t, client.UpdateFromApiResponseJSON(payload("")))
+	require.Len(t, diffs, 2)
+}
+
+func TestAffectedFeaturesExcludesChanged(t *testing.T) {
+	features := FeatureMap{
+		"a": NewFeature(true),
+		"b": NewFeature(0).WithRules(NewFeatureRule().WithParentCondition("a", MustCondition(map[string]any{"value": true}), false)),
+		"c": NewFeature(0).WithRules(NewFeatureRule().WithParentCondition("b", MustCondition(map[string]any{"value": 1}), false)),
+	}
+	require.Equal(t, []string{"c"}, affectedFeatures(features, &FeaturesDiff{Changed: []string{"a", "b"}}))
+	require.Empty(t, affectedFeatures(features, &FeaturesDiff{Added: []string{"c"}}))
+}
@@ -0,0 +1,166 @@
+package growthbook
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+)
+
+// SetForcedVariation forces the variation of the experiment at runtime for the client
+// and its child clients, e.g. to pin a variation in a QA session. Runtime forced
+// variations take precedence over the ones set by [WithForcedVariations].
+func (client *Client) SetForcedVariation(key string, variation int) error {
+	if err := validateForcedVariations(ForcedVariationsMap{key: variation}); err != nil {
+		return err
+	}
+	client.data.withLock(func(d *data) error {
+		forced := maps.Clone(d.forced)
+		if forced == nil {
+			forced = ForcedVariationsMap{}
+		}
+		forced[key] = variation
+		d.forced = forced
+		d.notifyUpdate()
+		return nil
+	})
+	return nil
+}
+
+// UnsetForcedVariation removes runtime forced variation of the experiment.
+func (client *Client) UnsetForcedVariation(key string) {
+	client.data.withLock(func(d *data) error {
+		if _, ok := d.forced[key]; ok {
+			forced := maps.Clone(d.forced)
+			delete(forced, key)
+			d.forced = forced
+			d.notifyUpdate()
+		}
+		return nil
+	})
+}
+
+// LoadForcedVariations replaces runtime forced variations with the JSON object
+// of experiment keys and variation indexes, e.g. saved by [Client.SaveForcedVariations].
+func (client *Client) LoadForcedVariations(r io.Reader) error {
+	var forced ForcedVariationsMap
+	if err := json.NewDecoder(r).Decode(&forced); err != nil {
+		return err
+	}
+	if err := validateForcedVariations(forced); err != nil {
+		return err
+	}
+	client.data.withLock(func(d *data) error {
+		d.forced = forced
+		d.notifyUpdate()
+		return nil
+	})
+	return nil
+}
+
+func validateForcedVariations(forced ForcedVariationsMap) error {
+	for key, variation := range forced {
+		if variation < 0 {
+			return fmt.Errorf("Forced variation of %q must not be negative, got %d", key, variation)
+		}
+	}
+	return nil
+}
+
+// SaveForcedVariations writes runtime forced variations as JSON object.
+func (client *Client) SaveForcedVariations(w io.Writer) error {
+	return json.NewEncoder(w).Encode(client.runtimeForcedVariations())
+}
+
+func (client *Client) runtimeForcedVariations() ForcedVariationsMap {
+	client.data.mu.RLock()
+	defer client.data.mu.RUnlock()
+	if client.data.forced == nil {
+		return ForcedVariationsMap{}
+	}
+	return client.data.forced
+}
+
+// evalForcedVariations merges runtime forced variations over the client ones.
+func (client *Client) evalForcedVariations(runtime ForcedVariationsMap) ForcedVariationsMap {
+	if len(runtime) == 0 {
+		return client.forcedVariations
+	}
+	if len(client.forcedVariations) == 0 {
+		return runtime
+	}
+	res := maps.Clone(client.forcedVariations)
+	maps.Copy(res, runtime)
+	return res
+}
+
+// ForcedVariationsHandler returns HTTP handler to manage runtime forced variations,
+// so QA can pin variations on a staging service without a redeploy. Requests must
+// have "Authorization: Bearer <token>" header. Handler responds with 404 if token
+// is empty. All methods respond with current forced variations:
+//
+//   - GET returns them
+//   - PUT replaces them with the JSON object of experiment keys and variations
+//   - POST sets variations of the JSON object, keeping other experiments
+//   - DELETE removes the experiment of the "key" query parameter, or all of them
+func (client *Client) ForcedVariationsHandler(token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token == "" {
+			http.NotFound(w, r)
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+token)) != 1 {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		var err error
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			err = client.LoadForcedVariations(r.Body)
+		case http.MethodPost:
+			var forced ForcedVariationsMap
+			if err = json.NewDecoder(r.Body).Decode(&forced); err == nil {
+				err = validateForcedVariations(forced)
+			}
+			if err == nil {
+				client.data.withLock(func(d *data) error {
+					merged := maps.Clone(d.forced)
+					if merged == nil {
+						merged = ForcedVariationsMap{}
+					}
+					maps.Copy(merged, forced)
+					d.forced = merged
+					d.notifyUpdate()
+					return nil
+				})
+			}
+		case http.MethodDelete:
+			if key := r.URL.Query().Get("key"); key != "" {
+				client.UnsetForcedVariation(key)
+			} else {
+				client.data.withLock(func(d *data) error {
+					d.forced = nil
+					d.notifyUpdate()
+					return nil
+				})
+			}
+		default:
+			w.Header().Set("Allow", "GET, PUT, POST, DELETE")
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := client.SaveForcedVariations(w); err != nil {
+			client.logger.Error("Error encoding forced variations", "error", err)
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package growthbook
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntimeForcedVariations(t *testing.T) {
+	ctx := context.TODO()
+	client, _ := NewClient(ctx,
+		WithJsonFeatures(`{"feature": {"defaultValue": 0, "rules": [{"key": "exp", "variations": [0, 1, 2], "weights": [1, 0, 0]}]}}`),
+		WithAttributes(Attributes{"id": "1"}),
+	)
+	child, _ := client.WithAttributes(Attributes{"id": "2"})
+
+	require.Equal(t, 0.0, client.EvalFeature(ctx, "feature").Value)
+	require.Nil(t, client.SetForcedVariation("exp", 1))
+	require.Equal(t, 1.0, client.EvalFeature(ctx, "feature").Value)
+	require.Equal(t, 1.0, child.EvalFeature(ctx, "feature").Value)
+	require.NotNil(t, client.SetForcedVariation("exp", -1))
+
+	forced, _ := client.WithForcedVariations(ForcedVariationsMap{"exp": 2, "other": 1})
+	require.Equal(t, 1.0, forced.EvalFeature(ctx, "feature").Value)
+
+	var buf bytes.Buffer
+	require.Nil(t, client.SaveForcedVariations(&buf))
+	require.JSONEq(t, `{"exp": 1}`, buf.String())
+
+	client.UnsetForcedVariation("exp")
+	require.Equal(t, 0.0, client.EvalFeature(ctx, "feature").Value)
+	require.Equal(t, 2.0, forced.EvalFeature(ctx, "feature").Value)
+
+	require.Nil(t, client.LoadForcedVariations(&buf))
+	require.Equal(t, 1.0, client.EvalFeature(ctx, "feature").Value)
+	require.NotNil(t, client.LoadForcedVariations(strings.NewReader(`{"exp": "1"}`)))
+}
+
+func TestForcedVariationsHandler(t *testing.T) {
+	ctx := context.TODO()
+	client, _ := NewClient(ctx, WithJsonFeatures(`{}`))
+	do := func(handler http.Handler, method, target, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	require.Equal(t, http.StatusNotFound, do(client.ForcedVariationsHandler(""), http.MethodGet, "/", "", "").Code)
+
+	handler := client.ForcedVariationsHandler("secret")
+	require.Equal(t, http.StatusUnauthorized, do(handler, http.MethodGet, "/", "", "").Code)
+	require.Equal(t, http.StatusUnauthorized, do(handler, http.MethodGet, "/", "wrong", "").Code)
+
+	rec := do(handler, http.MethodGet, "/", "secret", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{}`, rec.Body.String())
+
+	rec = do(handler, http.MethodPut, "/", "secret", `{"a": 1, "b": 0}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"a": 1, "b": 0}`, rec.Body.String())
+
+	rec = do(handler, http.MethodPost, "/", "secret", `{"c": 2}`)
+	require.JSONEq(t, `{"a": 1, "b": 0, "c": 2}`, rec.Body.String())
+	require.Equal(t, http.StatusBadRequest, do(handler, http.MethodPost, "/", "secret", `{"c": -2}`).Code)
+
+	rec = do(handler, http.MethodDelete, "/?key=a", "secret", "")
+	require.JSONEq(t, `{"b": 0, "c": 2}`, rec.Body.String())
+	rec = do(handler, http.MethodDelete, "/", "secret", "")
+	require.JSONEq(t, `{}`, rec.Body.String())
+
+	require.Equal(t, http.StatusMethodNotAllowed, do(handler, http.MethodPatch, "/", "secret", "").Code)
+}
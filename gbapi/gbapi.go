@@ -0,0 +1,158 @@
+// Package gbapi is a minimal client of the GrowthBook REST API for tooling,
+// e.g. scripts that reconcile feature flags used in code with the dashboard.
+// It is not needed to evaluate features; use the growthbook package for that.
+package gbapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultBaseUrl  = "https://api.growthbook.io"
+	defaultPageSize = 100
+	userAgent       = "Growhthbook Go SDK API client"
+)
+
+// ErrApi is returned when the API responds with an error status.
+var ErrApi = errors.New("GrowthBook API error")
+
+// Client accesses the GrowthBook REST API with a secret API key.
+type Client struct {
+	baseUrl    string
+	apiKey     string
+	httpClient *http.Client
+	pageSize   int
+}
+
+type Option func(*Client)
+
+// WithBaseUrl sets API host for self-hosted GrowthBook. Default "https://api.growthbook.io".
+func WithBaseUrl(baseUrl string) Option {
+	return func(c *Client) {
+		c.baseUrl = strings.TrimRight(baseUrl, "/")
+	}
+}
+
+// WithHttpClient sets HTTP client used for requests. Default [http.DefaultClient].
+func WithHttpClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithPageSize sets number of items requested per page. Default 100.
+func WithPageSize(pageSize int) Option {
+	return func(c *Client) {
+		c.pageSize = pageSize
+	}
+}
+
+// NewClient creates API client authenticated with the secret API key.
+func NewClient(apiKey string, opts ...Option) *Client {
+	c := &Client{
+		baseUrl:    defaultBaseUrl,
+		apiKey:     apiKey,
+		httpClient: http.DefaultClient,
+		pageSize:   defaultPageSize,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Page is pagination info of a list response.
+type Page struct {
+	Limit      int  `json:"limit"`
+	Offset     int  `json:"offset"`
+	Count      int  `json:"count"`
+	Total      int  `json:"total"`
+	HasMore    bool `json:"hasMore"`
+	NextOffset int  `json:"nextOffset"`
+}
+
+// FeaturesPage returns a single page of features starting at offset.
+func (c *Client) FeaturesPage(ctx context.Context, offset int) ([]Feature, Page, error) {
+	var resp struct {
+		Page
+		Features []Feature `json:"features"`
+	}
+	err := c.get(ctx, "/api/v1/features", offset, &resp)
+	return resp.Features, resp.Page, err
+}
+
+// ExperimentsPage returns a single page of experiments starting at offset.
+func (c *Client) ExperimentsPage(ctx context.Context, offset int) ([]Experiment, Page, error) {
+	var resp struct {
+		Page
+		Experiments []Experiment `json:"experiments"`
+	}
+	err := c.get(ctx, "/api/v1/experiments", offset, &resp)
+	return resp.Experiments, resp.Page, err
+}
+
+// ListFeatures returns all features, following pagination.
+func (c *Client) ListFeatures(ctx context.Context) ([]Feature, error) {
+	return listAll(ctx, c.FeaturesPage)
+}
+
+// ListExperiments returns all experiments, following pagination.
+func (c *Client) ListExperiments(ctx context.Context) ([]Experiment, error) {
+	return listAll(ctx, c.ExperimentsPage)
+}
+
+func listAll[T any](ctx context.Context, page func(context.Context, int) ([]T, Page, error)) ([]T, error) {
+	var res []T
+	offset := 0
+	for {
+		items, p, err := page(ctx, offset)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, items...)
+		// Guard against servers returning the same offset forever
+		if !p.HasMore || p.NextOffset <= offset {
+			return res, nil
+		}
+		offset = p.NextOffset
+	}
+}
+
+func (c *Client) get(ctx context.Context, path string, offset int, res any) error {
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(c.pageSize))
+	query.Set("offset", strconv.Itoa(offset))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseUrl+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(body, &apiErr)
+		return fmt.Errorf("%w: %s, code: %d, message: %q", ErrApi, path, resp.StatusCode, apiErr.Message)
+	}
+	return json.Unmarshal(body, res)
+}
@@ -0,0 +1,69 @@
+package gbapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListFeaturesPagination(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/features", r.URL.Path)
+		require.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		require.Equal(t, "2", r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		total := 5
+		count := min(2, total-offset)
+		items := ""
+		for i := offset; i < offset+count; i++ {
+			if items != "" {
+				items += ","
+			}
+			items += fmt.Sprintf(`{"id": "f%d"}`, i)
+		}
+		fmt.Fprintf(w, `{"features": [%s], "limit": 2, "offset": %d, "count": %d, "total": %d, "hasMore": %t, "nextOffset": %d}`,
+			items, offset, count, total, offset+count < total, offset+count)
+	}))
+	defer ts.Close()
+
+	c := NewClient("secret", WithBaseUrl(ts.URL+"/"), WithHttpClient(ts.Client()), WithPageSize(2))
+	features, err := c.ListFeatures(context.TODO())
+	require.Nil(t, err)
+	require.Len(t, features, 5)
+	require.Equal(t, "f0", features[0].Id)
+	require.Equal(t, "f4", features[4].Id)
+}
+
+func TestListExperiments(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/experiments", r.URL.Path)
+		w.Write([]byte(`{"experiments": [{"id": "exp_1", "trackingKey": "exp", "status": "running",
+		  "variations": [{"variationId": "v0", "key": "0", "name": "Control"}]}], "hasMore": false}`))
+	}))
+	defer ts.Close()
+
+	c := NewClient("secret", WithBaseUrl(ts.URL), WithHttpClient(ts.Client()))
+	exps, err := c.ListExperiments(context.TODO())
+	require.Nil(t, err)
+	require.Len(t, exps, 1)
+	require.Equal(t, "exp", exps[0].TrackingKey)
+	require.Equal(t, "Control", exps[0].Variations[0].Name)
+}
+
+func TestApiError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message": "Invalid API key"}`))
+	}))
+	defer ts.Close()
+
+	c := NewClient("bad", WithBaseUrl(ts.URL), WithHttpClient(ts.Client()))
+	_, err := c.ListFeatures(context.TODO())
+	require.ErrorIs(t, err, ErrApi)
+	require.ErrorContains(t, err, "Invalid API key")
+}
@@ -0,0 +1,55 @@
+package gbapi
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Feature is a feature flag definition from the dashboard.
+type Feature struct {
+	Id           string                        `json:"id"`
+	Description  string                        `json:"description"`
+	Owner        string                        `json:"owner"`
+	Project      string                        `json:"project"`
+	ValueType    string                        `json:"valueType"`
+	DefaultValue string                        `json:"defaultValue"`
+	Tags         []string                      `json:"tags"`
+	Archived     bool                          `json:"archived"`
+	DateCreated  time.Time                     `json:"dateCreated"`
+	DateUpdated  time.Time                     `json:"dateUpdated"`
+	Environments map[string]FeatureEnvironment `json:"environments"`
+}
+
+// FeatureEnvironment is a feature configuration in a single environment.
+// Rules are kept raw, as their format depends on the rule type.
+type FeatureEnvironment struct {
+	Enabled      bool              `json:"enabled"`
+	DefaultValue string            `json:"defaultValue"`
+	Rules        []json.RawMessage `json:"rules"`
+}
+
+// Experiment is an experiment definition from the dashboard.
+type Experiment struct {
+	Id            string                `json:"id"`
+	TrackingKey   string                `json:"trackingKey"`
+	Name          string                `json:"name"`
+	Project       string                `json:"project"`
+	Hypothesis    string                `json:"hypothesis"`
+	Description   string                `json:"description"`
+	Tags          []string              `json:"tags"`
+	Owner         string                `json:"owner"`
+	Archived      bool                  `json:"archived"`
+	Status        string                `json:"status"`
+	HashAttribute string                `json:"hashAttribute"`
+	DateCreated   time.Time             `json:"dateCreated"`
+	DateUpdated   time.Time             `json:"dateUpdated"`
+	Variations    []ExperimentVariation `json:"variations"`
+}
+
+// ExperimentVariation is a variation of an experiment.
+type ExperimentVariation struct {
+	VariationId string `json:"variationId"`
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
@@ -0,0 +1,94 @@
+package hashutil
+
+import "encoding/json"
+
+// BucketRange represents a single bucket range.
+type BucketRange struct {
+	Min float64
+	Max float64
+}
+
+// InRange checks if n is within [Min, Max) range.
+func (r *BucketRange) InRange(n float64) bool {
+	return n >= r.Min && n < r.Max
+}
+
+// GetBucketRanges converts an experiment's coverage and variation weights into
+// a slice of bucket ranges. Invalid coverage is clamped to [0, 1], and invalid
+// weights are replaced with equal weights.
+func GetBucketRanges(numVariations int, coverage float64, weights []float64) []BucketRange {
+	coverage = min(max(coverage, 0), 1)
+
+	if !ValidWeights(numVariations, weights) {
+		weights = GetEqualWeights(numVariations)
+	}
+
+	// Cast weights to ranges
+	cumulative := 0.0
+	ranges := make([]BucketRange, len(weights))
+	for i := range weights {
+		start := cumulative
+		cumulative += weights[i]
+		ranges[i] = BucketRange{start, start + coverage*weights[i]}
+	}
+	return ranges
+}
+
+// ValidWeights checks that there is weight for every variation and that they add up to 1 (or close to it).
+func ValidWeights(numVariations int, weights []float64) bool {
+	if len(weights) != numVariations {
+		return false
+	}
+	totalWeight := 0.0
+	for i := range weights {
+		totalWeight += weights[i]
+	}
+	return totalWeight >= 0.99 && totalWeight <= 1.01
+}
+
+// ChooseVariation returns index of the range n falls into, or -1 if there is none.
+func ChooseVariation(n float64, ranges []BucketRange) int {
+	for i := range ranges {
+		if ranges[i].InRange(n) {
+			return i
+		}
+	}
+	return -1
+}
+
+// GetEqualWeights returns a slice of floats with numVariations items that are all
+// equal and sum to 1.
+func GetEqualWeights(numVariations int) []float64 {
+	if numVariations < 0 {
+		numVariations = 0
+	}
+	equal := make([]float64, numVariations)
+	for i := range equal {
+		equal[i] = 1.0 / float64(numVariations)
+	}
+	return equal
+}
+
+func (br *BucketRange) UnmarshalJSON(data []byte) error {
+	var pair [2]float64
+	err := json.Unmarshal(data, &pair)
+	if err != nil {
+		return err
+	}
+	br.Min = pair[0]
+	br.Max = pair[1]
+	return nil
+}
+
+func (br BucketRange) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]float64{br.Min, br.Max})
+}
+
+// SplitTraffic assigns the id to one of the weights, e.g. []float64{0.9, 0.1}, and returns its index.
+// The id lands in the same bucket as in an experiment with the seed, hash version 2 and
+// full coverage, so decisions made outside of experiments stay consistent with them.
+// Equal weights are used if weights don't add up to 1. Returns -1 for empty weights.
+func SplitTraffic(seed string, id string, weights []float64) int {
+	n, _ := Hash(seed, id, 2)
+	return ChooseVariation(n, GetBucketRanges(len(weights), 1, weights))
+}
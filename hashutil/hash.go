@@ -0,0 +1,50 @@
+// Package hashutil exposes GrowthBook spec hashing and bucketing helpers.
+// The functions produce exactly the same results as the SDK evaluator,
+// so they can be used for traffic calculators, debug UIs and other tooling.
+package hashutil
+
+import "strconv"
+
+const (
+	fnv32Offset = 2166136261
+	fnv32Prime  = 16777619
+)
+
+// Hash computes the spec hash of the value with the seed, using hash version 1 or 2.
+// Result is a float from 0 to 1. Returns false for unsupported hash versions.
+// Version 0 is treated as the default version 1.
+// Hash doesn't allocate: strings are hashed in place instead of concatenated.
+func Hash(seed string, value string, version int) (float64, bool) {
+	switch version {
+	case 2:
+		var buf [10]byte // max uint32 has 10 digits
+		inner := strconv.AppendUint(buf[:0], uint64(fnv32aString(fnv32aString(fnv32Offset, seed), value)), 10)
+		return float64(fnv32aBytes(fnv32Offset, inner)%10000) / 10000, true
+	case 0, 1:
+		return float64(fnv32aString(fnv32aString(fnv32Offset, value), seed)%1000) / 1000, true
+	default:
+		return 0, false
+	}
+}
+
+// Fnv32a computes FNV-1a 32-bit hash of the string, same as hash/fnv New32a.
+func Fnv32a(s string) uint32 {
+	return fnv32aString(fnv32Offset, s)
+}
+
+// fnv32aString continues FNV-1a hash h with bytes of s.
+func fnv32aString(h uint32, s string) uint32 {
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= fnv32Prime
+	}
+	return h
+}
+
+func fnv32aBytes(h uint32, b []byte) uint32 {
+	for _, c := range b {
+		h ^= uint32(c)
+		h *= fnv32Prime
+	}
+	return h
+}
@@ -0,0 +1,110 @@
+package hashutil
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHash(t *testing.T) {
+	n, ok := Hash("", "a", 1)
+	require.True(t, ok)
+	require.Equal(t, 0.22, n)
+
+	n, ok = Hash("seed", "a", 2)
+	require.True(t, ok)
+	require.Equal(t, 0.0505, n)
+
+	_, ok = Hash("", "a", 99)
+	require.False(t, ok)
+}
+
+func TestFnv32a(t *testing.T) {
+	for _, s := range []string{"", "a", "user-123456789seed", "юникод"} {
+		h := fnv.New32a()
+		h.Write([]byte(s))
+		require.Equal(t, h.Sum32(), Fnv32a(s))
+	}
+}
+
+func TestHashAllocs(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		Hash("seed", "user-123456789", 2)
+		Hash("seed", "user-123456789", 1)
+		InNamespace("user-123456789", &Namespace{"ns", 0, 0.5})
+	})
+	require.Zero(t, allocs)
+}
+
+func BenchmarkHashV1(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Hash("checkout-experiment", "user-123456789", 1)
+	}
+}
+
+func BenchmarkHashV2(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Hash("checkout-experiment", "user-123456789", 2)
+	}
+}
+
+func TestGetBucketRanges(t *testing.T) {
+	require.Equal(t,
+		[]BucketRange{{0, 0.25}, {0.5, 0.75}},
+		GetBucketRanges(2, 0.5, nil))
+	require.Equal(t,
+		[]BucketRange{{0, 0.5}, {0.5, 1}},
+		GetBucketRanges(2, 1.5, []float64{0.7, 0.7}))
+	require.Equal(t, 1, ChooseVariation(0.6, GetBucketRanges(2, 1, nil)))
+	require.Equal(t, -1, ChooseVariation(0.3, GetBucketRanges(2, 0.5, nil)))
+}
+
+func TestSplitTraffic(t *testing.T) {
+	counts := make([]int, 2)
+	for i := 0; i < 10000; i++ {
+		counts[SplitTraffic("seed", strconv.Itoa(i), []float64{0.9, 0.1})]++
+	}
+	require.InDelta(t, 9000, counts[0], 200)
+	require.Equal(t, SplitTraffic("seed", "1", nil), -1)
+	require.Equal(t, SplitTraffic("seed", "1", []float64{1}), 0)
+}
+
+func TestNamespace(t *testing.T) {
+	var ns Namespace
+	err := json.Unmarshal([]byte(`["namespace2", 0, 0.4]`), &ns)
+	require.Nil(t, err)
+	require.True(t, InNamespace("3", &ns))
+	require.False(t, InNamespace("1", &ns))
+
+	data, err := json.Marshal(ns)
+	require.Nil(t, err)
+	require.JSONEq(t, `["namespace2", 0, 0.4]`, string(data))
+}
+
+func TestMigrationBucket(t *testing.T) {
+	migrated := func(coverage float64) int {
+		count := 0
+		for i := 0; i < 10000; i++ {
+			if MigrationBucket("db-migration", strconv.Itoa(i), coverage) {
+				count++
+			}
+		}
+		return count
+	}
+	require.Zero(t, migrated(0))
+	require.Equal(t, 10000, migrated(1))
+	require.InDelta(t, 2500, migrated(0.25), 150)
+
+	for i := 0; i < 1000; i++ {
+		id := strconv.Itoa(i)
+		if MigrationBucket("db-migration", id, 0.2) {
+			require.True(t, MigrationBucket("db-migration", id, 0.5))
+		}
+	}
+	require.False(t, MigrationBucket("db-migration", "", 1))
+}
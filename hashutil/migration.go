@@ -0,0 +1,14 @@
+package hashutil
+
+// MigrationBucket reports whether the id is in the migrated share of traffic of the
+// migration key. It uses hash version 2 seeded with the key and coverage semantics of
+// rollout rules: the id is migrated if its hash is not above coverage, so raising coverage
+// only adds ids and every id stays on the same side between calls and processes.
+// Empty ids are never migrated.
+func MigrationBucket(key string, id string, coverage float64) bool {
+	if id == "" || coverage <= 0 {
+		return false
+	}
+	n, _ := Hash(key, id, 2)
+	return n <= coverage
+}
@@ -1,4 +1,4 @@
-package growthbook
+package hashutil
 
 import (
 	"encoding/json"
@@ -14,9 +14,10 @@ type Namespace struct {
 	End   float64
 }
 
-// Determine whether a user's ID lies within a given namespace.
-func (namespace *Namespace) inNamespace(userId string) bool {
-	n := float64(hashFnv32a(userId+"__"+namespace.Id)%1000) / 1000
+// InNamespace determines whether a user's id lies within a given namespace.
+func InNamespace(userId string, namespace *Namespace) bool {
+	h := fnv32aString(fnv32aString(fnv32aString(fnv32Offset, userId), "__"), namespace.Id)
+	n := float64(h%1000) / 1000
 	return n >= namespace.Start && n < namespace.End
 }
 
@@ -44,3 +45,7 @@ func (namespace *Namespace) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+func (namespace Namespace) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{namespace.Id, namespace.Start, namespace.End})
+}
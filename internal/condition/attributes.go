@@ -0,0 +1,102 @@
+package condition
+
+import (
+	"slices"
+
+	"github.com/growthbook/growthbook-golang/internal/value"
+)
+
+// Attributes returns sorted unique attribute paths referenced by the condition.
+func (base Base) Attributes() []string {
+	var res []string
+	collectAttributes(base.src, &res)
+	slices.Sort(res)
+	return slices.Compact(res)
+}
+
+func collectAttributes(json value.Value, res *[]string) {
+	obj, ok := json.(value.ObjValue)
+	if !ok {
+		return
+	}
+	for f, fv := range obj {
+		switch Operator(f) {
+		case andOp, orOp, norOp:
+			if arr, ok := fv.(value.ArrValue); ok {
+				for _, v := range arr {
+					collectAttributes(v, res)
+				}
+			}
+		case notOp:
+			collectAttributes(fv, res)
+		default:
+			*res = append(*res, f)
+		}
+	}
+}
+
+// AttributeTypes returns attribute paths mapped to sorted unique JSON type names of values
+// the condition compares them with, e.g. "age" -> ["number"] for {"age": {"$gt": 18}}.
+// Version and regex operators expect "string"; $size, $elemMatch and $all expect "array".
+// Comparisons with null, $exists, $type and saved groups are skipped.
+func (base Base) AttributeTypes() map[string][]string {
+	res := map[string][]string{}
+	collectAttributeTypes(base.src, res)
+	for path, types := range res {
+		slices.Sort(types)
+		res[path] = slices.Compact(types)
+	}
+	return res
+}
+
+func collectAttributeTypes(json value.Value, res map[string][]string) {
+	obj, ok := json.(value.ObjValue)
+	if !ok {
+		return
+	}
+	for f, fv := range obj {
+		switch Operator(f) {
+		case andOp, orOp, norOp:
+			if arr, ok := fv.(value.ArrValue); ok {
+				for _, v := range arr {
+					collectAttributeTypes(v, res)
+				}
+			}
+		case notOp:
+			collectAttributeTypes(fv, res)
+		default:
+			collectFieldTypes(f, fv, res)
+		}
+	}
+}
+
+func collectFieldTypes(path string, json value.Value, res map[string][]string) {
+	add := func(v value.Value) {
+		if name := TypeName(v); name != "" {
+			res[path] = append(res[path], name)
+		}
+	}
+	obj, ok := json.(value.ObjValue)
+	if !(ok && isOperatorObject(obj)) {
+		add(json)
+		return
+	}
+	for op, arg := range obj {
+		switch Operator(op) {
+		case eqOp, neOp, ltOp, lteOp, gtOp, gteOp:
+			add(arg)
+		case inOp, ninOp:
+			if arr, ok := arg.(value.ArrValue); ok {
+				for _, v := range arr {
+					add(v)
+				}
+			}
+		case veqOp, vneOp, vgtOp, vgteOp, vltOp, vlteOp, regexOp:
+			add(value.Str(""))
+		case sizeOp, elemMatchOp, allOp:
+			add(value.Arr())
+		case notOp:
+			collectFieldTypes(path, arg, res)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package condition
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseAttributes(t *testing.T) {
+	var base Base
+	err := json.Unmarshal([]byte(`{
+	  "country": {"$in": ["US", "CA"]},
+	  "$or": [{"user.age": {"$gt": 18}}, {"$not": {"tier": "free"}}],
+	  "$nor": [{"country": "RU"}],
+	  "tags": {"$elemMatch": {"$eq": "beta"}}
+	}`), &base)
+	require.Nil(t, err)
+	require.Equal(t, []string{"country", "tags", "tier", "user.age"}, base.Attributes())
+	require.Nil(t, Base{}.Attributes())
+}
+
+func TestBaseAttributeTypes(t *testing.T) {
+	var base Base
+	err := json.Unmarshal([]byte(`{
+	  "age": {"$gt": "18", "$lt": 65},
+	  "$or": [{"country": {"$in": ["US", 1]}}, {"$not": {"tier": null}}],
+	  "version": {"$not": {"$vgt": "1.2.0"}},
+	  "tags": {"$size": 2},
+	  "seen": {"$exists": true},
+	  "user": {"plan": "pro"}
+	}`), &base)
+	require.Nil(t, err)
+	require.Equal(t, map[string][]string{
+		"age":     {"number", "string"},
+		"country": {"number", "string"},
+		"version": {"string"},
+		"tags":    {"array"},
+		"user":    {"object"},
+	}, base.AttributeTypes())
+	require.Empty(t, Base{}.AttributeTypes())
+}
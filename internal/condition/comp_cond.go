@@ -56,18 +56,5 @@ func jsCompare(a, b value.Value) int {
 			return 1
 		}
 	}
-	a, b = a.Cast(value.NumType), b.Cast(value.NumType)
-	na, oka := a.(value.NumValue)
-	nb, okb := b.(value.NumValue)
-	if oka && okb {
-		switch {
-		case na < nb:
-			return -1
-		case na == nb:
-			return 0
-		default:
-			return 1
-		}
-	}
-	return 2
+	return value.CompareNum(a.Cast(value.NumType), b.Cast(value.NumType))
 }
@@ -24,6 +24,13 @@ func TestCompCond(t *testing.T) {
 		{lteOp, 100, 10, false},
 		{gtOp, 10, "2", true},
 		{gteOp, value.Null(), 0, true},
+		{eqOp, int64(9007199254740993), int64(9007199254740993), true},
+		{eqOp, int64(9007199254740993), int64(9007199254740992), false},
+		{eqOp, int64(9007199254740993), float64(9007199254740992), false},
+		{gtOp, int64(9007199254740993), int64(9007199254740992), true},
+		{gtOp, int64(9007199254740993), float64(9007199254740992), true},
+		{ltOp, "9007199254740992", int64(9007199254740993), true},
+		{lteOp, uint64(1 << 63), int64(9223372036854775807), false},
 	}
 	for _, tt := range tests {
 		c := NewCompCond(tt.op, tt.arg)
@@ -1,26 +1,49 @@
 package condition
 
 import (
+	"regexp"
 	"strings"
 
 	"github.com/growthbook/growthbook-golang/internal/value"
 )
 
 type FieldCond struct {
-	path []string
-	cond Condition
+	path     []string
+	cond     Condition
+	wildcard bool
 }
 
+// Eval checks condition against the field value. Path with "*" wildcard
+// matches if the condition holds for any of the array elements.
 func (c FieldCond) Eval(actual value.Value, groups SavedGroups) bool {
 	obj, ok := actual.(value.ObjValue)
 	if !ok {
 		return false
 	}
-	fieldValue := obj.Path(c.path...)
-	return c.cond.Eval(fieldValue, groups)
+	if !c.wildcard {
+		return c.cond.Eval(obj.Path(c.path...), groups)
+	}
+	for _, v := range obj.PathAll(c.path...) {
+		if c.cond.Eval(v, groups) {
+			return true
+		}
+	}
+	return false
 }
 
+// indexRe matches array index or wildcard in brackets, e.g. "[0]" or "[*]".
+var indexRe = regexp.MustCompile(`\[(\d+|\*)\]`)
+
+// NewFieldCond creates condition on the field at the dotted path, e.g. "user.age".
+// Array elements are addressed by index, "items.0.sku" or "items[0].sku", or
+// by "*" wildcard, "addresses.*.country" or "addresses[*].country".
 func NewFieldCond(pathStr string, cond Condition) FieldCond {
-	path := strings.Split(pathStr, ".")
-	return FieldCond{path, cond}
+	path := strings.Split(indexRe.ReplaceAllString(pathStr, ".$1"), ".")
+	wildcard := false
+	for _, field := range path {
+		if field == "*" {
+			wildcard = true
+		}
+	}
+	return FieldCond{path, cond, wildcard}
 }
@@ -15,3 +15,29 @@ func TestFieldCond(t *testing.T) {
 	require.True(t, c.Eval(obj1, nil))
 	require.False(t, c.Eval(obj2, nil))
 }
+
+func TestFieldCondArrays(t *testing.T) {
+	obj := value.Obj(map[string]any{
+		"items": []any{
+			map[string]any{"sku": "a1"},
+			map[string]any{"sku": "b2"},
+		},
+	})
+	tests := []struct {
+		path string
+		sku  string
+		res  bool
+	}{
+		{"items.0.sku", "a1", true},
+		{"items[1].sku", "b2", true},
+		{"items.1.sku", "a1", false},
+		{"items.2.sku", "a1", false},
+		{"items.*.sku", "b2", true},
+		{"items[*].sku", "a1", true},
+		{"items[*].sku", "c3", false},
+	}
+	for _, tt := range tests {
+		c := NewFieldCond(tt.path, NewCompCond(eqOp, tt.sku))
+		require.Equal(t, tt.res, c.Eval(obj, nil), tt.path)
+	}
+}
@@ -1,6 +1,7 @@
 package condition
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"regexp"
@@ -10,6 +11,7 @@ import (
 
 type Base struct {
 	cond Condition
+	src  value.Value
 }
 
 func (base Base) Eval(actual value.Value, groups SavedGroups) bool {
@@ -19,21 +21,47 @@ func (base Base) Eval(actual value.Value, groups SavedGroups) bool {
 	return base.cond.Eval(actual, groups)
 }
 
+// New builds condition from the object of the same shape as JSON conditions.
+func New(obj map[string]any) (Base, error) {
+	json := value.New(obj)
+	cond, err := buildBaseCond(json)
+	if err != nil {
+		return Base{}, err
+	}
+	return Base{cond, json}, nil
+}
+
 func (base *Base) UnmarshalJSON(data []byte) error {
+	// Numbers are decoded as json.Number to compare large integers exactly.
 	m := map[string]any{}
-	err := json.Unmarshal(data, &m)
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	err := dec.Decode(&m)
 	if err != nil {
 		return err
 	}
-	json := value.New(m)
-	cond, err := buildBaseCond(json)
+	res, err := New(m)
 	if err != nil {
 		return err
 	}
-	*base = Base{cond}
+	*base = res
 	return nil
 }
 
+// MarshalJSON writes back the condition object the base was parsed from.
+func (base Base) MarshalJSON() ([]byte, error) {
+	if base.src == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(base.src)
+}
+
+// IsEmpty reports whether the condition matches everything.
+func (base Base) IsEmpty() bool {
+	obj, ok := base.src.(value.ObjValue)
+	return base.src == nil || (ok && len(obj) == 0)
+}
+
 func buildBaseCond(json value.Value) (Condition, error) {
 	obj, ok := json.(value.ObjValue)
 	if !ok {
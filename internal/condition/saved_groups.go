@@ -1,6 +1,7 @@
 package condition
 
 import (
+	"bytes"
 	"encoding/json"
 
 	"github.com/growthbook/growthbook-golang/internal/value"
@@ -10,7 +11,9 @@ type SavedGroups map[string]value.ArrValue
 
 func (sg *SavedGroups) UnmarshalJSON(data []byte) error {
 	var groups map[string][]any
-	if err := json.Unmarshal(data, &groups); err != nil {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&groups); err != nil {
 		return err
 	}
 	*sg = SavedGroups{}
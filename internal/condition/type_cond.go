@@ -28,6 +28,25 @@ func typeFromName(arg string) value.ValueType {
 	}
 }
 
+// TypeName returns JSON type name of the value as used by $type operator,
+// or empty string for null.
+func TypeName(v value.Value) string {
+	switch v.Type() {
+	case value.StrType:
+		return "string"
+	case value.NumType:
+		return "number"
+	case value.BoolType:
+		return "boolean"
+	case value.ObjType:
+		return "object"
+	case value.ArrType:
+		return "array"
+	default:
+		return ""
+	}
+}
+
 func (c TypeCond) Eval(actual value.Value, _ SavedGroups) bool {
 	return actual.Type() == c.t
 }
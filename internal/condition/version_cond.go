@@ -45,7 +45,7 @@ var (
 func paddedVersionString(input value.Value) string {
 	var version string
 	switch v := input.(type) {
-	case value.NumValue, value.StrValue:
+	case value.NumValue, value.IntValue, value.StrValue:
 		version = v.String()
 	}
 	if version == "" {
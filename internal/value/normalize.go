@@ -0,0 +1,96 @@
+package value
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+)
+
+// Unsupported describes a Go value without JSON counterpart, e.g. a channel or a function.
+type Unsupported struct {
+	// Dot-separated path of the value, with array indexes
+	Path string
+	// Go type of the value
+	Type string
+}
+
+// Normalize is like New, but values of Go types that New converts to null, like structs,
+// pointers and maps with non-string keys, are converted through JSON encoding.
+// Values JSON can't encode, like channels, functions and complex numbers, become null
+// and are reported.
+func Normalize(a any) (Value, []Unsupported) {
+	var unsupported []Unsupported
+	res := New(normalize(a, "", &unsupported))
+	return res, unsupported
+}
+
+// NormalizeInto is like Normalize for attributes, but stores values into dst,
+// so the top-level object can be reused.
+func NormalizeInto(dst ObjValue, attributes map[string]any) []Unsupported {
+	var unsupported []Unsupported
+	for k, v := range attributes {
+		dst[k] = New(normalize(v, k, &unsupported))
+	}
+	return unsupported
+}
+
+func normalize(a any, path string, unsupported *[]Unsupported) any {
+	if a == nil {
+		return nil
+	}
+	if _, ok := a.(Value); ok {
+		return a
+	}
+	if _, ok := a.(json.Number); ok {
+		return a
+	}
+	ref := reflect.ValueOf(a)
+	switch ref.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return a
+	case reflect.Array, reflect.Slice:
+		res := make([]any, ref.Len())
+		for i := range res {
+			res[i] = normalize(ref.Index(i).Interface(), joinPath(path, strconv.Itoa(i)), unsupported)
+		}
+		return res
+	case reflect.Map:
+		if ref.Type().Key().Kind() == reflect.String {
+			res := make(map[string]any, ref.Len())
+			iter := ref.MapRange()
+			for iter.Next() {
+				k := iter.Key().String()
+				res[k] = normalize(iter.Value().Interface(), joinPath(path, k), unsupported)
+			}
+			return res
+		}
+	case reflect.Chan, reflect.Func, reflect.Complex64, reflect.Complex128, reflect.UnsafePointer:
+		*unsupported = append(*unsupported, Unsupported{path, ref.Type().String()})
+		return nil
+	}
+
+	data, err := json.Marshal(a)
+	if err != nil {
+		*unsupported = append(*unsupported, Unsupported{path, ref.Type().String()})
+		return nil
+	}
+	var res any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&res); err != nil {
+		*unsupported = append(*unsupported, Unsupported{path, ref.Type().String()})
+		return nil
+	}
+	return res
+}
+
+func joinPath(path string, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
@@ -0,0 +1,50 @@
+package value
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	type user struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+	age := 30
+	v, unsupported := Normalize(map[string]any{
+		"user":    user{"Bob", 30},
+		"age":     &age,
+		"nilPtr":  (*int)(nil),
+		"created": time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		"scores":  map[int]string{1: "a"},
+		"list":    []any{1, make(chan int)},
+		"cb":      func() {},
+		"num":     Num(1),
+		"null":    Null(),
+	})
+	require.Equal(t, ObjValue{
+		"user":    ObjValue{"name": Str("Bob"), "age": Num(30)},
+		"age":     Num(30),
+		"nilPtr":  Null(),
+		"created": Str("2024-01-02T00:00:00Z"),
+		"scores":  ObjValue{"1": Str("a")},
+		"list":    ArrValue{Num(1), Null()},
+		"cb":      Null(),
+		"num":     Num(1),
+		"null":    Null(),
+	}, v)
+	require.ElementsMatch(t, []Unsupported{{"list.1", "chan int"}, {"cb", "func()"}}, unsupported)
+
+	v, unsupported = Normalize(map[string]any{"id": "1"})
+	require.Equal(t, ObjValue{"id": Str("1")}, v)
+	require.Nil(t, unsupported)
+}
+
+func TestNormalizeInto(t *testing.T) {
+	dst := ObjValue{}
+	unsupported := NormalizeInto(dst, map[string]any{"id": "1", "tags": []string{"a"}, "cb": func() {}})
+	require.Equal(t, ObjValue{"id": Str("1"), "tags": ArrValue{Str("a")}, "cb": Null()}, dst)
+	require.Equal(t, []Unsupported{{"cb", "func()"}}, unsupported)
+}
@@ -30,3 +30,7 @@ func IsNull(v Value) bool {
 func (n NullValue) String() string {
 	return "null"
 }
+
+func (n NullValue) MarshalJSON() ([]byte, error) {
+	return []byte("null"), nil
+}
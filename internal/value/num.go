@@ -1,6 +1,9 @@
 package value
 
 import (
+	"cmp"
+	"math"
+	"math/big"
 	"strconv"
 )
 
@@ -40,3 +43,78 @@ func IsNum(v Value) bool {
 func (n NumValue) String() string {
 	return strconv.FormatFloat(float64(n), 'f', -1, 64)
 }
+
+// maxSafeInt is the largest integer float64 represents exactly (2^53).
+const maxSafeInt = 1 << 53
+
+// IntValue is an integer number beyond float64 precision. It keeps large ids
+// like int64 database keys exact in comparisons.
+type IntValue int64
+
+// Int returns number value of n. Integers float64 represents exactly are
+// returned as NumValue, larger ones as IntValue.
+func Int(n int64) Value {
+	if n >= -maxSafeInt && n <= maxSafeInt {
+		return NumValue(n)
+	}
+	return IntValue(n)
+}
+
+func (n IntValue) Type() ValueType {
+	return NumType
+}
+
+func (n IntValue) Cast(t ValueType) Value {
+	switch t {
+	case NumType:
+		return n
+	case BoolType:
+		return Bool(n != 0)
+	case StrType:
+		return Str(n.String())
+	default:
+		return Null()
+	}
+}
+
+// String formats the number as float64, like JS SDKs do, so hash values
+// and variation assignments of large ids stay the same across SDKs.
+func (n IntValue) String() string {
+	return NumValue(n).String()
+}
+
+// CompareNum compares two number values exactly, returns:
+//   - 0, a == b
+//   - 1, a > b
+//   - -1, a < b
+//   - 2, a or b is not a number or is NaN
+func CompareNum(a, b Value) int {
+	ia, aInt := a.(IntValue)
+	ib, bInt := b.(IntValue)
+	if aInt && bInt {
+		return cmp.Compare(ia, ib)
+	}
+	fa, aNum := a.(NumValue)
+	fb, bNum := b.(NumValue)
+	switch {
+	case aNum && bNum:
+		if math.IsNaN(float64(fa)) || math.IsNaN(float64(fb)) {
+			return 2
+		}
+		return cmp.Compare(fa, fb)
+	case aInt && bNum:
+		return compareIntFloat(int64(ia), float64(fb))
+	case aNum && bInt:
+		if res := compareIntFloat(int64(ib), float64(fa)); res != 2 {
+			return -res
+		}
+	}
+	return 2
+}
+
+func compareIntFloat(i int64, f float64) int {
+	if math.IsNaN(f) {
+		return 2
+	}
+	return new(big.Float).SetInt64(i).Cmp(big.NewFloat(f))
+}
@@ -1,5 +1,7 @@
 package value
 
+import "strconv"
+
 type ObjValue map[string]Value
 
 func Obj(args map[string]any) ObjValue {
@@ -28,21 +30,70 @@ func (o ObjValue) Cast(t ValueType) Value {
 	return Null()
 }
 
+// Path returns value at the path of object fields and array indexes,
+// or null if there is none.
 func (o ObjValue) Path(path ...string) Value {
-	var cur ObjValue = o
+	var cur Value = o
 	for _, field := range path {
-		val, ok := cur[field]
-		if !ok {
-			return Null()
-		}
-		cur, ok = val.(ObjValue)
-		if !ok {
-			return val
+		switch v := cur.(type) {
+		case ObjValue, ArrValue:
+			var ok bool
+			cur, ok = step(v, field)
+			if !ok {
+				return Null()
+			}
+		default:
+			return cur
 		}
 	}
 	return cur
 }
 
+// PathAll returns values at the path like [ObjValue.Path], with "*" field matching
+// every element of an array.
+func (o ObjValue) PathAll(path ...string) []Value {
+	return pathAll(o, path)
+}
+
+func pathAll(cur Value, path []string) []Value {
+	for i, field := range path {
+		if arr, ok := cur.(ArrValue); ok && field == "*" {
+			res := []Value{}
+			for _, elem := range arr {
+				res = append(res, pathAll(elem, path[i+1:])...)
+			}
+			return res
+		}
+		switch v := cur.(type) {
+		case ObjValue, ArrValue:
+			var ok bool
+			cur, ok = step(v, field)
+			if !ok {
+				return []Value{Null()}
+			}
+		default:
+			return []Value{cur}
+		}
+	}
+	return []Value{cur}
+}
+
+// step returns field of the object or element of the array at the index.
+func step(v Value, field string) (Value, bool) {
+	switch v := v.(type) {
+	case ObjValue:
+		res, ok := v[field]
+		return res, ok
+	case ArrValue:
+		i, err := strconv.Atoi(field)
+		if err != nil || i < 0 || i >= len(v) {
+			return nil, false
+		}
+		return v[i], true
+	}
+	return nil, false
+}
+
 func (o ObjValue) String() string {
 	return "Object"
 }
@@ -21,7 +21,11 @@ func (s StrValue) Cast(t ValueType) Value {
 		if s == Str("") {
 			return Num(0)
 		}
-		f, err := strconv.ParseFloat(strings.TrimSpace(string(s)), 64)
+		str := strings.TrimSpace(string(s))
+		if i, err := strconv.ParseInt(str, 10, 64); err == nil {
+			return Int(i)
+		}
+		f, err := strconv.ParseFloat(str, 64)
 		if err != nil {
 			return Null()
 		}
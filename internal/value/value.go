@@ -1,6 +1,10 @@
 package value
 
-import "reflect"
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+)
 
 // Value represents Grothbok's internal set of allowed values.
 // Both in rules/conditions and attributes.
@@ -65,19 +69,57 @@ func Equal(v1, v2 Value) bool {
 			}
 		}
 		return true
+	case NumType:
+		return CompareNum(v1, v2) == 0
 	default:
 		return v1 == v2
 	}
 }
 
+// Native converts value back to plain Go values:
+// nil, bool, float64, int64 (for integers beyond float64 precision),
+// string, []any and map[string]any.
+func Native(v Value) any {
+	switch v := v.(type) {
+	case BoolValue:
+		return bool(v)
+	case NumValue:
+		return float64(v)
+	case IntValue:
+		return int64(v)
+	case StrValue:
+		return string(v)
+	case ArrValue:
+		res := make([]any, len(v))
+		for i, item := range v {
+			res[i] = Native(item)
+		}
+		return res
+	case ObjValue:
+		res := make(map[string]any, len(v))
+		for k, item := range v {
+			res[k] = Native(item)
+		}
+		return res
+	default:
+		return nil
+	}
+}
+
 func fromAny(a any) Value {
+	if n, ok := a.(json.Number); ok {
+		return fromJsonNumber(n)
+	}
 	ref := reflect.ValueOf(a)
 	switch {
 	case ref.CanFloat():
 		return Num(ref.Float())
 	case ref.CanInt():
-		return Num(ref.Int())
+		return Int(ref.Int())
 	case ref.CanUint():
+		if u := ref.Uint(); u <= math.MaxInt64 {
+			return Int(int64(u))
+		}
 		return Num(ref.Uint())
 	}
 	switch ref.Kind() {
@@ -108,3 +150,14 @@ func fromAny(a any) Value {
 		return Null()
 	}
 }
+
+// fromJsonNumber converts json.Number into number value, keeping large integers exact.
+func fromJsonNumber(n json.Number) Value {
+	if i, err := n.Int64(); err == nil {
+		return Int(i)
+	}
+	if f, err := n.Float64(); err == nil {
+		return Num(f)
+	}
+	return Str(string(n))
+}
@@ -1,8 +1,11 @@
 package value
 
 import (
-	"github.com/stretchr/testify/require"
+	"encoding/json"
+	"math"
 	"testing"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestValueConstructor(t *testing.T) {
@@ -204,6 +207,24 @@ func TestObjValuePath(t *testing.T) {
 	require.Equal(t, Str("us"), obj.Path(path...))
 }
 
+func TestObjValuePathArrays(t *testing.T) {
+	obj := Obj(map[string]any{
+		"addresses": []any{
+			map[string]any{"country": "US"},
+			map[string]any{"city": "Paris"},
+			map[string]any{"country": "FR"},
+		},
+	})
+	require.Equal(t, Str("FR"), obj.Path("addresses", "2", "country"))
+	require.Equal(t, Null(), obj.Path("addresses", "1", "country"))
+	require.Equal(t, Null(), obj.Path("addresses", "3", "country"))
+	require.Equal(t, Null(), obj.Path("addresses", "-1", "country"))
+	require.Equal(t, Null(), obj.Path("addresses", "country"))
+	require.Equal(t, []Value{Str("US"), Null(), Str("FR")}, obj.PathAll("addresses", "*", "country"))
+	require.Equal(t, []Value{Str("FR")}, obj.PathAll("addresses", "2", "country"))
+	require.Equal(t, []Value{}, Obj(map[string]any{"addresses": []any{}}).PathAll("addresses", "*", "country"))
+}
+
 func TestValueString(t *testing.T) {
 	tests := []struct {
 		v any
@@ -221,3 +242,33 @@ func TestValueString(t *testing.T) {
 		require.Equal(t, tt.s, New(tt.v).String())
 	}
 }
+
+func TestIntValue(t *testing.T) {
+	big := int64(9007199254740993) // 2^53 + 1
+	require.Equal(t, Num(10), New(int64(10)))
+	require.Equal(t, IntValue(big), New(big))
+	require.Equal(t, IntValue(big), New(uint64(big)))
+	require.Equal(t, Num(float64(1<<63)), New(uint64(1<<63)))
+	require.Equal(t, IntValue(big), Str("9007199254740993").Cast(NumType))
+	require.Equal(t, "9007199254740992", New(big).String())
+	require.Equal(t, big, Native(New(big)))
+
+	require.True(t, Equal(New(big), New(big)))
+	require.False(t, Equal(New(big), New(big-1)))
+	require.False(t, Equal(New(big), Num(float64(big))))
+	require.True(t, Equal(New(big+1), Num(float64(big+1))))
+
+	require.Equal(t, 1, CompareNum(New(big), Num(float64(big-1))))
+	require.Equal(t, -1, CompareNum(Num(float64(big-1)), New(big)))
+	require.Equal(t, -1, CompareNum(New(-big), Num(0.5)))
+	require.Equal(t, 2, CompareNum(New(big), Num(math.NaN())))
+	require.Equal(t, 2, CompareNum(Num(math.NaN()), New(big)))
+	require.Equal(t, 2, CompareNum(New(big), Str("1")))
+}
+
+func TestValueFromJsonNumber(t *testing.T) {
+	require.Equal(t, Num(10), New(json.Number("10")))
+	require.Equal(t, Num(10.5), New(json.Number("10.5")))
+	require.Equal(t, IntValue(9007199254740993), New(json.Number("9007199254740993")))
+	require.Equal(t, Arr(1, IntValue(9007199254740993)), New([]any{json.Number("1"), json.Number("9007199254740993")}))
+}
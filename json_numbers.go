@@ -0,0 +1,41 @@
+package growthbook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// WithJsonNumbers enables decoding numbers of feature payloads as [json.Number]
+// instead of float64, so feature values keep integers beyond float64 precision
+// and their original representation. Shared with child clients.
+// [FeatureResult.IntValue] and conditions handle json.Number values,
+// as well as attributes passed as json.Number.
+func WithJsonNumbers(enabled bool) ClientOption {
+	return func(c *Client) error {
+		c.data.useNumber = enabled
+		return nil
+	}
+}
+
+// unmarshal decodes payload JSON, keeping numbers as json.Number if enabled.
+func (d *data) unmarshal(data []byte, v any) error {
+	if !d.useNumber {
+		return json.Unmarshal(data, v)
+	}
+	return unmarshalUseNumber(data, v)
+}
+
+// unmarshalUseNumber is like json.Unmarshal, but decodes numbers into any as json.Number.
+func unmarshalUseNumber(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("invalid character after top-level value")
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package growthbook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const jsonNumbersFeatures = `{
+  "big": {"defaultValue": 9007199254740993},
+  "vip": {"defaultValue": false, "rules": [{"condition": {"id": 9007199254740993}, "force": true}]}
+}`
+
+func TestClientJsonNumbers(t *testing.T) {
+	ctx := context.TODO()
+	client, err := NewClient(ctx, WithJsonNumbers(true), WithJsonFeatures(jsonNumbersFeatures))
+	require.Nil(t, err)
+
+	res := client.EvalFeature(ctx, "big")
+	require.Equal(t, json.Number("9007199254740993"), res.Value)
+	require.Equal(t, 9007199254740993, res.IntValue(0))
+	require.True(t, res.On)
+
+	for _, id := range []any{json.Number("9007199254740993"), int64(9007199254740993), "9007199254740993"} {
+		child, err := client.WithAttributes(Attributes{"id": id})
+		require.Nil(t, err)
+		require.True(t, child.EvalFeature(ctx, "vip").On, "id %#v", id)
+	}
+	child, _ := client.WithAttributes(Attributes{"id": int64(9007199254740992)})
+	require.False(t, child.EvalFeature(ctx, "vip").On)
+}
+
+func TestClientWithoutJsonNumbers(t *testing.T) {
+	ctx := context.TODO()
+	client, err := NewClient(ctx, WithJsonFeatures(jsonNumbersFeatures))
+	require.Nil(t, err)
+	require.Equal(t, float64(9007199254740992), client.EvalFeature(ctx, "big").Value)
+
+	// Conditions keep large integers regardless of the option
+	child, _ := client.WithAttributes(Attributes{"id": int64(9007199254740992)})
+	require.False(t, child.EvalFeature(ctx, "vip").On)
+}
@@ -0,0 +1,278 @@
+package growthbook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrJsonPatch is returned when RFC 6902 JSON Patch can't be applied to the payload.
+var ErrJsonPatch = errors.New("Error applying JSON patch")
+
+type jsonPatchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// applyJsonPatch applies RFC 6902 JSON Patch to the JSON document.
+// Document is left untouched if any operation fails.
+func applyJsonPatch(doc []byte, patch []byte) ([]byte, error) {
+	var ops []jsonPatchOp
+	err := json.Unmarshal(patch, &ops)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrJsonPatch, err)
+	}
+	// Numbers are kept as json.Number, so the patched document doesn't lose precision.
+	var root any
+	err = unmarshalUseNumber(doc, &root)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrJsonPatch, err)
+	}
+	for i, op := range ops {
+		root, err = op.apply(root)
+		if err != nil {
+			return nil, fmt.Errorf("%w: operation %d (%s %s): %w", ErrJsonPatch, i, op.Op, op.Path, err)
+		}
+	}
+	return json.Marshal(root)
+}
+
+func (op *jsonPatchOp) apply(root any) (any, error) {
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, errors.New("missing value")
+		}
+		var val any
+		err := unmarshalUseNumber(op.Value, &val)
+		if err != nil {
+			return nil, err
+		}
+		switch op.Op {
+		case "add":
+			return jsonPointerAdd(root, op.Path, val)
+		case "replace":
+			if op.Path == "" {
+				return val, nil
+			}
+			root, _, err = jsonPointerRemove(root, op.Path)
+			if err != nil {
+				return nil, err
+			}
+			return jsonPointerAdd(root, op.Path, val)
+		default:
+			cur, err := jsonPointerGet(root, op.Path)
+			if err != nil {
+				return nil, err
+			}
+			if !jsonEqual(cur, val) {
+				return nil, errors.New("test failed")
+			}
+			return root, nil
+		}
+	case "remove":
+		root, _, err := jsonPointerRemove(root, op.Path)
+		return root, err
+	case "move":
+		if strings.HasPrefix(op.Path, op.From+"/") {
+			return nil, errors.New("can't move value into its child")
+		}
+		root, val, err := jsonPointerRemove(root, op.From)
+		if err != nil {
+			return nil, err
+		}
+		return jsonPointerAdd(root, op.Path, val)
+	case "copy":
+		val, err := jsonPointerGet(root, op.From)
+		if err != nil {
+			return nil, err
+		}
+		return jsonPointerAdd(root, op.Path, jsonDeepCopy(val))
+	default:
+		return nil, fmt.Errorf("unknown operation %q", op.Op)
+	}
+}
+
+func parseJsonPointer(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if path[0] != '/' {
+		return nil, fmt.Errorf("invalid pointer %q", path)
+	}
+	tokens := strings.Split(path[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func jsonArrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if allowEnd && token == "-" {
+		return length, nil
+	}
+	idx, err := strconv.Atoi(token)
+	if err != nil || idx < 0 || idx > length || (idx == length && !allowEnd) || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	return idx, nil
+}
+
+func jsonPointerGet(root any, path string) (any, error) {
+	tokens, err := parseJsonPointer(path)
+	if err != nil {
+		return nil, err
+	}
+	cur := root
+	for _, t := range tokens {
+		switch node := cur.(type) {
+		case map[string]any:
+			val, ok := node[t]
+			if !ok {
+				return nil, fmt.Errorf("path %q not found", path)
+			}
+			cur = val
+		case []any:
+			idx, err := jsonArrayIndex(t, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			cur = node[idx]
+		default:
+			return nil, fmt.Errorf("path %q not found", path)
+		}
+	}
+	return cur, nil
+}
+
+// jsonPointerUpdate replaces the parent container of the path with the result of update.
+// Arrays may change their length, so parents are reassigned on the way back.
+func jsonPointerUpdate(root any, tokens []string, update func(parent any, token string) (any, error)) (any, error) {
+	if len(tokens) == 1 {
+		return update(root, tokens[0])
+	}
+	t := tokens[0]
+	switch node := root.(type) {
+	case map[string]any:
+		child, ok := node[t]
+		if !ok {
+			return nil, fmt.Errorf("path segment %q not found", t)
+		}
+		res, err := jsonPointerUpdate(child, tokens[1:], update)
+		if err != nil {
+			return nil, err
+		}
+		node[t] = res
+		return node, nil
+	case []any:
+		idx, err := jsonArrayIndex(t, len(node), false)
+		if err != nil {
+			return nil, err
+		}
+		res, err := jsonPointerUpdate(node[idx], tokens[1:], update)
+		if err != nil {
+			return nil, err
+		}
+		node[idx] = res
+		return node, nil
+	default:
+		return nil, fmt.Errorf("path segment %q not found", t)
+	}
+}
+
+func jsonPointerAdd(root any, path string, val any) (any, error) {
+	tokens, err := parseJsonPointer(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return val, nil
+	}
+	return jsonPointerUpdate(root, tokens, func(parent any, t string) (any, error) {
+		switch node := parent.(type) {
+		case map[string]any:
+			node[t] = val
+			return node, nil
+		case []any:
+			idx, err := jsonArrayIndex(t, len(node), true)
+			if err != nil {
+				return nil, err
+			}
+			node = append(node, nil)
+			copy(node[idx+1:], node[idx:])
+			node[idx] = val
+			return node, nil
+		default:
+			return nil, fmt.Errorf("can't add to %q", path)
+		}
+	})
+}
+
+func jsonPointerRemove(root any, path string) (any, any, error) {
+	tokens, err := parseJsonPointer(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil, errors.New("can't remove document root")
+	}
+	var removed any
+	root, err = jsonPointerUpdate(root, tokens, func(parent any, t string) (any, error) {
+		switch node := parent.(type) {
+		case map[string]any:
+			val, ok := node[t]
+			if !ok {
+				return nil, fmt.Errorf("path %q not found", path)
+			}
+			removed = val
+			delete(node, t)
+			return node, nil
+		case []any:
+			idx, err := jsonArrayIndex(t, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			removed = node[idx]
+			return append(node[:idx], node[idx+1:]...), nil
+		default:
+			return nil, fmt.Errorf("path %q not found", path)
+		}
+	})
+	return root, removed, err
+}
+
+func jsonDeepCopy(val any) any {
+	switch v := val.(type) {
+	case map[string]any:
+		res := make(map[string]any, len(v))
+		for k, item := range v {
+			res[k] = jsonDeepCopy(item)
+		}
+		return res
+	case []any:
+		res := make([]any, len(v))
+		for i, item := range v {
+			res[i] = jsonDeepCopy(item)
+		}
+		return res
+	default:
+		return val
+	}
+}
+
+// jsonEqual compares decoded JSON values, treating numbers of different representation
+// like 1 and 1.0 as equal.
+func jsonEqual(a, b any) bool {
+	var fa, fb any
+	da, errA := json.Marshal(a)
+	db, errB := json.Marshal(b)
+	if errA != nil || errB != nil || json.Unmarshal(da, &fa) != nil || json.Unmarshal(db, &fb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(fa, fb)
+}
@@ -0,0 +1,53 @@
+package growthbook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyJsonPatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+	}{
+		{"add object member", `{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`},
+		{"add array element", `{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{"add to array end", `{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":"baz"}]`, `{"foo":["bar","baz"]}`},
+		{"remove object member", `{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{"remove array element", `{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{"replace value", `{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{"move value", `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`,
+			`[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+			`{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{"copy value", `{"foo":{"a":1}}`, `[{"op":"copy","from":"/foo","path":"/bar"}]`, `{"bar":{"a":1},"foo":{"a":1}}`},
+		{"test and escaped pointer", `{"a/b":{"m~n":1}}`, `[{"op":"test","path":"/a~1b/m~0n","value":1},{"op":"replace","path":"/a~1b/m~0n","value":2}]`, `{"a/b":{"m~n":2}}`},
+		{"test numbers and keep precision", `{"id":9007199254740993,"n":1.0}`, `[{"op":"test","path":"/n","value":1}]`, `{"id":9007199254740993,"n":1.0}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := applyJsonPatch([]byte(test.doc), []byte(test.patch))
+			require.Nil(t, err)
+			require.JSONEq(t, test.expected, string(res))
+		})
+	}
+
+	errTests := []struct {
+		name  string
+		patch string
+	}{
+		{"failed test", `[{"op":"test","path":"/foo","value":"baz"}]`},
+		{"missing path", `[{"op":"remove","path":"/baz"}]`},
+		{"invalid index", `[{"op":"add","path":"/arr/5","value":1}]`},
+		{"unknown op", `[{"op":"merge","path":"/foo","value":1}]`},
+		{"invalid patch", `{}`},
+	}
+	for _, test := range errTests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := applyJsonPatch([]byte(`{"foo":"bar","arr":[]}`), []byte(test.patch))
+			require.ErrorIs(t, err, ErrJsonPatch)
+		})
+	}
+}
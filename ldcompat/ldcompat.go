@@ -0,0 +1,167 @@
+// Package ldcompat provides a LaunchDarkly-like client on top of GrowthBook client
+// to migrate call sites without rewriting them at once.
+//
+// Variation methods take the flag key, the user and the default value, and return
+// the default value with an error if the feature is missing or has another type.
+// User key is passed as the "id" attribute, built-in and custom user attributes
+// as attributes of the same names.
+package ldcompat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/growthbook/growthbook-golang"
+)
+
+var (
+	ErrFlagNotFound = errors.New("Unknown feature key")
+	ErrWrongType    = errors.New("Feature value has wrong type")
+)
+
+// User is a LaunchDarkly-style user, which is converted into GrowthBook attributes.
+type User struct {
+	Key       string
+	IP        string
+	Country   string
+	Email     string
+	FirstName string
+	LastName  string
+	Avatar    string
+	Name      string
+	Anonymous bool
+	// Custom attributes. Built-in attributes take precedence.
+	Custom map[string]any
+}
+
+// NewUser creates user with the key.
+func NewUser(key string) User {
+	return User{Key: key}
+}
+
+// Attributes converts user into GrowthBook attributes. Empty attributes are omitted.
+func (u User) Attributes() growthbook.Attributes {
+	attrs := make(growthbook.Attributes, len(u.Custom)+2)
+	for k, v := range u.Custom {
+		attrs[k] = v
+	}
+	builtin := map[string]string{
+		"id":        u.Key,
+		"ip":        u.IP,
+		"country":   u.Country,
+		"email":     u.Email,
+		"firstName": u.FirstName,
+		"lastName":  u.LastName,
+		"avatar":    u.Avatar,
+		"name":      u.Name,
+	}
+	for k, v := range builtin {
+		if v != "" {
+			attrs[k] = v
+		}
+	}
+	if u.Anonymous {
+		attrs["anonymous"] = true
+	}
+	return attrs
+}
+
+// Client evaluates LaunchDarkly-style variations with GrowthBook client.
+type Client struct {
+	gb *growthbook.Client
+}
+
+// NewClient creates client evaluating features with the GrowthBook client.
+func NewClient(gb *growthbook.Client) *Client {
+	return &Client{gb}
+}
+
+// Close closes the underlying GrowthBook client.
+func (c *Client) Close() error {
+	return c.gb.Close()
+}
+
+// BoolVariation returns value of the boolean feature for the user.
+func (c *Client) BoolVariation(key string, user User, defaultVal bool) (bool, error) {
+	value, err := c.variation(key, user)
+	if err != nil {
+		return defaultVal, err
+	}
+	v, ok := value.(bool)
+	if !ok {
+		return defaultVal, wrongType(key, value)
+	}
+	return v, nil
+}
+
+// StringVariation returns value of the string feature for the user.
+func (c *Client) StringVariation(key string, user User, defaultVal string) (string, error) {
+	value, err := c.variation(key, user)
+	if err != nil {
+		return defaultVal, err
+	}
+	v, ok := value.(string)
+	if !ok {
+		return defaultVal, wrongType(key, value)
+	}
+	return v, nil
+}
+
+// IntVariation returns value of the number feature for the user.
+// Fractional numbers are truncated, as LaunchDarkly does.
+func (c *Client) IntVariation(key string, user User, defaultVal int) (int, error) {
+	f, err := c.Float64Variation(key, user, float64(defaultVal))
+	if err != nil {
+		return defaultVal, err
+	}
+	return int(math.Trunc(f)), nil
+}
+
+// Float64Variation returns value of the number feature for the user.
+func (c *Client) Float64Variation(key string, user User, defaultVal float64) (float64, error) {
+	value, err := c.variation(key, user)
+	if err != nil {
+		return defaultVal, err
+	}
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return f, nil
+		}
+	}
+	return defaultVal, wrongType(key, value)
+}
+
+// JSONVariation returns JSON encoded value of the feature of any type for the user.
+func (c *Client) JSONVariation(key string, user User, defaultVal json.RawMessage) (json.RawMessage, error) {
+	value, err := c.variation(key, user)
+	if err != nil {
+		return defaultVal, err
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return defaultVal, fmt.Errorf("%w: %w", ErrWrongType, err)
+	}
+	return data, nil
+}
+
+func (c *Client) variation(key string, user User) (any, error) {
+	client, err := c.gb.WithAttributes(user.Attributes())
+	if err != nil {
+		return nil, err
+	}
+	res := client.EvalFeature(context.Background(), key)
+	if res.Source == growthbook.UnknownFeatureResultSource {
+		return nil, fmt.Errorf("%w: %q", ErrFlagNotFound, key)
+	}
+	return res.Value, nil
+}
+
+func wrongType(key string, value any) error {
+	return fmt.Errorf("%w: feature %q value is %T", ErrWrongType, key, value)
+}
@@ -0,0 +1,51 @@
+package ldcompat
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/growthbook/growthbook-golang"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	gb, err := growthbook.NewClient(context.TODO(), growthbook.WithJsonFeatures(`{
+	  "beta": {"defaultValue": false, "rules": [{"condition": {"email": {"$regex": "@example.com$"}, "plan": "pro"}, "force": true}]},
+	  "title": {"defaultValue": "Hello"},
+	  "limit": {"defaultValue": 2.7},
+	  "config": {"defaultValue": {"a": [1, 2]}}
+	}`))
+	require.Nil(t, err)
+	client := NewClient(gb)
+
+	user := User{Key: "1", Email: "bob@example.com", Custom: map[string]any{"plan": "pro", "email": "ignored"}}
+	require.Equal(t, growthbook.Attributes{"id": "1", "email": "bob@example.com", "plan": "pro"}, user.Attributes())
+
+	b, err := client.BoolVariation("beta", user, false)
+	require.Nil(t, err)
+	require.True(t, b)
+	b, err = client.BoolVariation("beta", NewUser("2"), true)
+	require.Nil(t, err)
+	require.False(t, b)
+
+	s, err := client.StringVariation("title", user, "")
+	require.Nil(t, err)
+	require.Equal(t, "Hello", s)
+
+	i, err := client.IntVariation("limit", user, 0)
+	require.Nil(t, err)
+	require.Equal(t, 2, i)
+
+	j, err := client.JSONVariation("config", user, nil)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"a": [1, 2]}`, string(j))
+
+	s, err = client.StringVariation("limit", user, "def")
+	require.ErrorIs(t, err, ErrWrongType)
+	require.Equal(t, "def", s)
+
+	j, err = client.JSONVariation("missing", user, json.RawMessage(`null`))
+	require.ErrorIs(t, err, ErrFlagNotFound)
+	require.Equal(t, json.RawMessage(`null`), j)
+}
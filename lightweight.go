@@ -0,0 +1,207 @@
+package growthbook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// lightweightCache is the default cache of lightweight clients. It outlives clients,
+// so clients created per invocation of a warm serverless instance share the payload.
+var lightweightCache SharedCache = newMemoryCache()
+
+// lightweight fetches features lazily on evaluation instead of a data source.
+type lightweight struct {
+	ttl     time.Duration
+	cache   SharedCache
+	mu      sync.Mutex
+	expires time.Time
+	payload []byte
+}
+
+// WithLightweightMode is intended for bursty serverless platforms, like AWS Lambda
+// or Cloud Run. Client runs no background goroutines: features are fetched on the
+// first evaluation and refetched with conditional requests once older than ttl.
+// Expiration is jittered by up to 20% of ttl, so instances started by a burst don't
+// refetch at once. Payload with its ETag is shared via the cache, a package-level
+// in-memory cache if nil. Call [Client.WarmUp] in init hooks to fetch in advance.
+func WithLightweightMode(ttl time.Duration, cache SharedCache) ClientOption {
+	return func(c *Client) error {
+		if ttl <= 0 {
+			return errors.New("Lightweight mode ttl must be positive")
+		}
+		if cache == nil {
+			cache = lightweightCache
+		}
+		c.data.dsFactory = nil
+		c.data.light.Store(&lightweight{ttl: ttl, cache: cache})
+		return nil
+	}
+}
+
+// WarmUp loads features, for provisioned concurrency and other init hooks.
+// In lightweight mode it fetches features unless they are fresh,
+// otherwise it waits for the data source like [Client.EnsureLoaded].
+func (client *Client) WarmUp(ctx context.Context) error {
+	if l := client.data.light.Load(); l != nil {
+		return client.refreshLightweight(ctx, l)
+	}
+	return client.EnsureLoaded(ctx)
+}
+
+// refreshLightweight loads features from the cache or the API once they expire.
+// Concurrent evaluations wait for the single refresh.
+func (client *Client) refreshLightweight(ctx context.Context, l *lightweight) (err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	if now.Before(l.expires) {
+		return nil
+	}
+	defer func() {
+		// Stale features are served until the next refresh, but without
+		// features every evaluation retries.
+		if err == nil || l.payload != nil {
+			l.expires = now.Add(l.ttl - rand.N(l.ttl/5+1))
+		}
+	}()
+
+	key := lightweightKey(client.data.getApiUrl())
+	fetched, etag, cached, err := client.readLightweightCache(ctx, l, key)
+	if err != nil {
+		client.logger.Warn("Shared cache error, loading from API", "error", err)
+	}
+	if cached != nil && now.Sub(fetched) < l.ttl {
+		return client.applyLightweight(ctx, l, cached)
+	}
+
+	resp, err := client.CallFeatureApi(ctx, etag)
+	if err != nil {
+		return err
+	}
+	payload := cached
+	if resp.Status != http.StatusNotModified {
+		payload = resp.body
+	}
+	if payload == nil && l.payload != nil {
+		// Request in flight is slow, keep current features
+		return nil
+	}
+	if payload == nil {
+		return errors.New("Empty features response")
+	}
+	client.writeLightweightCache(ctx, l, key, resp.Etag, payload)
+	return client.applyLightweight(ctx, l, payload)
+}
+
+func lightweightKey(apiUrl string) string {
+	return "gb:" + apiUrl + ":lightweight"
+}
+
+func (client *Client) applyLightweight(ctx context.Context, l *lightweight, payload []byte) error {
+	if bytes.Equal(payload, l.payload) {
+		client.markRefreshed()
+		return nil
+	}
+	if err := client.updateFromApiResponseJSON(ctx, string(payload)); err != nil {
+		return err
+	}
+	l.payload = payload
+	return nil
+}
+
+// Cache value is "<fetched unix ms> <etag>\n<payload encoded for the cache>".
+func (client *Client) readLightweightCache(ctx context.Context, l *lightweight, key string) (time.Time, string, []byte, error) {
+	value, err := l.cache.Get(ctx, key)
+	if err != nil || value == nil {
+		return time.Time{}, "", nil, err
+	}
+	header, encoded, ok := bytes.Cut(value, []byte("\n"))
+	if !ok {
+		return time.Time{}, "", nil, errors.New("Invalid lightweight cache value")
+	}
+	ms, etag, _ := bytes.Cut(header, []byte(" "))
+	fetched, err := strconv.ParseInt(string(ms), 10, 64)
+	if err != nil {
+		return time.Time{}, "", nil, err
+	}
+	payload, err := client.data.decodeCachePayload(encoded)
+	if err != nil {
+		return time.Time{}, "", nil, err
+	}
+	return time.UnixMilli(fetched), string(etag), payload, nil
+}
+
+func (client *Client) writeLightweightCache(ctx context.Context, l *lightweight, key string, etag string, payload []byte) {
+	encoded, err := client.data.encodeCachePayload(payload)
+	if err == nil {
+		value := strconv.AppendInt(nil, time.Now().UnixMilli(), 10)
+		value = append(append(append(append(value, ' '), etag...), '\n'), encoded...)
+		err = l.cache.Set(ctx, key, value, 0)
+	}
+	if err != nil {
+		client.logger.Warn("Error writing payload to shared cache", "error", err)
+	}
+}
+
+// memoryCache is in-process [SharedCache].
+type memoryCache struct {
+	mu      sync.Mutex
+	values  map[string][]byte
+	expires map[string]time.Time
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{values: map[string][]byte{}, expires: map[string]time.Time{}}
+}
+
+func (c *memoryCache) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.get(key), nil
+}
+
+func (c *memoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(key, value, ttl)
+	return nil
+}
+
+func (c *memoryCache) SetNX(_ context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.get(key) != nil {
+		return false, nil
+	}
+	c.set(key, value, ttl)
+	return true, nil
+}
+
+func (c *memoryCache) delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.values, key)
+	delete(c.expires, key)
+}
+
+func (c *memoryCache) get(key string) []byte {
+	if exp, ok := c.expires[key]; ok && !time.Now().Before(exp) {
+		delete(c.values, key)
+		delete(c.expires, key)
+	}
+	return c.values[key]
+}
+
+func (c *memoryCache) set(key string, value []byte, ttl time.Duration) {
+	c.values[key] = value
+	delete(c.expires, key)
+	if ttl > 0 {
+		c.expires[key] = time.Now().Add(ttl)
+	}
+}
@@ -0,0 +1,64 @@
+package growthbook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLightweightMode(t *testing.T) {
+	ctx := context.TODO()
+	var requests, notModified atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Etag", `"v1"`)
+		w.Write([]byte(`{"features": {"foo": {"defaultValue": "api"}}}`))
+	}))
+	defer ts.Close()
+
+	newClient := func(ttl time.Duration, cache SharedCache) *Client {
+		client, err := NewClient(ctx,
+			WithHttpClient(ts.Client()),
+			WithApiHost(ts.URL),
+			WithClientKey("somekey"),
+			WithLightweightMode(ttl, cache),
+		)
+		require.Nil(t, err)
+		return client
+	}
+
+	cache := newMemoryCache()
+	client := newClient(time.Hour, cache)
+	require.Equal(t, int32(0), requests.Load())
+	require.Equal(t, "api", client.EvalFeature(ctx, "foo").Value)
+	require.Equal(t, "api", client.EvalFeature(ctx, "foo").Value)
+	require.Equal(t, int32(1), requests.Load())
+	require.Equal(t, 0, client.Stats().Goroutines)
+
+	// New client of the same instance reads payload from the cache
+	other := newClient(time.Hour, cache)
+	require.Nil(t, other.WarmUp(ctx))
+	require.Equal(t, "api", other.EvalFeature(ctx, "foo").Value)
+	require.Equal(t, int32(1), requests.Load())
+
+	// Expired payload is revalidated with the cached etag
+	expired := newClient(time.Millisecond, cache)
+	time.Sleep(5 * time.Millisecond)
+	require.Nil(t, expired.EnsureLoaded(ctx))
+	require.Equal(t, "api", expired.EvalFeature(ctx, "foo").Value)
+	require.Equal(t, int32(2), requests.Load())
+	require.Equal(t, int32(1), notModified.Load())
+
+	_, err := NewClient(ctx, WithLightweightMode(0, nil))
+	require.NotNil(t, err)
+}
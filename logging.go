@@ -0,0 +1,55 @@
+package growthbook
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"slices"
+)
+
+// WithLoggerFields adds static fields to every log line of the client, e.g.
+// WithLoggerFields("service", "billing"). Arguments are passed to [slog.Logger.With].
+func WithLoggerFields(args ...any) ClientOption {
+	return func(c *Client) error {
+		c.logFields = append(slices.Clip(c.logFields), args...)
+		return nil
+	}
+}
+
+// scopeLogger adds fields identifying the client to its logger: API host, hash of the
+// client key, id of the instance shared with child clients and user fields.
+// Fields are set on client creation and aren't updated by [Client.Reconfigure].
+func (client *Client) scopeLogger() {
+	d := client.data
+	d.mu.RLock()
+	args := []any{"apiHost", d.apiHost, "instanceId", d.instanceId}
+	if d.clientKey != "" {
+		args = append(args, "clientKeyHash", clientKeyHash(d.clientKey))
+	}
+	d.mu.RUnlock()
+	client.logger = client.baseLogger.With(append(args, client.logFields...)...)
+}
+
+// evalLogger returns logger of the feature or experiment evaluation. The evaluation
+// context is added only if debug logs are enabled, as they are the most of
+// evaluation logs and adding fields isn't free.
+func (client *Client) evalLogger(ctx context.Context, args ...any) *slog.Logger {
+	if !client.logger.Enabled(ctx, slog.LevelDebug) {
+		return client.logger
+	}
+	return client.logger.With(args...)
+}
+
+// clientKeyHash identifies client key in logs without revealing it.
+func clientKeyHash(clientKey string) string {
+	sum := sha256.Sum256([]byte(clientKey))
+	return hex.EncodeToString(sum[:4])
+}
+
+func newInstanceId() string {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,43 @@
+package growthbook
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientLoggerFields(t *testing.T) {
+	var logs bytes.Buffer
+	handler := slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})
+	client, err := NewClient(context.TODO(),
+		WithLogger(slog.New(handler)),
+		WithClientKey("sdk-key"),
+		WithLoggerFields("service", "billing"),
+		WithJsonFeatures(`{"feature": {"defaultValue": 1, "rules": [{"variations": [1]}]}}`),
+	)
+	require.Nil(t, err)
+
+	child, err := client.WithAttributes(Attributes{"id": "2"})
+	require.Nil(t, err)
+	child.EvalFeature(context.TODO(), "feature")
+	out := logs.String()
+	require.Contains(t, out, "apiHost="+defaultApiHost)
+	require.Contains(t, out, "instanceId="+client.data.instanceId)
+	require.Contains(t, out, "clientKeyHash="+clientKeyHash("sdk-key"))
+	require.Contains(t, out, "service=billing")
+	require.Contains(t, out, "feature=feature")
+
+	inactive := false
+	child.RunExperiment(context.TODO(), &Experiment{Key: "exp", Variations: []FeatureValue{0, 1}, Active: &inactive})
+	require.Contains(t, logs.String(), "experiment=exp")
+	require.NotContains(t, logs.String(), "sdk-key")
+
+	logs.Reset()
+	other, err := child.cloneWith(WithLoggerFields("request", "42"))
+	require.Nil(t, err)
+	other.EvalFeature(context.TODO(), "feature")
+	require.Contains(t, logs.String(), "service=billing request=42")
+}
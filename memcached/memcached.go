@@ -0,0 +1,275 @@
+// Package memcached provides memcached-backed [growthbook.SharedCache] and
+// [growthbook.StickyBucketService], for fleets that run memcached instead of Redis.
+// The client speaks the memcached text protocol using the standard library only,
+// so the package adds no dependencies and is compiled only when imported.
+package memcached
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/growthbook/growthbook-golang"
+)
+
+const (
+	defaultTimeout      = time.Second
+	defaultMaxIdleConns = 4
+	maxKeyLength        = 250
+	// Longer expiration is interpreted by memcached as unix time
+	maxRelativeExpiration = 30 * 24 * time.Hour
+)
+
+// Client is a client of a single memcached server with a pool of connections.
+// It implements [growthbook.SharedCache], e.g. for [growthbook.WithCoordinatedPollDataSource]
+// and [growthbook.WithLightweightMode].
+type Client struct {
+	addr    string
+	timeout time.Duration
+	maxIdle int
+	dialer  net.Dialer
+	mu      sync.Mutex
+	idle    []*conn
+}
+
+var _ growthbook.SharedCache = &Client{}
+
+type conn struct {
+	net.Conn
+	rw *bufio.ReadWriter
+}
+
+// Option configures [Client].
+type Option func(*Client)
+
+// WithTimeout sets timeout of dialing and of a single command, unless the context
+// deadline is earlier. Default 1 second.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithMaxIdleConns sets number of idle connections kept for reuse. Default 4.
+func WithMaxIdleConns(n int) Option {
+	return func(c *Client) {
+		c.maxIdle = n
+	}
+}
+
+// NewClient creates client of the memcached server at addr, e.g. "localhost:11211".
+// Connections are opened on demand.
+func NewClient(addr string, opts ...Option) *Client {
+	c := &Client{
+		addr:    addr,
+		timeout: defaultTimeout,
+		maxIdle: defaultMaxIdleConns,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Get returns value of the key or nil if there is none.
+func (c *Client) Get(ctx context.Context, key string) ([]byte, error) {
+	value, _, err := c.gets(ctx, key)
+	return value, err
+}
+
+// Set stores value of the key. Zero ttl means no expiration.
+func (c *Client) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := c.store(ctx, "set", key, value, ttl, 0)
+	return err
+}
+
+// SetNX stores value only if the key doesn't exist and reports whether it was stored.
+func (c *Client) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	return c.store(ctx, "add", key, value, ttl, 0)
+}
+
+// Close closes idle connections. Connections in use are closed when returned.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	idle := c.idle
+	c.idle, c.maxIdle = nil, 0
+	c.mu.Unlock()
+	for _, cn := range idle {
+		_ = cn.Close()
+	}
+	return nil
+}
+
+// gets returns value of the key with its check-and-set id.
+func (c *Client) gets(ctx context.Context, key string) (value []byte, cas uint64, err error) {
+	err = c.do(ctx, func(cn *conn) error {
+		if _, err := fmt.Fprintf(cn.rw, "gets %s\r\n", memcachedKey(key)); err != nil {
+			return err
+		}
+		if err := cn.rw.Flush(); err != nil {
+			return err
+		}
+		line, err := readLine(cn.rw)
+		if err != nil || string(line) == "END" {
+			return err
+		}
+		// VALUE <key> <flags> <bytes> <cas unique>
+		fields := bytes.Fields(line)
+		if len(fields) != 5 || string(fields[0]) != "VALUE" {
+			return fmt.Errorf("Unexpected memcached response: %q", line)
+		}
+		size, err := strconv.Atoi(string(fields[3]))
+		if err != nil {
+			return err
+		}
+		if cas, err = strconv.ParseUint(string(fields[4]), 10, 64); err != nil {
+			return err
+		}
+		value = make([]byte, size+2)
+		if _, err := io.ReadFull(cn.rw, value); err != nil {
+			return err
+		}
+		value = value[:size]
+		if line, err = readLine(cn.rw); err != nil {
+			return err
+		}
+		if string(line) != "END" {
+			return fmt.Errorf("Unexpected memcached response: %q", line)
+		}
+		return nil
+	})
+	return value, cas, err
+}
+
+// store runs set, add or cas command and reports whether the value was stored.
+func (c *Client) store(ctx context.Context, cmd string, key string, value []byte, ttl time.Duration, cas uint64) (bool, error) {
+	stored := false
+	err := c.do(ctx, func(cn *conn) error {
+		_, err := fmt.Fprintf(cn.rw, "%s %s 0 %d %d", cmd, memcachedKey(key), expiration(ttl), len(value))
+		if err == nil && cmd == "cas" {
+			_, err = fmt.Fprintf(cn.rw, " %d", cas)
+		}
+		if err == nil {
+			_, err = cn.rw.WriteString("\r\n")
+		}
+		if err == nil {
+			_, err = cn.rw.Write(value)
+		}
+		if err == nil {
+			_, err = cn.rw.WriteString("\r\n")
+		}
+		if err == nil {
+			err = cn.rw.Flush()
+		}
+		if err != nil {
+			return err
+		}
+		line, err := readLine(cn.rw)
+		if err != nil {
+			return err
+		}
+		switch string(line) {
+		case "STORED":
+			stored = true
+		case "NOT_STORED", "EXISTS", "NOT_FOUND":
+		default:
+			return fmt.Errorf("Unexpected memcached response: %q", line)
+		}
+		return nil
+	})
+	return stored, err
+}
+
+// do runs the command on an idle or new connection. Connections are reused
+// only after successful commands, as failed ones may leave unread responses.
+func (c *Client) do(ctx context.Context, fn func(*conn) error) error {
+	cn, err := c.conn(ctx)
+	if err != nil {
+		return err
+	}
+	deadline := time.Now().Add(c.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := cn.SetDeadline(deadline); err != nil {
+		_ = cn.Close()
+		return err
+	}
+	if err := fn(cn); err != nil {
+		_ = cn.Close()
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.idle) < c.maxIdle {
+		c.idle = append(c.idle, cn)
+		return nil
+	}
+	return cn.Close()
+}
+
+func (c *Client) conn(ctx context.Context) (*conn, error) {
+	c.mu.Lock()
+	if n := len(c.idle); n > 0 {
+		cn := c.idle[n-1]
+		c.idle = c.idle[:n-1]
+		c.mu.Unlock()
+		return cn, nil
+	}
+	c.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	nc, err := c.dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{Conn: nc, rw: bufio.NewReadWriter(bufio.NewReader(nc), bufio.NewWriter(nc))}, nil
+}
+
+// readLine reads response line, returning error responses as errors.
+func readLine(r *bufio.ReadWriter) ([]byte, error) {
+	line, err := r.ReadSlice('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = bytes.TrimSuffix(bytes.TrimSuffix(line, []byte("\n")), []byte("\r"))
+	if string(line) == "ERROR" || bytes.HasPrefix(line, []byte("CLIENT_ERROR")) || bytes.HasPrefix(line, []byte("SERVER_ERROR")) {
+		return nil, fmt.Errorf("Memcached error: %s", line)
+	}
+	return line, nil
+}
+
+// memcachedKey returns the key if memcached accepts it, otherwise its hash,
+// as keys are limited to 250 bytes without spaces and control characters.
+func memcachedKey(key string) string {
+	valid := len(key) > 0 && len(key) <= maxKeyLength
+	for i := 0; valid && i < len(key); i++ {
+		valid = key[i] > ' ' && key[i] != 0x7f
+	}
+	if valid {
+		return key
+	}
+	sum := sha256.Sum256([]byte(key))
+	return "gb:sha256:" + hex.EncodeToString(sum[:])
+}
+
+// expiration converts ttl to memcached expiration time, rounded up to seconds.
+func expiration(ttl time.Duration) int64 {
+	if ttl <= 0 {
+		return 0
+	}
+	if ttl > maxRelativeExpiration {
+		return time.Now().Add(ttl).Unix()
+	}
+	return int64(math.Ceil(ttl.Seconds()))
+}
@@ -0,0 +1,249 @@
+package memcached
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/growthbook/growthbook-golang"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServer implements get, gets, set, add and cas commands of the memcached text protocol.
+type fakeServer struct {
+	ln     net.Listener
+	mu     sync.Mutex
+	items  map[string]fakeItem
+	cas    uint64
+	expiry map[string]int64
+	// Called before a cas command is processed, e.g. to simulate concurrent updates
+	beforeCas func()
+}
+
+type fakeItem struct {
+	value []byte
+	cas   uint64
+}
+
+func startFakeServer(t *testing.T) *fakeServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	s := &fakeServer{ln: ln, items: map[string]fakeItem{}, expiry: map[string]int64{}}
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(c)
+		}
+	}()
+	t.Cleanup(func() { _ = ln.Close() })
+	return s
+}
+
+func (s *fakeServer) serve(c net.Conn) {
+	defer c.Close()
+	r := bufio.NewReader(c)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			fmt.Fprint(c, "ERROR\r\n")
+			continue
+		}
+		switch cmd := fields[0]; cmd {
+		case "get", "gets":
+			s.mu.Lock()
+			item, ok := s.items[fields[1]]
+			s.mu.Unlock()
+			if ok {
+				fmt.Fprintf(c, "VALUE %s 0 %d %d\r\n%s\r\n", fields[1], len(item.value), item.cas, item.value)
+			}
+			fmt.Fprint(c, "END\r\n")
+		case "set", "add", "cas":
+			size, _ := strconv.Atoi(fields[4])
+			value := make([]byte, size+2)
+			if _, err := io.ReadFull(r, value); err != nil {
+				return
+			}
+			s.mu.Lock()
+			beforeCas := s.beforeCas
+			s.mu.Unlock()
+			if cmd == "cas" && beforeCas != nil {
+				beforeCas()
+			}
+			s.mu.Lock()
+			item, exists := s.items[fields[1]]
+			var res string
+			switch {
+			case cmd == "add" && exists:
+				res = "NOT_STORED"
+			case cmd == "cas" && !exists:
+				res = "NOT_FOUND"
+			case cmd == "cas" && fields[5] != strconv.FormatUint(item.cas, 10):
+				res = "EXISTS"
+			default:
+				s.cas++
+				s.items[fields[1]] = fakeItem{value: value[:size], cas: s.cas}
+				s.expiry[fields[1]], _ = strconv.ParseInt(fields[3], 10, 64)
+				res = "STORED"
+			}
+			s.mu.Unlock()
+			fmt.Fprintf(c, "%s\r\n", res)
+		default:
+			fmt.Fprint(c, "ERROR\r\n")
+		}
+	}
+}
+
+func (s *fakeServer) has(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.items[key]
+	return ok
+}
+
+func (s *fakeServer) expiration(key string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.expiry[key]
+}
+
+func (s *fakeServer) setBeforeCas(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.beforeCas = fn
+}
+
+func (s *fakeServer) set(key string, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cas++
+	s.items[key] = fakeItem{value: []byte(value), cas: s.cas}
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.TODO()
+	server := startFakeServer(t)
+	client := NewClient(server.ln.Addr().String())
+	defer client.Close()
+
+	value, err := client.Get(ctx, "gb:key")
+	require.Nil(t, err)
+	require.Nil(t, value)
+
+	require.Nil(t, client.Set(ctx, "gb:key", []byte("line 1\r\nline 2"), 1500*time.Millisecond))
+	value, err = client.Get(ctx, "gb:key")
+	require.Nil(t, err)
+	require.Equal(t, "line 1\r\nline 2", string(value))
+	require.Equal(t, int64(2), server.expiration("gb:key"))
+
+	ok, err := client.SetNX(ctx, "gb:key", []byte("other"), 0)
+	require.Nil(t, err)
+	require.False(t, ok)
+	ok, err = client.SetNX(ctx, "gb:leader", []byte("me"), 0)
+	require.Nil(t, err)
+	require.True(t, ok)
+
+	// Keys memcached doesn't accept are hashed
+	long := "gb:" + strings.Repeat("x", 300)
+	require.Nil(t, client.Set(ctx, long, []byte("long"), 0))
+	require.Nil(t, client.Set(ctx, "key with spaces", []byte("spaces"), 0))
+	value, err = client.Get(ctx, long)
+	require.Nil(t, err)
+	require.Equal(t, "long", string(value))
+	require.True(t, server.has(memcachedKey(long)))
+	require.True(t, server.has(memcachedKey("key with spaces")))
+	require.Len(t, client.idle, 1)
+
+	// Long expiration is sent as unix time
+	require.Nil(t, client.Set(ctx, "gb:key", []byte("value"), 60*24*time.Hour))
+	require.InDelta(t, time.Now().Add(60*24*time.Hour).Unix(), server.expiration("gb:key"), 5)
+}
+
+func TestClientErrors(t *testing.T) {
+	ctx := context.TODO()
+	server := startFakeServer(t)
+	client := NewClient(server.ln.Addr().String())
+	defer client.Close()
+
+	_, err := client.store(ctx, "unknown", "gb:key", []byte("value"), 0, 0)
+	require.ErrorContains(t, err, "Memcached error")
+	require.Empty(t, client.idle)
+
+	_ = server.ln.Close()
+	client = NewClient(server.ln.Addr().String(), WithTimeout(100*time.Millisecond))
+	_, err = client.Get(ctx, "gb:key")
+	require.NotNil(t, err)
+}
+
+func TestStickyBucketService(t *testing.T) {
+	ctx := context.TODO()
+	server := startFakeServer(t)
+	client := NewClient(server.ln.Addr().String())
+	defer client.Close()
+	s := NewStickyBucketService(client)
+
+	doc, err := s.GetAssignments(ctx, "id", "123")
+	require.Nil(t, err)
+	require.Nil(t, doc)
+
+	require.Nil(t, s.SaveAssignments(ctx, &growthbook.StickyBucketAssignmentDoc{
+		AttributeName: "id", AttributeValue: "123", Assignments: growthbook.StickyBucketAssignments{"exp1__0": "1"},
+	}))
+	require.True(t, server.has("gbStickyBuckets__id||123"))
+
+	// Another instance saves assignment of another experiment while the doc is merged
+	server.setBeforeCas(func() {
+		server.setBeforeCas(nil)
+		server.set("gbStickyBuckets__id||123", `{"attributeName": "id", "attributeValue": "123", "assignments": {"exp1__0": "1", "exp3__0": "0"}}`)
+	})
+	require.Nil(t, s.SaveAssignments(ctx, &growthbook.StickyBucketAssignmentDoc{
+		AttributeName: "id", AttributeValue: "123", Assignments: growthbook.StickyBucketAssignments{"exp1__0": "2", "exp2__0": "0"},
+	}))
+	doc, err = s.GetAssignments(ctx, "id", "123")
+	require.Nil(t, err)
+	require.Equal(t, growthbook.StickyBucketAssignments{"exp1__0": "2", "exp2__0": "0", "exp3__0": "0"}, doc.Assignments)
+
+	// Doc is updated by others on every attempt
+	server.setBeforeCas(func() { server.set("gbStickyBuckets__id||123", `{}`) })
+	err = s.SaveAssignments(ctx, &growthbook.StickyBucketAssignmentDoc{
+		AttributeName: "id", AttributeValue: "123", Assignments: growthbook.StickyBucketAssignments{"exp4__0": "1"},
+	})
+	require.ErrorIs(t, err, ErrConcurrentUpdates)
+}
+
+func TestStickyBucketServiceKeepsAssignment(t *testing.T) {
+	ctx := context.TODO()
+	server := startFakeServer(t)
+	client := NewClient(server.ln.Addr().String())
+	defer client.Close()
+	featuresJSON := `{"exp": {"defaultValue": "control", "rules": [{
+	  "key": "exp", "variations": ["control", "red", "blue"], "meta": [{"key": "0"}, {"key": "1"}, {"key": "2"}], "weights": %s
+	}]}}`
+
+	gb, err := growthbook.NewClient(ctx,
+		growthbook.WithJsonFeatures(fmt.Sprintf(featuresJSON, "[0, 1, 0]")),
+		growthbook.WithAttributes(growthbook.Attributes{"id": "123"}),
+		growthbook.WithStickyBucketService(NewStickyBucketService(client, WithKeyPrefix("test:"))),
+	)
+	require.Nil(t, err)
+	require.Equal(t, "red", gb.EvalFeature(ctx, "exp").Value)
+
+	require.Nil(t, gb.SetJSONFeatures(fmt.Sprintf(featuresJSON, "[0, 0, 1]")))
+	res := gb.EvalFeature(ctx, "exp")
+	require.Equal(t, "red", res.Value)
+	require.True(t, res.ExperimentResult.StickyBucketUsed)
+	require.True(t, server.has("test:id||123"))
+}
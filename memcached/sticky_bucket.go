@@ -0,0 +1,124 @@
+package memcached
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/growthbook/growthbook-golang"
+	"github.com/growthbook/growthbook-golang/eval"
+)
+
+const (
+	defaultStickyBucketPrefix = "gbStickyBuckets__"
+	maxSaveAttempts           = 5
+)
+
+var ErrConcurrentUpdates = errors.New("Too many concurrent updates of sticky bucket assignments")
+
+// StickyBucketService is a [growthbook.StickyBucketService] that keeps assignments docs
+// in memcached as JSON. Saved assignments are merged with ones saved by other instances
+// using check-and-set, so concurrent evaluations don't overwrite each other's assignments.
+// Memcached evicts items under memory pressure, which re-buckets the users, so size
+// the server memory for all docs.
+type StickyBucketService struct {
+	client *Client
+	prefix string
+	ttl    time.Duration
+}
+
+var _ growthbook.StickyBucketService = &StickyBucketService{}
+
+// StickyBucketOption configures [StickyBucketService].
+type StickyBucketOption func(*StickyBucketService)
+
+// WithKeyPrefix sets prefix of memcached keys of the docs. Default "gbStickyBuckets__".
+func WithKeyPrefix(prefix string) StickyBucketOption {
+	return func(s *StickyBucketService) {
+		s.prefix = prefix
+	}
+}
+
+// WithTTL sets expiration of docs, renewed on every save. Default is no expiration.
+func WithTTL(ttl time.Duration) StickyBucketOption {
+	return func(s *StickyBucketService) {
+		s.ttl = ttl
+	}
+}
+
+// NewStickyBucketService creates service that keeps assignments docs with the client.
+func NewStickyBucketService(client *Client, opts ...StickyBucketOption) *StickyBucketService {
+	s := &StickyBucketService{
+		client: client,
+		prefix: defaultStickyBucketPrefix,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *StickyBucketService) GetAssignments(ctx context.Context, attributeName string, attributeValue string) (*growthbook.StickyBucketAssignmentDoc, error) {
+	value, err := s.client.Get(ctx, s.key(attributeName, attributeValue))
+	if err != nil || value == nil {
+		return nil, err
+	}
+	var doc growthbook.StickyBucketAssignmentDoc
+	if err := json.Unmarshal(value, &doc); err != nil {
+		return nil, fmt.Errorf("Error decoding sticky bucket assignments: %w", err)
+	}
+	return &doc, nil
+}
+
+// SaveAssignments merges assignments of the doc into the stored doc, assignments of the doc win.
+func (s *StickyBucketService) SaveAssignments(ctx context.Context, doc *growthbook.StickyBucketAssignmentDoc) error {
+	key := s.key(doc.AttributeName, doc.AttributeValue)
+	for range maxSaveAttempts {
+		value, cas, err := s.client.gets(ctx, key)
+		if err != nil {
+			return err
+		}
+		// Docs that can't be decoded are replaced
+		merged := doc
+		if value != nil {
+			var existing growthbook.StickyBucketAssignmentDoc
+			if err := json.Unmarshal(value, &existing); err == nil {
+				if existing.Assignments == nil {
+					existing.Assignments = growthbook.StickyBucketAssignments{}
+				}
+				assignments := maps.Clone(existing.Assignments)
+				maps.Copy(assignments, doc.Assignments)
+				if maps.Equal(assignments, existing.Assignments) {
+					return nil
+				}
+				merged = &growthbook.StickyBucketAssignmentDoc{
+					AttributeName:  doc.AttributeName,
+					AttributeValue: doc.AttributeValue,
+					Assignments:    assignments,
+				}
+			}
+		}
+		encoded, err := json.Marshal(merged)
+		if err != nil {
+			return err
+		}
+		var stored bool
+		if value == nil {
+			stored, err = s.client.store(ctx, "add", key, encoded, s.ttl, 0)
+		} else {
+			stored, err = s.client.store(ctx, "cas", key, encoded, s.ttl, cas)
+		}
+		if err != nil || stored {
+			return err
+		}
+		// Another instance saved the doc meanwhile, merge with its assignments
+	}
+	return ErrConcurrentUpdates
+}
+
+func (s *StickyBucketService) key(attributeName string, attributeValue string) string {
+	return s.prefix + eval.StickyBucketKey(attributeName, attributeValue)
+}
@@ -0,0 +1,52 @@
+package growthbook
+
+import (
+	"context"
+	"maps"
+
+	"github.com/growthbook/growthbook-golang/hashutil"
+)
+
+// migrationAttribute is the attribute name of sticky bucket docs of migration ids.
+const migrationAttribute = "id"
+
+// MigrationBucket reports whether the id should be served by the new backend of
+// a gradual infrastructure migration, see [hashutil.MigrationBucket]. With sticky
+// bucketing service set, migrated ids are saved and stay migrated when coverage is
+// lowered, e.g. to stop ramping up after an incident without moving data back.
+// Coverage 0 rolls every id back regardless of saved assignments.
+func (client *Client) MigrationBucket(ctx context.Context, key string, id string, coverage float64) bool {
+	if id == "" || coverage <= 0 {
+		return false
+	}
+	service := client.stickyBucketService
+	if service == nil {
+		return hashutil.MigrationBucket(key, id, coverage)
+	}
+
+	assignmentKey := "migration:" + key
+	doc, err := service.GetAssignments(ctx, migrationAttribute, id)
+	if err != nil {
+		client.logger.Warn("Error loading sticky bucket assignments", "id", client.redactor.value(migrationAttribute, id), "error", err)
+	}
+	if doc != nil && doc.Assignments[assignmentKey] == "1" {
+		return true
+	}
+	if !hashutil.MigrationBucket(key, id, coverage) {
+		return false
+	}
+
+	newDoc := &StickyBucketAssignmentDoc{
+		AttributeName:  migrationAttribute,
+		AttributeValue: id,
+		Assignments:    StickyBucketAssignments{},
+	}
+	if doc != nil {
+		maps.Copy(newDoc.Assignments, doc.Assignments)
+	}
+	newDoc.Assignments[assignmentKey] = "1"
+	if err := service.SaveAssignments(ctx, newDoc); err != nil {
+		client.logger.Warn("Error saving sticky bucket assignments", "id", client.redactor.value(migrationAttribute, id), "error", err)
+	}
+	return true
+}
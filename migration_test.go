@@ -0,0 +1,41 @@
+package growthbook
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/growthbook/growthbook-golang/hashutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrationBucket(t *testing.T) {
+	ctx := context.TODO()
+	client, _ := NewClient(ctx, WithJsonFeatures(`{}`))
+	for i := 0; i < 100; i++ {
+		id := strconv.Itoa(i)
+		require.Equal(t, hashutil.MigrationBucket("db", id, 0.3), client.MigrationBucket(ctx, "db", id, 0.3))
+	}
+
+	t.Run("Sticky migrated ids", func(t *testing.T) {
+		service := NewMemoryStickyBucketService()
+		sticky, _ := client.WithStickyBucketService(service)
+		migrated := []string{}
+		for i := 0; i < 100; i++ {
+			id := strconv.Itoa(i)
+			if sticky.MigrationBucket(ctx, "db", id, 0.5) {
+				migrated = append(migrated, id)
+			}
+		}
+		require.NotEmpty(t, migrated)
+		require.Equal(t, len(migrated), service.Len())
+
+		for _, id := range migrated {
+			require.True(t, sticky.MigrationBucket(ctx, "db", id, 0.01))
+			require.False(t, sticky.MigrationBucket(ctx, "db", id, 0))
+			require.False(t, sticky.MigrationBucket(ctx, "other", id, 0.0001))
+		}
+		doc, _ := service.GetAssignments(ctx, "id", migrated[0])
+		require.Equal(t, StickyBucketAssignments{"migration:db": "1"}, doc.Assignments)
+	})
+}
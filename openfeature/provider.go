@@ -0,0 +1,266 @@
+// Package openfeature implements OpenFeature provider API backed by GrowthBook client.
+//
+// Types mirror the provider interface of the OpenFeature Go SDK
+// (github.com/open-feature/go-sdk/openfeature): evaluation methods take a flattened
+// evaluation context and return resolution details with value, reason, variant and
+// error code. The package doesn't depend on the SDK, so registering the provider
+// takes only converting result structs to the SDK types.
+package openfeature
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strconv"
+
+	"github.com/growthbook/growthbook-golang"
+)
+
+// TargetingKey is the evaluation context key of the user identifier.
+// It is passed to GrowthBook as the "id" attribute, unless context sets "id" itself.
+const TargetingKey = "targetingKey"
+
+// FlattenedContext is OpenFeature evaluation context with targeting key and attributes.
+type FlattenedContext map[string]any
+
+// Reason explains why the flag resolved to the value.
+type Reason string
+
+const (
+	TargetingMatchReason Reason = "TARGETING_MATCH"
+	SplitReason          Reason = "SPLIT"
+	DisabledReason       Reason = "DISABLED"
+	DefaultReason        Reason = "DEFAULT"
+	StaticReason         Reason = "STATIC"
+	ErrorReason          Reason = "ERROR"
+)
+
+// ErrorCode identifies kind of the [ResolutionError].
+type ErrorCode string
+
+const (
+	FlagNotFoundCode ErrorCode = "FLAG_NOT_FOUND"
+	TypeMismatchCode ErrorCode = "TYPE_MISMATCH"
+	GeneralCode      ErrorCode = "GENERAL"
+)
+
+// ResolutionError is an error of the flag resolution.
+type ResolutionError struct {
+	Code    ErrorCode
+	Message string
+}
+
+func (e *ResolutionError) Error() string {
+	return string(e.Code) + ": " + e.Message
+}
+
+// FlagMetadata is additional information about the resolution: "source" of the
+// GrowthBook result, "ruleId" and "experimentKey" if any.
+// Variant is the experiment variation key or the rule id.
+type FlagMetadata map[string]any
+
+// ProviderResolutionDetail is resolution details common for all flag types.
+type ProviderResolutionDetail struct {
+	// Not nil if flag failed to resolve and the default value is returned
+	ResolutionError *ResolutionError
+	Reason          Reason
+	Variant         string
+	FlagMetadata    FlagMetadata
+}
+
+type BoolResolutionDetail struct {
+	Value bool
+	ProviderResolutionDetail
+}
+
+type StringResolutionDetail struct {
+	Value string
+	ProviderResolutionDetail
+}
+
+type FloatResolutionDetail struct {
+	Value float64
+	ProviderResolutionDetail
+}
+
+type IntResolutionDetail struct {
+	Value int64
+	ProviderResolutionDetail
+}
+
+type InterfaceResolutionDetail struct {
+	Value any
+	ProviderResolutionDetail
+}
+
+// Metadata describes the provider.
+type Metadata struct {
+	Name string
+}
+
+// Provider resolves OpenFeature flags as GrowthBook features.
+type Provider struct {
+	client *growthbook.Client
+}
+
+// NewProvider creates provider that evaluates features with the client.
+// Evaluation context attributes override client attributes.
+func NewProvider(client *growthbook.Client) *Provider {
+	return &Provider{client}
+}
+
+func (p *Provider) Metadata() Metadata {
+	return Metadata{Name: "GrowthBook"}
+}
+
+func (p *Provider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, evalCtx FlattenedContext) BoolResolutionDetail {
+	value, detail := p.resolve(ctx, flag, evalCtx)
+	res := BoolResolutionDetail{defaultValue, detail}
+	if detail.ResolutionError != nil || value == nil {
+		return res
+	}
+	if v, ok := value.(bool); ok {
+		res.Value = v
+		return res
+	}
+	res.ProviderResolutionDetail = typeMismatch(detail, value, "boolean")
+	return res
+}
+
+func (p *Provider) StringEvaluation(ctx context.Context, flag string, defaultValue string, evalCtx FlattenedContext) StringResolutionDetail {
+	value, detail := p.resolve(ctx, flag, evalCtx)
+	res := StringResolutionDetail{defaultValue, detail}
+	if detail.ResolutionError != nil || value == nil {
+		return res
+	}
+	if v, ok := value.(string); ok {
+		res.Value = v
+		return res
+	}
+	res.ProviderResolutionDetail = typeMismatch(detail, value, "string")
+	return res
+}
+
+func (p *Provider) FloatEvaluation(ctx context.Context, flag string, defaultValue float64, evalCtx FlattenedContext) FloatResolutionDetail {
+	value, detail := p.resolve(ctx, flag, evalCtx)
+	res := FloatResolutionDetail{defaultValue, detail}
+	if detail.ResolutionError != nil || value == nil {
+		return res
+	}
+	switch v := value.(type) {
+	case float64:
+		res.Value = v
+		return res
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			res.Value = f
+			return res
+		}
+	}
+	res.ProviderResolutionDetail = typeMismatch(detail, value, "float")
+	return res
+}
+
+func (p *Provider) IntEvaluation(ctx context.Context, flag string, defaultValue int64, evalCtx FlattenedContext) IntResolutionDetail {
+	value, detail := p.resolve(ctx, flag, evalCtx)
+	res := IntResolutionDetail{defaultValue, detail}
+	if detail.ResolutionError != nil || value == nil {
+		return res
+	}
+	switch v := value.(type) {
+	case float64:
+		if v == math.Trunc(v) && v >= math.MinInt64 && v < math.MaxInt64 {
+			res.Value = int64(v)
+			return res
+		}
+	case json.Number:
+		if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			res.Value = i
+			return res
+		}
+	}
+	res.ProviderResolutionDetail = typeMismatch(detail, value, "integer")
+	return res
+}
+
+func (p *Provider) ObjectEvaluation(ctx context.Context, flag string, defaultValue any, evalCtx FlattenedContext) InterfaceResolutionDetail {
+	value, detail := p.resolve(ctx, flag, evalCtx)
+	res := InterfaceResolutionDetail{defaultValue, detail}
+	if detail.ResolutionError != nil || value == nil {
+		return res
+	}
+	res.Value = value
+	return res
+}
+
+// resolve evaluates the feature with evaluation context attributes.
+func (p *Provider) resolve(ctx context.Context, flag string, evalCtx FlattenedContext) (any, ProviderResolutionDetail) {
+	client := p.client
+	if attrs := attributes(evalCtx); len(attrs) > 0 {
+		var err error
+		client, err = client.WithAttributeOverrides(attrs)
+		if err != nil {
+			return nil, ProviderResolutionDetail{
+				ResolutionError: &ResolutionError{GeneralCode, err.Error()},
+				Reason:          ErrorReason,
+			}
+		}
+	}
+
+	res := client.EvalFeature(ctx, flag)
+	detail := ProviderResolutionDetail{FlagMetadata: FlagMetadata{"source": string(res.Source)}}
+	if res.RuleId != "" {
+		detail.FlagMetadata["ruleId"] = res.RuleId
+		detail.Variant = res.RuleId
+	}
+	if res.Experiment != nil && res.ExperimentResult != nil {
+		detail.FlagMetadata["experimentKey"] = res.Experiment.Key
+		detail.Variant = res.ExperimentResult.Key
+	}
+
+	switch res.Source {
+	case growthbook.UnknownFeatureResultSource:
+		detail.ResolutionError = &ResolutionError{FlagNotFoundCode, "feature " + strconv.Quote(flag) + " not found"}
+		detail.Reason = ErrorReason
+	case growthbook.CyclicPrerequisiteResultSource:
+		detail.ResolutionError = &ResolutionError{GeneralCode, "cyclic prerequisite of feature " + strconv.Quote(flag)}
+		detail.Reason = ErrorReason
+	case growthbook.StalePayloadResultSource:
+		detail.ResolutionError = &ResolutionError{GeneralCode, "features payload of flag " + strconv.Quote(flag) + " is stale"}
+		detail.Reason = ErrorReason
+	case growthbook.ForceResultSource:
+		detail.Reason = TargetingMatchReason
+	case growthbook.ExperimentResultSource:
+		detail.Reason = SplitReason
+	case growthbook.OverrideResultSource:
+		detail.Reason = StaticReason
+	case growthbook.PrerequisiteResultSource:
+		detail.Reason = DisabledReason
+	default:
+		detail.Reason = DefaultReason
+	}
+	return res.Value, detail
+}
+
+// attributes converts evaluation context into GrowthBook attributes.
+func attributes(evalCtx FlattenedContext) growthbook.Attributes {
+	attrs := make(growthbook.Attributes, len(evalCtx))
+	for k, v := range evalCtx {
+		if k != TargetingKey {
+			attrs[k] = v
+		}
+	}
+	if key, ok := evalCtx[TargetingKey]; ok {
+		if _, ok := attrs["id"]; !ok {
+			attrs["id"] = key
+		}
+	}
+	return attrs
+}
+
+func typeMismatch(detail ProviderResolutionDetail, value any, expected string) ProviderResolutionDetail {
+	data, _ := json.Marshal(value)
+	detail.ResolutionError = &ResolutionError{TypeMismatchCode, "value " + string(data) + " is not " + expected}
+	detail.Reason = ErrorReason
+	return detail
+}
@@ -0,0 +1,64 @@
+package openfeature
+
+import (
+	"context"
+	"testing"
+
+	"github.com/growthbook/growthbook-golang"
+	"github.com/stretchr/testify/require"
+)
+
+const featuresJson = `{
+  "banner": {"defaultValue": false, "rules": [{"id": "us", "condition": {"country": "US"}, "force": true}]},
+  "color": {"defaultValue": "red", "rules": [{"key": "color-exp", "variations": ["blue", "green"], "weights": [0, 1]}]},
+  "limit": {"defaultValue": 10},
+  "ratio": {"defaultValue": 0.5},
+  "config": {"defaultValue": {"a": 1}}
+}`
+
+func TestProvider(t *testing.T) {
+	ctx := context.TODO()
+	client, err := growthbook.NewClient(ctx, growthbook.WithJsonFeatures(featuresJson))
+	require.Nil(t, err)
+	p := NewProvider(client)
+	require.Equal(t, "GrowthBook", p.Metadata().Name)
+
+	b := p.BooleanEvaluation(ctx, "banner", false, FlattenedContext{TargetingKey: "1", "country": "US"})
+	require.True(t, b.Value)
+	require.Equal(t, TargetingMatchReason, b.Reason)
+	require.Equal(t, "us", b.Variant)
+
+	b = p.BooleanEvaluation(ctx, "banner", true, FlattenedContext{"country": "CA"})
+	require.False(t, b.Value)
+	require.Equal(t, DefaultReason, b.Reason)
+	require.Nil(t, b.ResolutionError)
+
+	s := p.StringEvaluation(ctx, "color", "none", FlattenedContext{TargetingKey: "1"})
+	require.Equal(t, "green", s.Value)
+	require.Equal(t, SplitReason, s.Reason)
+	require.Equal(t, "1", s.Variant)
+	require.Equal(t, "color-exp", s.FlagMetadata["experimentKey"])
+
+	i := p.IntEvaluation(ctx, "limit", 0, nil)
+	require.Equal(t, int64(10), i.Value)
+	f := p.FloatEvaluation(ctx, "ratio", 0, nil)
+	require.Equal(t, 0.5, f.Value)
+	o := p.ObjectEvaluation(ctx, "config", nil, nil)
+	require.Equal(t, map[string]any{"a": 1.0}, o.Value)
+
+	i = p.IntEvaluation(ctx, "ratio", 7, nil)
+	require.Equal(t, int64(7), i.Value)
+	require.Equal(t, TypeMismatchCode, i.ResolutionError.Code)
+	require.Equal(t, ErrorReason, i.Reason)
+
+	s = p.StringEvaluation(ctx, "missing", "def", nil)
+	require.Equal(t, "def", s.Value)
+	require.Equal(t, FlagNotFoundCode, s.ResolutionError.Code)
+}
+
+func TestAttributes(t *testing.T) {
+	require.Equal(t, growthbook.Attributes{"id": "1", "country": "US"},
+		attributes(FlattenedContext{TargetingKey: "1", "country": "US"}))
+	require.Equal(t, growthbook.Attributes{"id": "2"},
+		attributes(FlattenedContext{TargetingKey: "1", "id": "2"}))
+}
@@ -0,0 +1,117 @@
+package growthbook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/growthbook/growthbook-golang/eval"
+)
+
+// ErrPayloadType is returned in strict payload types mode for payload fields given
+// as strings instead of numbers.
+var ErrPayloadType = errors.New("Payload field has wrong type")
+
+// WithStrictPayloadTypes rejects payloads with coverage or weights of rules given
+// as strings, e.g. "0.5" from older exports, with [ErrPayloadType]. By default
+// such numeric strings are converted with a warning.
+func WithStrictPayloadTypes(strict bool) ClientOption {
+	return func(c *Client) error {
+		c.data.strictJson = strict
+		return nil
+	}
+}
+
+// ParseExperiment decodes experiment from JSON with the same number handling and
+// coercion of coverage and weights as the features payload.
+func (client *Client) ParseExperiment(data []byte) (*Experiment, error) {
+	var exp Experiment
+	if err := client.unmarshalPayload(data, &exp); err != nil {
+		return nil, err
+	}
+	return &exp, nil
+}
+
+// unmarshalPayload decodes features payload, features map or experiment. If decoding
+// fails on a string, numeric strings of coverage and weights are converted to numbers.
+func (client *Client) unmarshalPayload(data []byte, v any) error {
+	err := client.data.unmarshal(data, v)
+	var typeErr *json.UnmarshalTypeError
+	if err == nil || !errors.As(err, &typeErr) || typeErr.Value != "string" {
+		return err
+	}
+	coerced, fields, cerr := coercePayload(data, v)
+	if cerr != nil || len(fields) == 0 {
+		return err
+	}
+	if client.data.strictJson {
+		return fmt.Errorf("%w: %s is a string", ErrPayloadType, fields[0])
+	}
+	for _, field := range fields {
+		client.logger.Warn("Payload number is given as string, converted", "field", field)
+	}
+	// Maps decoded partially before the error would be merged with the new ones
+	switch v := v.(type) {
+	case *FeatureApiResponse:
+		v.Features, v.SavedGroups = nil, nil
+	case *FeatureMap:
+		*v = nil
+	case *Experiment:
+		*v = Experiment{}
+	}
+	return client.data.unmarshal(coerced, v)
+}
+
+// coercePayload converts numeric strings in rules of the features payload or map,
+// or in the experiment. Returns converted JSON and paths of the converted fields.
+func coercePayload(data []byte, v any) ([]byte, []string, error) {
+	var root map[string]any
+	if err := unmarshalUseNumber(data, &root); err != nil {
+		return nil, nil, err
+	}
+	var fields []string
+	coerce := func(prefix string, obj map[string]any) error {
+		coerced, err := eval.CoerceNumbers(obj)
+		for _, field := range coerced {
+			fields = append(fields, prefix+field)
+		}
+		return err
+	}
+	coerceFeatures := func(prefix string, features map[string]any) error {
+		keys := make([]string, 0, len(features))
+		for key := range features {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			feature, _ := features[key].(map[string]any)
+			rules, _ := feature["rules"].([]any)
+			for i, rule := range rules {
+				if obj, ok := rule.(map[string]any); ok {
+					if err := coerce(prefix+strconv.Quote(key)+".rules["+strconv.Itoa(i)+"].", obj); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	}
+
+	var err error
+	switch v.(type) {
+	case *FeatureApiResponse:
+		features, _ := root["features"].(map[string]any)
+		err = coerceFeatures("features.", features)
+	case *FeatureMap:
+		err = coerceFeatures("", root)
+	case *Experiment:
+		err = coerce("", root)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	res, err := json.Marshal(root)
+	return res, fields, err
+}
@@ -0,0 +1,43 @@
+package growthbook
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayloadTypesCoercion(t *testing.T) {
+	featuresJSON := `{
+		"rollout": {"defaultValue": false, "rules": [{"force": true, "coverage": "0.5"}]},
+		"exp": {"defaultValue": 0, "rules": [{"key": "exp", "variations": [0, 1], "weights": ["0.2", 0.8]}]},
+		"text": {"defaultValue": {"coverage": "0.5"}}
+	}`
+	var logs bytes.Buffer
+	client, err := NewClient(context.TODO(), WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+	require.Nil(t, err)
+	require.Nil(t, client.SetJSONFeatures(featuresJSON))
+
+	features := client.Features()
+	require.Equal(t, 0.5, *features["rollout"].Rules[0].Coverage)
+	require.Equal(t, []float64{0.2, 0.8}, features["exp"].Rules[0].Weights)
+	require.Equal(t, map[string]any{"coverage": "0.5"}, features["text"].DefaultValue)
+	require.Contains(t, logs.String(), `field="\"exp\".rules[0].weights[0]"`)
+	require.Contains(t, logs.String(), `field="\"rollout\".rules[0].coverage"`)
+
+	exp, err := client.ParseExperiment([]byte(`{"key": "exp", "variations": [0, 1], "coverage": "1", "weights": [0.5, "0.5"]}`))
+	require.Nil(t, err)
+	require.Equal(t, 1.0, *exp.Coverage)
+	require.Equal(t, []float64{0.5, 0.5}, exp.Weights)
+
+	_, err = client.ParseExperiment([]byte(`{"key": "exp", "coverage": "half"}`))
+	require.NotNil(t, err)
+
+	strict, err := NewClient(context.TODO(), WithStrictPayloadTypes(true))
+	require.Nil(t, err)
+	require.ErrorIs(t, strict.SetJSONFeatures(featuresJSON), ErrPayloadType)
+	_, err = strict.ParseExperiment([]byte(`{"key": "exp", "coverage": "1"}`))
+	require.ErrorIs(t, err, ErrPayloadType)
+}
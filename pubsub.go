@@ -0,0 +1,207 @@
+package growthbook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const publishTimeout = 10 * time.Second
+
+// MessageBus is a publish/subscribe transport between processes, e.g. NATS or Kafka.
+// Implementations adapt the client library of the bus to this interface.
+type MessageBus interface {
+	// Publish sends message to all subscribers of the topic.
+	Publish(ctx context.Context, topic string, msg []byte) error
+	// Subscribe calls handler with every message published to the topic until unsubscribe
+	// is called. Buses that retain the last message of the topic, like NATS JetStream or
+	// compacted Kafka topics, should deliver it to the new subscriber first.
+	Subscribe(ctx context.Context, topic string, handler func(msg []byte)) (unsubscribe func(), err error)
+}
+
+type payloadPublisher struct {
+	bus   MessageBus
+	topic string
+	mu    sync.Mutex
+	last  []byte
+}
+
+// WithPayloadPublisher publishes payload to the topic of the bus every time the data source
+// loads a changed one, including the first load. Messages carry the API response as is, so
+// encrypted payloads stay encrypted. Pair with [WithSubscriberDataSource] to fetch payload
+// in one process of the cluster and push it to the others.
+func WithPayloadPublisher(bus MessageBus, topic string) ClientOption {
+	return func(c *Client) error {
+		if bus == nil {
+			return errors.New("Payload publisher requires message bus")
+		}
+		c.publisher = &payloadPublisher{bus: bus, topic: topic}
+		return nil
+	}
+}
+
+func (p *payloadPublisher) publish(client *Client, resp *FeatureApiResponse) {
+	msg := resp.body
+	if msg == nil {
+		var err error
+		msg, err = json.Marshal(resp)
+		if err != nil {
+			client.logger.Error("Error encoding published payload", "error", err)
+			return
+		}
+	}
+	p.mu.Lock()
+	if bytes.Equal(p.last, msg) {
+		p.mu.Unlock()
+		return
+	}
+	p.last = msg
+	p.mu.Unlock()
+
+	// Subscribers ignore payloads older than the current one, so order of delivery doesn't matter
+	client.data.spawn(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+		defer cancel()
+		if err := p.bus.Publish(ctx, p.topic, msg); err != nil {
+			client.logger.Error("Error publishing payload", "topic", p.topic, "error", err)
+		}
+	})
+}
+
+// SubscriberDataSource loads payloads published by [WithPayloadPublisher] from the message bus
+// instead of GrowthBook API.
+type SubscriberDataSource struct {
+	client *Client
+	logger *slog.Logger
+	bus    MessageBus
+	topic  string
+	lifecycle
+}
+
+// WithSubscriberDataSource sets data source that receives payloads from the topic of the bus.
+// It starts once the first payload arrives, so the bus should retain the last message of the
+// topic, otherwise the client waits for the next payload change.
+func WithSubscriberDataSource(bus MessageBus, topic string) ClientOption {
+	return func(c *Client) error {
+		if bus == nil {
+			return errors.New("Subscriber data source requires message bus")
+		}
+		c.data.dsFactory = func(c *Client) DataSource {
+			return newSubscriberDataSource(c, bus, topic)
+		}
+		return nil
+	}
+}
+
+func newSubscriberDataSource(client *Client, bus MessageBus, topic string) *SubscriberDataSource {
+	return &SubscriberDataSource{
+		client: client,
+		bus:    bus,
+		topic:  topic,
+		logger: client.logger.With("source", "Growthbook subscriber datasource", "topic", topic),
+	}
+}
+
+func (ds *SubscriberDataSource) Start(ctx context.Context) error {
+	ctx, ok, err := ds.begin(ctx)
+	if !ok {
+		return err
+	}
+	ds.logger.Info("Starting")
+
+	loaded := make(chan struct{})
+	var once sync.Once
+	unsubscribe, err := ds.bus.Subscribe(ctx, ds.topic, func(msg []byte) {
+		if err := ds.client.updateFromApiResponseJSON(ctx, string(msg)); err != nil {
+			ds.logger.Error("Error applying published payload", "error", err)
+			return
+		}
+		once.Do(func() { close(loaded) })
+	})
+	if err != nil {
+		ds.end()
+		return err
+	}
+	ds.client.data.spawn(func() {
+		<-ctx.Done()
+		unsubscribe()
+	})
+
+	select {
+	case <-loaded:
+	case <-ctx.Done():
+		ds.end()
+		return ctx.Err()
+	}
+	ds.logger.Info("First load finished")
+
+	if err := ds.run(); err != nil {
+		return err
+	}
+	ds.logger.Info("Started")
+	return nil
+}
+
+func (ds *SubscriberDataSource) Close() error {
+	if ds.end() {
+		ds.logger.Info("Closing")
+	}
+	return nil
+}
+
+// MemoryBus is [MessageBus] within a single process, e.g. for tests or to share payload
+// between clients of the same service. It retains the last message of every topic.
+type MemoryBus struct {
+	mu       sync.Mutex
+	last     map[string][]byte
+	handlers map[string]map[int]func([]byte)
+	nextId   int
+}
+
+// NewMemoryBus creates empty in-memory message bus.
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{
+		last:     map[string][]byte{},
+		handlers: map[string]map[int]func([]byte){},
+	}
+}
+
+// Publish synchronously calls handlers subscribed to the topic.
+func (b *MemoryBus) Publish(ctx context.Context, topic string, msg []byte) error {
+	b.mu.Lock()
+	b.last[topic] = msg
+	handlers := make([]func([]byte), 0, len(b.handlers[topic]))
+	for _, h := range b.handlers[topic] {
+		handlers = append(handlers, h)
+	}
+	b.mu.Unlock()
+	for _, h := range handlers {
+		h(msg)
+	}
+	return nil
+}
+
+// Subscribe calls handler with the last message of the topic, if any, and then with every new one.
+func (b *MemoryBus) Subscribe(ctx context.Context, topic string, handler func([]byte)) (func(), error) {
+	b.mu.Lock()
+	id := b.nextId
+	b.nextId++
+	if b.handlers[topic] == nil {
+		b.handlers[topic] = map[int]func([]byte){}
+	}
+	b.handlers[topic][id] = handler
+	last := b.last[topic]
+	b.mu.Unlock()
+	if last != nil {
+		handler(last)
+	}
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.handlers[topic], id)
+	}, nil
+}
@@ -0,0 +1,70 @@
+package growthbook
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type countingBus struct {
+	*MemoryBus
+	published atomic.Int32
+}
+
+func (b *countingBus) Publish(ctx context.Context, topic string, msg []byte) error {
+	b.published.Add(1)
+	return b.MemoryBus.Publish(ctx, topic, msg)
+}
+
+func TestPayloadPubSub(t *testing.T) {
+	bus := &countingBus{MemoryBus: NewMemoryBus()}
+	logger, _ := testLogger(slog.LevelError, t)
+	publisher, err := NewClient(context.TODO(), WithLogger(logger), WithPayloadPublisher(bus, "features"))
+	require.Nil(t, err)
+	defer publisher.Close()
+
+	payload := `{"features": {"a": {"defaultValue": 1}}, "dateUpdated": "2024-01-01T00:00:00Z"}`
+	require.Nil(t, publisher.UpdateFromApiResponseJSON(payload))
+	require.Nil(t, publisher.UpdateFromApiResponseJSON(payload))
+	require.Eventually(t, func() bool { return bus.published.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+	// Subscriber started after the first publish gets the retained payload
+	subscriber, err := NewClient(context.TODO(), WithLogger(logger), WithSubscriberDataSource(bus, "features"))
+	require.Nil(t, err)
+	defer subscriber.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.Nil(t, subscriber.EnsureLoaded(ctx))
+	require.Equal(t, 1.0, subscriber.EvalFeature(context.TODO(), "a").Value)
+	require.Equal(t, "subscriber", subscriber.EffectiveConfig().DataSource)
+
+	require.Nil(t, publisher.UpdateFromApiResponseJSON(`{"features": {"a": {"defaultValue": 2}}, "dateUpdated": "2024-01-02T00:00:00Z"}`))
+	require.Eventually(t, func() bool {
+		return subscriber.EvalFeature(context.TODO(), "a").Value == 2.0
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, int32(2), bus.published.Load())
+}
+
+func TestSubscriberDataSourceWaitsForPayload(t *testing.T) {
+	bus := NewMemoryBus()
+	logger, _ := testLogger(slog.LevelError, t)
+	client, err := NewClient(context.TODO(), WithLogger(logger), WithSubscriberDataSource(bus, "features"))
+	require.Nil(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, client.EnsureLoaded(ctx), context.DeadlineExceeded)
+
+	// Invalid payloads are skipped
+	require.Nil(t, bus.Publish(context.TODO(), "features", []byte(`not json`)))
+	require.Nil(t, bus.Publish(context.TODO(), "features", []byte(`{"features": {"a": {"defaultValue": true}}}`)))
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.Nil(t, client.EnsureLoaded(ctx))
+	require.True(t, client.EvalFeature(context.TODO(), "a").On)
+}
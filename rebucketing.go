@@ -0,0 +1,168 @@
+package growthbook
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/growthbook/growthbook-golang/hashutil"
+)
+
+// RebucketReason is the kind of experiment change that re-buckets existing users.
+type RebucketReason string
+
+const (
+	// Variation weights or number of variations changed
+	WeightsRebucketReason RebucketReason = "weights"
+	// Explicit variation ranges moved
+	RangesRebucketReason RebucketReason = "ranges"
+	// Namespace or its range changed
+	NamespaceRebucketReason RebucketReason = "namespace"
+	// Seed, hash attribute or hash version changed
+	HashRebucketReason RebucketReason = "hash"
+)
+
+// RebucketWarning reports experiment change of a payload update that moves existing users
+// between variations, or in and out of the experiment, without a bucketVersion bump or
+// a new phase. Such changes silently corrupt experiment analysis.
+type RebucketWarning struct {
+	FeatureKey    string
+	ExperimentKey string
+	Reason        RebucketReason
+	// Old and new values, e.g. "[0.5 0.5] -> [0.8 0.2]"
+	Detail string
+}
+
+// RebucketCallback is executed when a payload update re-buckets users of experiments.
+type RebucketCallback func(ctx context.Context, warnings []RebucketWarning)
+
+// WithRebucketCallback sets callback executed when a payload update changes weights, ranges,
+// namespaces or hashing of running experiments without bumping bucketVersion or starting
+// a new phase. Warnings are logged regardless of the callback.
+func WithRebucketCallback(cb RebucketCallback) ClientOption {
+	return func(c *Client) error {
+		c.rebucketCallback = cb
+		return nil
+	}
+}
+
+// checkRebucketing logs and reports experiment changes of the payload update that re-bucket users.
+func (client *Client) checkRebucketing(ctx context.Context, old FeatureMap, new FeatureMap) {
+	// Initial load is not a change
+	if old == nil {
+		return
+	}
+	warnings := rebucketWarnings(old, new)
+	for _, w := range warnings {
+		client.logger.WarnContext(ctx, "Payload update re-buckets existing users of the experiment. Bump bucketVersion or start a new phase.",
+			"feature", w.FeatureKey, "experiment", w.ExperimentKey, "reason", w.Reason, "change", w.Detail)
+	}
+	if len(warnings) > 0 && client.rebucketCallback != nil {
+		client.callback(ctx, "rebucket", func() { client.rebucketCallback(ctx, warnings) })
+	}
+}
+
+// rebucketWarnings compares experiment rules of features present in both payloads,
+// matched by experiment key, sorted by feature and experiment key.
+func rebucketWarnings(old FeatureMap, new FeatureMap) []RebucketWarning {
+	var res []RebucketWarning
+	for _, key := range sortedKeys(new) {
+		newF, oldF := new[key], old[key]
+		if newF == nil || oldF == nil || newF == oldF {
+			continue
+		}
+		oldRules, newRules := experimentRules(key, oldF), experimentRules(key, newF)
+		for _, expKey := range sortedKeys(newRules) {
+			oldRule, ok := oldRules[expKey]
+			if !ok {
+				continue
+			}
+			for _, w := range compareExperimentRules(oldRule, newRules[expKey]) {
+				w.FeatureKey, w.ExperimentKey = key, expKey
+				res = append(res, w)
+			}
+		}
+	}
+	return res
+}
+
+func experimentRules(featureKey string, f *Feature) map[string]*FeatureRule {
+	rules := map[string]*FeatureRule{}
+	for i := range f.Rules {
+		rule := &f.Rules[i]
+		if len(rule.Variations) == 0 {
+			continue
+		}
+		expKey := rule.Key
+		if expKey == "" {
+			expKey = featureKey
+		}
+		rules[expKey] = rule
+	}
+	return rules
+}
+
+// compareExperimentRules returns warnings without feature and experiment keys. Coverage
+// changes are fine, as ranges grow and shrink without moving users between variations.
+func compareExperimentRules(old *FeatureRule, new *FeatureRule) []RebucketWarning {
+	// Re-bucketing is intended
+	if new.BucketVersion != old.BucketVersion || new.Phase != old.Phase {
+		return nil
+	}
+	var res []RebucketWarning
+	add := func(reason RebucketReason, old any, new any) {
+		res = append(res, RebucketWarning{Reason: reason, Detail: fmt.Sprintf("%v -> %v", old, new)})
+	}
+
+	oldWeights, newWeights := ruleWeights(old), ruleWeights(new)
+	if !slices.Equal(oldWeights, newWeights) {
+		add(WeightsRebucketReason, oldWeights, newWeights)
+	} else if rangesMoved(old.Ranges, new.Ranges) {
+		add(RangesRebucketReason, old.Ranges, new.Ranges)
+	}
+
+	if !namespaceEqual(old.Namespace, new.Namespace) {
+		add(NamespaceRebucketReason, namespaceString(old.Namespace), namespaceString(new.Namespace))
+	}
+
+	if oldHash, newHash := hashInputs(old), hashInputs(new); oldHash != newHash {
+		add(HashRebucketReason, oldHash, newHash)
+	}
+	return res
+}
+
+// hashInputs describes rule settings users are hashed with, defaults filled in.
+func hashInputs(rule *FeatureRule) string {
+	return fmt.Sprintf("seed=%q hashAttribute=%q hashVersion=%d", rule.Seed, cmp.Or(rule.HashAttribute, "id"), max(rule.HashVersion, 1))
+}
+
+// ruleWeights returns weights of the rule variations, equal if not set.
+func ruleWeights(rule *FeatureRule) []float64 {
+	if len(rule.Weights) == len(rule.Variations) {
+		return rule.Weights
+	}
+	return hashutil.GetEqualWeights(len(rule.Variations))
+}
+
+// rangesMoved reports whether explicit ranges changed besides their ends, which only change with coverage.
+func rangesMoved(old []BucketRange, new []BucketRange) bool {
+	if len(old) == 0 || len(new) == 0 {
+		return len(old) != len(new)
+	}
+	return !slices.EqualFunc(old, new, func(a, b BucketRange) bool { return a.Min == b.Min })
+}
+
+func namespaceEqual(a *Namespace, b *Namespace) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func namespaceString(ns *Namespace) string {
+	if ns == nil {
+		return "none"
+	}
+	return fmt.Sprintf("%s [%v, %v)", ns.Id, ns.Start, ns.End)
+}
@@ -0,0 +1,54 @@
+package growthbook
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebucketWarnings(t *testing.T) {
+	exp := func(rule string) string {
+		return `{"features": {"f": {"defaultValue": 0, "rules": [{"key": "exp", "variations": [0, 1]` + rule + `}]}}}`
+	}
+	logger, logs := testLogger(slog.LevelWarn, t)
+	var warnings []RebucketWarning
+	client, err := NewClient(context.TODO(), WithLogger(logger), WithRebucketCallback(func(ctx context.Context, w []RebucketWarning) {
+		warnings = append(warnings, w...)
+	}))
+	require.Nil(t, err)
+
+	require.Nil(t, client.UpdateFromApiResponseJSON(exp(`, "weights": [0.5, 0.5], "coverage": 0.5`)))
+	// Coverage changes and explicit default hash attribute keep assignments
+	require.Nil(t, client.UpdateFromApiResponseJSON(exp(`, "weights": [0.5, 0.5], "coverage": 1, "hashAttribute": "id"`)))
+	require.Empty(t, warnings)
+	require.Empty(t, *logs)
+
+	require.Nil(t, client.UpdateFromApiResponseJSON(exp(`, "weights": [0.8, 0.2], "namespace": ["ns", 0, 0.5]`)))
+	require.Equal(t, []RebucketWarning{
+		{FeatureKey: "f", ExperimentKey: "exp", Reason: WeightsRebucketReason, Detail: "[0.5 0.5] -> [0.8 0.2]"},
+		{FeatureKey: "f", ExperimentKey: "exp", Reason: NamespaceRebucketReason, Detail: "none -> ns [0, 0.5)"},
+	}, warnings)
+	require.Len(t, *logs, 2)
+
+	// Bucket version bump re-buckets intentionally
+	warnings = nil
+	require.Nil(t, client.UpdateFromApiResponseJSON(exp(`, "weights": [0.5, 0.5], "bucketVersion": 1`)))
+	require.Empty(t, warnings)
+
+	require.Nil(t, client.UpdateFromApiResponseJSON(exp(`, "weights": [0.5, 0.5], "bucketVersion": 1, "seed": "new", "hashVersion": 2`)))
+	require.Len(t, warnings, 1)
+	require.Equal(t, HashRebucketReason, warnings[0].Reason)
+}
+
+func TestRebucketWarningsRanges(t *testing.T) {
+	rule := func(r0 BucketRange, r1 BucketRange) *FeatureRule {
+		return &FeatureRule{Variations: []FeatureValue{0, 1}, Ranges: []BucketRange{r0, r1}}
+	}
+	r := func(min float64, max float64) BucketRange { return BucketRange{Min: min, Max: max} }
+	require.Empty(t, compareExperimentRules(rule(r(0, 0.3), r(0.5, 0.8)), rule(r(0, 0.5), r(0.5, 1))))
+	warnings := compareExperimentRules(rule(r(0, 0.5), r(0.5, 1)), rule(r(0, 0.4), r(0.4, 1)))
+	require.Len(t, warnings, 1)
+	require.Equal(t, RangesRebucketReason, warnings[0].Reason)
+}
@@ -0,0 +1,149 @@
+package growthbook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+)
+
+// RedactedValue replaces values of sensitive attributes, unless they are hashed.
+const RedactedValue = "[REDACTED]"
+
+// redactor replaces values of sensitive attributes leaving the SDK.
+type redactor struct {
+	attributes map[string]bool
+	hashKey    []byte
+}
+
+// WithSensitiveAttributes sets attributes, like "email" or "ssn", whose values are
+// redacted from debug logs, DevTools events, experiment records and payloads of
+// callbacks: experiment, feature usage, exposure enrichment, subscriber and
+// consistency callbacks. Results returned by evaluation methods and evaluation
+// itself keep raw values. Nested attributes are set as dot-separated paths.
+// Values are replaced with [RedactedValue], see [WithSensitiveAttributeHashKey]
+// to hash them instead.
+func WithSensitiveAttributes(attributes ...string) ClientOption {
+	return func(c *Client) error {
+		r := c.redactor.clone()
+		for _, attr := range attributes {
+			r.attributes[attr] = true
+		}
+		c.redactor = r
+		return nil
+	}
+}
+
+// WithSensitiveAttributeHashKey makes sensitive attributes replaced with hex-encoded
+// HMAC-SHA256 of their values with the key, so analytics events of the same user
+// can still be joined. Keep the key secret: hashes of guessable values, like emails,
+// can be reversed by brute force without it.
+func WithSensitiveAttributeHashKey(key []byte) ClientOption {
+	return func(c *Client) error {
+		if len(key) == 0 {
+			return fmt.Errorf("Sensitive attribute hash key must not be empty")
+		}
+		r := c.redactor.clone()
+		r.hashKey = key
+		c.redactor = r
+		return nil
+	}
+}
+
+func (r *redactor) clone() *redactor {
+	if r == nil {
+		return &redactor{attributes: map[string]bool{}}
+	}
+	return &redactor{attributes: maps.Clone(r.attributes), hashKey: r.hashKey}
+}
+
+func (r *redactor) enabled() bool {
+	return r != nil && len(r.attributes) > 0
+}
+
+// value returns replacement of the attribute value, or the value itself if the attribute isn't sensitive.
+func (r *redactor) value(attribute string, value string) string {
+	if !r.enabled() || !r.attributes[attribute] || value == "" {
+		return value
+	}
+	if r.hashKey == nil {
+		return RedactedValue
+	}
+	mac := hmac.New(sha256.New, r.hashKey)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// experimentResult returns copy of the result with redacted hash value.
+func (r *redactor) experimentResult(res *ExperimentResult) *ExperimentResult {
+	if res == nil || !r.enabled() || !r.attributes[res.HashAttribute] || res.HashValue == "" {
+		return res
+	}
+	redacted := *res
+	redacted.HashValue = r.value(res.HashAttribute, res.HashValue)
+	return &redacted
+}
+
+// featureResult returns copy of the result with redacted experiment results.
+func (r *redactor) featureResult(res *FeatureResult) *FeatureResult {
+	if res == nil || !r.enabled() {
+		return res
+	}
+	redacted := *res
+	redacted.ExperimentResult = r.experimentResult(res.ExperimentResult)
+	if len(res.Passthrough) > 0 {
+		redacted.Passthrough = make([]PassthroughAssignment, len(res.Passthrough))
+		for i, p := range res.Passthrough {
+			redacted.Passthrough[i] = PassthroughAssignment{Experiment: p.Experiment, ExperimentResult: r.experimentResult(p.ExperimentResult)}
+		}
+	}
+	return &redacted
+}
+
+// attrs returns copy of the attributes with redacted values, nested maps are copied along the paths.
+func (r *redactor) attrs(attrs Attributes) Attributes {
+	if !r.enabled() {
+		return attrs
+	}
+	res := maps.Clone(attrs)
+	for path := range r.attributes {
+		r.redactPath(res, path, strings.Split(path, "."))
+	}
+	return res
+}
+
+func (r *redactor) redactPath(obj map[string]any, attribute string, path []string) {
+	v, ok := obj[path[0]]
+	if !ok || v == nil {
+		return
+	}
+	if len(path) == 1 {
+		s, isString := v.(string)
+		if !isString {
+			// JSON keeps numbers as written, e.g. 123456789 instead of 1.23456789e+08
+			data, err := json.Marshal(v)
+			if err != nil {
+				data = []byte(fmt.Sprint(v))
+			}
+			s = string(data)
+		}
+		obj[path[0]] = r.value(attribute, s)
+		return
+	}
+	if nested, ok := v.(map[string]any); ok {
+		nested = maps.Clone(nested)
+		obj[path[0]] = nested
+		r.redactPath(nested, attribute, path[1:])
+	}
+}
+
+// redactAttribute is passed to the evaluator, nil without sensitive attributes.
+func (client *Client) redactAttribute() func(string, string) string {
+	if !client.redactor.enabled() {
+		return nil
+	}
+	return client.redactor.value
+}
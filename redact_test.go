@@ -0,0 +1,99 @@
+package growthbook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type failingStickyBucketService struct {
+	calls *atomic.Int32
+}
+
+func (s failingStickyBucketService) GetAssignments(context.Context, string, string) (*StickyBucketAssignmentDoc, error) {
+	if s.calls != nil {
+		s.calls.Add(1)
+	}
+	return nil, errors.New("unavailable")
+}
+
+func (failingStickyBucketService) SaveAssignments(context.Context, *StickyBucketAssignmentDoc) error {
+	return errors.New("unavailable")
+}
+
+func TestSensitiveAttributes(t *testing.T) {
+	ctx := context.TODO()
+	var logs bytes.Buffer
+	var tracked, used []string
+	client, err := NewClient(ctx,
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+		WithJsonFeatures(`{"feature": {"defaultValue": 0, "rules": [{"key": "exp", "hashAttribute": "email", "variations": [0, 1]}]}}`),
+		WithAttributes(Attributes{"email": "jane@example.com", "user": map[string]any{"ssn": 123456789, "plan": "pro"}}),
+		WithSensitiveAttributes("email", "user.ssn"),
+		WithStickyBucketService(failingStickyBucketService{}),
+		WithExperimentCallback(func(_ context.Context, _ *Experiment, res *ExperimentResult, _ any) {
+			tracked = append(tracked, res.HashValue)
+		}),
+		WithFeatureUsageCallback(func(_ context.Context, _ string, res *FeatureResult, _ any) {
+			used = append(used, res.ExperimentResult.HashValue)
+		}),
+		WithDevMode(true),
+	)
+	require.Nil(t, err)
+
+	res := client.EvalFeature(ctx, "feature")
+	require.Equal(t, "jane@example.com", res.ExperimentResult.HashValue)
+	require.Equal(t, []string{RedactedValue}, tracked)
+	require.Equal(t, []string{RedactedValue}, used)
+	require.NotContains(t, logs.String(), "jane@example.com")
+	require.Contains(t, logs.String(), "email||"+RedactedValue)
+
+	event := client.DevToolsEvent()
+	require.Equal(t, RedactedValue, event.Logs[0].Result.(*FeatureResult).ExperimentResult.HashValue)
+	require.Equal(t, Attributes{
+		"email": RedactedValue,
+		"user":  map[string]any{"ssn": RedactedValue, "plan": "pro"},
+	}, event.SdkInfo.Attributes)
+	require.Equal(t, "jane@example.com", client.Attributes()["email"])
+	require.Equal(t, 123456789.0, client.Attributes()["user"].(map[string]any)["ssn"])
+
+	t.Run("Hashed values", func(t *testing.T) {
+		tracked = nil
+		hashed, err := client.cloneWith(WithSensitiveAttributeHashKey([]byte("secret")))
+		require.Nil(t, err)
+		hashed.EvalFeature(ctx, "feature")
+		hashed.EvalFeature(ctx, "feature")
+		require.Len(t, tracked, 2)
+		require.Len(t, tracked[0], 64)
+		require.Equal(t, tracked[0], tracked[1])
+		require.NotEqual(t, "jane@example.com", tracked[0])
+
+		_, err = client.cloneWith(WithSensitiveAttributeHashKey(nil))
+		require.NotNil(t, err)
+	})
+}
+
+func TestSensitiveAttributesStickyBucketDocCached(t *testing.T) {
+	ctx := context.TODO()
+	var calls atomic.Int32
+	client, err := NewClient(ctx,
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithJsonFeatures(`{"feature": {"defaultValue": 0, "rules": [
+		  {"key": "exp1", "hashAttribute": "email", "condition": {"country": "CA"}, "variations": [0, 1]},
+		  {"key": "exp2", "hashAttribute": "email", "condition": {"country": "CA"}, "variations": [0, 1]}
+		]}}`),
+		WithAttributes(Attributes{"email": "jane@example.com", "country": "US"}),
+		WithSensitiveAttributes("email"),
+		WithStickyBucketService(failingStickyBucketService{&calls}),
+	)
+	require.Nil(t, err)
+
+	client.EvalFeature(ctx, "feature")
+	require.Equal(t, int32(1), calls.Load())
+}
@@ -0,0 +1,52 @@
+package growthbook
+
+import (
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+const defaultRetryBackoff = 100 * time.Millisecond
+
+// RetryPolicy configures retries of features API requests made by data sources,
+// lightweight mode and [Client.CallFeatureApi]. Requests are idempotent GETs, so they
+// are retried on connection errors, timeouts and 429 or 5xx responses. Invalid payloads
+// aren't retried. Zero policy makes a single attempt.
+type RetryPolicy struct {
+	// Retries after the first attempt
+	MaxRetries int
+	// Timeout of every attempt. Zero means only the HTTP client timeout applies.
+	PerTryTimeout time.Duration
+	// Total time of all attempts and delays between them. Zero means no limit
+	// besides the context. Retries that can't start within the budget are skipped.
+	Budget time.Duration
+	// Delay before the first retry, doubled for every next one, with jitter. Default 100ms.
+	Backoff time.Duration
+}
+
+// WithRetryPolicy sets retry policy of features API requests.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		if policy.MaxRetries < 0 || policy.PerTryTimeout < 0 || policy.Budget < 0 || policy.Backoff < 0 {
+			return errors.New("Retry policy values must not be negative")
+		}
+		if policy.Backoff == 0 {
+			policy.Backoff = defaultRetryBackoff
+		}
+		c.data.retry = policy
+		return nil
+	}
+}
+
+func (d *data) getRetryPolicy() RetryPolicy {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.retry
+}
+
+// retryDelay returns delay before the retry with the number, starting from 0:
+// exponential backoff with up to half of it randomized.
+func (p RetryPolicy) retryDelay(retry int) time.Duration {
+	backoff := p.Backoff << min(retry, 16)
+	return backoff/2 + rand.N(backoff/2+1)
+}
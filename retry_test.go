@@ -0,0 +1,83 @@
+package growthbook
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	ctx := context.TODO()
+	var attempts atomic.Int32
+	var failures, status atomic.Int32
+	var delay atomic.Int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) <= failures.Load() {
+			time.Sleep(time.Duration(delay.Load()))
+			w.WriteHeader(int(status.Load()))
+			return
+		}
+		_, _ = w.Write([]byte(`{"features": {"a": {"defaultValue": 1}}}`))
+	}))
+	defer ts.Close()
+
+	newClient := func(policy RetryPolicy) *Client {
+		logger, _ := testLogger(slog.LevelError, t)
+		client, err := NewClient(ctx, WithLogger(logger), WithHttpClient(ts.Client()), WithApiHost(ts.URL),
+			WithClientKey("somekey"), WithRetryPolicy(policy))
+		require.Nil(t, err)
+		return client
+	}
+	reset := func(f int32, s int32, d time.Duration) {
+		attempts.Store(0)
+		failures.Store(f)
+		status.Store(s)
+		delay.Store(int64(d))
+	}
+
+	reset(2, http.StatusServiceUnavailable, 0)
+	resp, err := newClient(RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond}).CallFeatureApi(ctx, "")
+	require.Nil(t, err)
+	require.Equal(t, http.StatusOK, resp.Status)
+	require.Equal(t, int32(3), attempts.Load())
+
+	reset(3, http.StatusTooManyRequests, 0)
+	_, err = newClient(RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond}).CallFeatureApi(ctx, "")
+	require.ErrorContains(t, err, "code: 429")
+	require.Equal(t, int32(3), attempts.Load())
+
+	// Client errors aren't retried
+	reset(1, http.StatusNotFound, 0)
+	_, err = newClient(RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond}).CallFeatureApi(ctx, "")
+	require.ErrorContains(t, err, "code: 404")
+	require.Equal(t, int32(1), attempts.Load())
+
+	// Slow attempts time out and are retried
+	reset(1, http.StatusOK, 200*time.Millisecond)
+	resp, err = newClient(RetryPolicy{MaxRetries: 1, PerTryTimeout: 50 * time.Millisecond, Backoff: time.Millisecond}).CallFeatureApi(ctx, "")
+	require.Nil(t, err)
+	require.Equal(t, int32(2), attempts.Load())
+
+	// Retries that don't fit into the budget are skipped
+	reset(10, http.StatusInternalServerError, 0)
+	start := time.Now()
+	_, err = newClient(RetryPolicy{MaxRetries: 10, Budget: 100 * time.Millisecond, Backoff: 40 * time.Millisecond}).CallFeatureApi(ctx, "")
+	require.Error(t, err)
+	require.Less(t, time.Since(start), 100*time.Millisecond)
+	require.Less(t, attempts.Load(), int32(4))
+
+	// No retries by default
+	reset(1, http.StatusBadGateway, 0)
+	_, err = newClient(RetryPolicy{}).CallFeatureApi(ctx, "")
+	require.Error(t, err)
+	require.Equal(t, int32(1), attempts.Load())
+
+	_, err = NewClient(ctx, WithRetryPolicy(RetryPolicy{MaxRetries: -1}))
+	require.Error(t, err)
+}
@@ -0,0 +1,63 @@
+package growthbook
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// RollbackPolicy defines how API responses older than the current data are handled.
+// dateUpdated moves backwards when a payload is restored in the GrowthBook UI,
+// as well as when a stale CDN node or replica serves an outdated payload.
+type RollbackPolicy string
+
+const (
+	// RejectRollback ignores older responses with a warning. Default policy.
+	RejectRollback RollbackPolicy = "reject"
+	// WarnRollback applies older responses with a warning.
+	WarnRollback RollbackPolicy = "warn"
+	// ChangedRollback applies older responses with a warning if their features or
+	// saved groups differ from the current ones, and ignores replays of the same payload.
+	ChangedRollback RollbackPolicy = "changed"
+)
+
+// WithRollbackPolicy sets how API responses with dateUpdated older than the current
+// data are handled. Shared with child clients.
+func WithRollbackPolicy(policy RollbackPolicy) ClientOption {
+	return func(c *Client) error {
+		switch policy {
+		case RejectRollback, WarnRollback, ChangedRollback:
+		default:
+			return fmt.Errorf("Unknown rollback policy %q", policy)
+		}
+		c.data.rollback = policy
+		return nil
+	}
+}
+
+// ForceUpdateFromApiResponse updates shared data from Growthbook API response
+// regardless of its dateUpdated, e.g. to roll back to a restored payload.
+func (client *Client) ForceUpdateFromApiResponse(resp *FeatureApiResponse) error {
+	return client.applyApiResponse(context.Background(), resp, true)
+}
+
+// acceptRollback reports whether the response older than the current data is applied.
+func (client *Client) acceptRollback(resp *FeatureApiResponse, features FeatureMap) bool {
+	d := client.data
+	d.mu.RLock()
+	policy := d.rollback
+	dataUpdated := d.dateUpdated
+	changed := policy == ChangedRollback &&
+		(!reflect.DeepEqual(d.features, features) || !reflect.DeepEqual(d.savedGroups, resp.SavedGroups))
+	d.mu.RUnlock()
+	switch {
+	case policy == WarnRollback, policy == ChangedRollback && changed:
+		client.logger.Warn("Api response is older then current data, rolling back",
+			"dataUpdated", dataUpdated, "apiUpdated", resp.DateUpdated)
+		return true
+	default:
+		client.logger.Warn("Api response is older then current data, refuse to update",
+			"dataUpdated", dataUpdated, "apiUdpated", resp.DateUpdated)
+		return false
+	}
+}
@@ -0,0 +1,68 @@
+package growthbook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientRollbackPolicy(t *testing.T) {
+	published := time.Date(2024, time.May, 2, 0, 0, 0, 0, time.UTC)
+	current := &FeatureApiResponse{
+		Features:    FeatureMap{"foo": {DefaultValue: "new"}},
+		DateUpdated: published,
+	}
+	restored := &FeatureApiResponse{
+		Features:    FeatureMap{"foo": {DefaultValue: "old"}},
+		DateUpdated: published.Add(-time.Hour),
+	}
+	replayed := &FeatureApiResponse{
+		Features:    FeatureMap{"foo": {DefaultValue: "new"}},
+		DateUpdated: published.Add(-time.Hour),
+	}
+
+	newClient := func(opts ...ClientOption) *Client {
+		client, err := NewClient(context.TODO(), opts...)
+		require.Nil(t, err)
+		require.Nil(t, client.UpdateFromApiResponse(current))
+		return client
+	}
+	value := func(client *Client) any {
+		return client.EvalFeature(context.TODO(), "foo").Value
+	}
+
+	t.Run("rejects older response by default", func(t *testing.T) {
+		client := newClient()
+		require.Nil(t, client.UpdateFromApiResponse(restored))
+		require.Equal(t, "new", value(client))
+		require.Equal(t, published, client.data.getDateUpdated())
+	})
+
+	t.Run("applies older response with warn policy", func(t *testing.T) {
+		client := newClient(WithRollbackPolicy(WarnRollback))
+		require.Nil(t, client.UpdateFromApiResponse(restored))
+		require.Equal(t, "old", value(client))
+		require.Equal(t, restored.DateUpdated, client.data.getDateUpdated())
+	})
+
+	t.Run("applies older response only if it differs with changed policy", func(t *testing.T) {
+		client := newClient(WithRollbackPolicy(ChangedRollback))
+		require.Nil(t, client.UpdateFromApiResponse(replayed))
+		require.Equal(t, published, client.data.getDateUpdated())
+		require.Nil(t, client.UpdateFromApiResponse(restored))
+		require.Equal(t, "old", value(client))
+	})
+
+	t.Run("forced update ignores dateUpdated", func(t *testing.T) {
+		client := newClient()
+		require.Nil(t, client.ForceUpdateFromApiResponse(restored))
+		require.Equal(t, "old", value(client))
+	})
+
+	t.Run("unknown policy", func(t *testing.T) {
+		_, err := NewClient(context.TODO(), WithRollbackPolicy("accept"))
+		require.NotNil(t, err)
+	})
+}
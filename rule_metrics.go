@@ -0,0 +1,80 @@
+package growthbook
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// RuleMetric counts evaluations of the feature resolved by the rule.
+type RuleMetric struct {
+	FeatureKey string
+	// Id of the matched rule, empty if no rule matched
+	RuleId string
+	Source FeatureResultSource
+	Count  uint64
+}
+
+// RuleMetricsCallback is called on every feature evaluation with the matched rule,
+// e.g. to increment a counter labeled by feature key, rule id and source.
+type RuleMetricsCallback func(ctx context.Context, featureKey string, ruleId string, source FeatureResultSource)
+
+type ruleMetricKey struct {
+	feature string
+	rule    string
+	source  FeatureResultSource
+}
+
+// ruleMetrics counts evaluations by matched rule. Shared between a client and its child clients.
+type ruleMetrics struct {
+	callback RuleMetricsCallback
+	// ruleMetricKey to *atomic.Uint64
+	counts sync.Map
+}
+
+// WithRuleMetrics enables counters of feature evaluations by matched rule id and result source,
+// reported by [Client.RuleMetrics], to verify what fraction of traffic hits each rule, e.g.
+// that a ramp actually changed behavior. Callback, if not nil, is called on every evaluation.
+func WithRuleMetrics(cb RuleMetricsCallback) ClientOption {
+	return func(c *Client) error {
+		c.ruleMetrics = &ruleMetrics{callback: cb}
+		return nil
+	}
+}
+
+func (client *Client) recordRule(ctx context.Context, key string, res *FeatureResult) {
+	m := client.ruleMetrics
+	if m == nil {
+		return
+	}
+	k := ruleMetricKey{key, res.RuleId, res.Source}
+	v, ok := m.counts.Load(k)
+	if !ok {
+		v, _ = m.counts.LoadOrStore(k, &atomic.Uint64{})
+	}
+	v.(*atomic.Uint64).Add(1)
+	if m.callback != nil {
+		client.callback(ctx, "ruleMetrics", func() { m.callback(ctx, key, res.RuleId, res.Source) })
+	}
+}
+
+// RuleMetrics returns evaluation counters of the client and its child clients since creation,
+// sorted by feature key, rule id and source, or nil unless [WithRuleMetrics] is set.
+func (client *Client) RuleMetrics() []RuleMetric {
+	m := client.ruleMetrics
+	if m == nil {
+		return nil
+	}
+	var res []RuleMetric
+	m.counts.Range(func(k, v any) bool {
+		key := k.(ruleMetricKey)
+		res = append(res, RuleMetric{key.feature, key.rule, key.source, v.(*atomic.Uint64).Load()})
+		return true
+	})
+	slices.SortFunc(res, func(a, b RuleMetric) int {
+		return cmp.Or(cmp.Compare(a.FeatureKey, b.FeatureKey), cmp.Compare(a.RuleId, b.RuleId), cmp.Compare(a.Source, b.Source))
+	})
+	return res
+}
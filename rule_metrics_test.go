@@ -0,0 +1,38 @@
+package growthbook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleMetrics(t *testing.T) {
+	ctx := context.TODO()
+	var calls int
+	client, err := NewClient(ctx,
+		WithJsonFeatures(`{"flag": {"defaultValue": false, "rules": [{"id": "beta", "condition": {"beta": true}, "force": true}]}}`),
+		WithRuleMetrics(func(_ context.Context, featureKey string, ruleId string, source FeatureResultSource) {
+			calls++
+		}),
+	)
+	require.Nil(t, err)
+	client.EvalFeature(ctx, "flag")
+	beta, err := client.WithAttributes(Attributes{"beta": true})
+	require.Nil(t, err)
+	beta.EvalFeature(ctx, "flag")
+	beta.EvalFeature(ctx, "flag")
+	beta.EvalFeature(ctx, "missing")
+
+	require.Equal(t, []RuleMetric{
+		{FeatureKey: "flag", RuleId: "", Source: DefaultValueResultSource, Count: 1},
+		{FeatureKey: "flag", RuleId: "beta", Source: ForceResultSource, Count: 2},
+		{FeatureKey: "missing", RuleId: "", Source: UnknownFeatureResultSource, Count: 1},
+	}, client.RuleMetrics())
+	require.Equal(t, 4, calls)
+	require.Contains(t, client.EffectiveConfig().Callbacks, "ruleMetrics")
+
+	plain, err := NewClient(ctx)
+	require.Nil(t, err)
+	require.Nil(t, plain.RuleMetrics())
+}
@@ -0,0 +1,98 @@
+package growthbook
+
+import (
+	"context"
+	"time"
+)
+
+// RunWhenEnabled runs job in a goroutine while the feature is on for the client attributes.
+// When the feature turns off, job context is canceled and the next start waits for
+// the job to return. A job that returns by itself isn't restarted until the feature
+// turns off and on again. Changes are applied after the value stays the same for
+// debounce, the initial value is applied immediately. The returned function stops
+// watching the feature, cancels the job and waits for it to return, as well as
+// canceling ctx does.
+func (client *Client) RunWhenEnabled(ctx context.Context, key string, debounce time.Duration, job func(ctx context.Context)) func() {
+	ctx, cancel := context.WithCancel(ctx)
+	results, stopWatch := client.WatchFeature(ctx, key, nil)
+	done := make(chan struct{})
+
+	client.data.spawn(func() {
+		defer close(done)
+		defer stopWatch()
+
+		var jobCancel context.CancelFunc
+		var jobDone chan struct{}
+		startJob := func() {
+			client.logger.Info("Feature is on, starting job", "key", key)
+			var jobCtx context.Context
+			jobCtx, jobCancel = context.WithCancel(ctx)
+			jobDone = make(chan struct{})
+			client.data.spawn(func() {
+				defer close(jobDone)
+				client.callback(jobCtx, "job", func() { job(jobCtx) })
+			})
+		}
+		stopJob := func() {
+			client.logger.Info("Feature is off, stopping job", "key", key)
+			jobCancel()
+			<-jobDone
+			jobCancel = nil
+		}
+		defer func() {
+			if jobCancel != nil {
+				stopJob()
+			}
+		}()
+
+		on, initial := false, true
+		apply := func() {
+			running := jobCancel != nil
+			if on && !running {
+				startJob()
+			} else if !on && running {
+				stopJob()
+			}
+		}
+
+		var timer *time.Timer
+		var timerC <-chan time.Time
+		for {
+			select {
+			case res, ok := <-results:
+				if !ok {
+					return
+				}
+				on = res.On
+				if initial || debounce <= 0 {
+					initial = false
+					apply()
+					continue
+				}
+				if timer == nil {
+					timer = time.NewTimer(debounce)
+					defer timer.Stop()
+				} else {
+					if !timer.Stop() {
+						select {
+						case <-timer.C:
+						default:
+						}
+					}
+					timer.Reset(debounce)
+				}
+				timerC = timer.C
+			case <-timerC:
+				timerC = nil
+				apply()
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
@@ -0,0 +1,41 @@
+package growthbook
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientRunWhenEnabled(t *testing.T) {
+	ctx := context.TODO()
+	client, err := NewClient(ctx, WithJsonFeatures(`{"job": {"defaultValue": true}}`))
+	require.Nil(t, err)
+
+	var running, starts atomic.Int32
+	stop := client.RunWhenEnabled(ctx, "job", 20*time.Millisecond, func(ctx context.Context) {
+		starts.Add(1)
+		running.Add(1)
+		defer running.Add(-1)
+		<-ctx.Done()
+	})
+	require.Eventually(t, func() bool { return running.Load() == 1 }, time.Second, time.Millisecond)
+
+	// Flip that doesn't last for debounce period is ignored
+	client.SetFeature("job", &Feature{DefaultValue: false})
+	client.SetFeature("job", &Feature{DefaultValue: true})
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, int32(1), starts.Load())
+
+	client.SetFeature("job", &Feature{DefaultValue: false})
+	require.Eventually(t, func() bool { return running.Load() == 0 }, time.Second, time.Millisecond)
+
+	client.SetFeature("job", &Feature{DefaultValue: true})
+	require.Eventually(t, func() bool { return running.Load() == 1 }, time.Second, time.Millisecond)
+	require.Equal(t, int32(2), starts.Load())
+
+	stop()
+	require.Equal(t, int32(0), running.Load())
+}
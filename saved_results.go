@@ -0,0 +1,45 @@
+package growthbook
+
+import (
+	"maps"
+	"sync"
+)
+
+// ExperimentAssignment is an experiment together with the result assigned by the client.
+type ExperimentAssignment struct {
+	Experiment *Experiment
+	Result     *ExperimentResult
+}
+
+// savedResults keeps the latest assignment per experiment key for a single client instance.
+type savedResults struct {
+	mu      sync.RWMutex
+	results map[string]ExperimentAssignment
+}
+
+func newSavedResults() *savedResults {
+	return &savedResults{results: map[string]ExperimentAssignment{}}
+}
+
+func (r *savedResults) save(exp *Experiment, res *ExperimentResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results[exp.Key] = ExperimentAssignment{exp, res}
+}
+
+// GetAllResults returns the latest assignments made by this client instance,
+// keyed by experiment key. Child clients keep their own results.
+func (client *Client) GetAllResults() map[string]ExperimentAssignment {
+	r := client.results
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return maps.Clone(r.results)
+}
+
+// ClearSavedResults forgets assignments returned by [Client.GetAllResults].
+func (client *Client) ClearSavedResults() {
+	r := client.results
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clear(r.results)
+}
@@ -0,0 +1,40 @@
+package growthbook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedResults(t *testing.T) {
+	ctx := context.TODO()
+	client, _ := NewClient(ctx,
+		WithAttributes(Attributes{"id": "1"}),
+		WithJsonFeatures(`{"feature": {"defaultValue": 0, "rules": [{
+          "key": "feature-exp",
+          "variations": [0, 1],
+          "meta": [{"key": "control", "name": "Control"}, {"key": "treatment", "name": "Treatment"}]
+        }]}}`),
+	)
+	exp := &Experiment{Key: "my-test", Variations: []FeatureValue{0, 1}}
+
+	expRes := client.RunExperiment(ctx, exp)
+	featureRes := client.EvalFeature(ctx, "feature")
+
+	results := client.GetAllResults()
+	require.Len(t, results, 2)
+	require.Equal(t, ExperimentAssignment{exp, expRes}, results["my-test"])
+	require.Equal(t, featureRes.ExperimentResult, results["feature-exp"].Result)
+	require.Len(t, results["feature-exp"].Experiment.Meta, 2)
+
+	child, _ := client.WithAttributes(Attributes{"id": "2"})
+	require.Empty(t, child.GetAllResults())
+	child.RunExperiment(ctx, exp)
+	require.Len(t, child.GetAllResults(), 1)
+	require.Len(t, client.GetAllResults(), 2)
+
+	client.ClearSavedResults()
+	require.Empty(t, client.GetAllResults())
+	require.Len(t, child.GetAllResults(), 1)
+}
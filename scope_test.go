@@ -0,0 +1,103 @@
+package growthbook
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScope(t *testing.T) {
+	ctx := context.TODO()
+	features := FeatureMap{"f": NewFeature(false).WithRules(NewFeatureRule().WithCondition(MustCondition(map[string]any{"country": "US"})).WithForce(true))}
+	client, err := NewClient(ctx, WithFeatures(features))
+	require.Nil(t, err)
+
+	s, err := client.AcquireScope(Attributes{"id": "1", "country": "US"})
+	require.Nil(t, err)
+	require.True(t, s.Client().EvalFeature(ctx, "f").On)
+	child, err := s.Client().WithGroups(map[string]bool{"beta": true})
+	require.Nil(t, err)
+	require.Equal(t, int64(1), client.Stats().ScopesInUse)
+	client.ReleaseScope(s)
+	require.Equal(t, int64(0), client.Stats().ScopesInUse)
+
+	// Child clients don't share attributes with released scopes
+	require.Equal(t, Attributes{"id": "1", "country": "US"}, child.Attributes())
+	require.True(t, child.EvalFeature(ctx, "f").On)
+
+	s, err = client.AcquireScope(Attributes{"id": "2"})
+	require.Nil(t, err)
+	require.Equal(t, Attributes{"id": "2"}, s.Client().Attributes())
+	require.False(t, s.Client().EvalFeature(ctx, "f").On)
+	require.Empty(t, s.Client().GetAllResults())
+	client.ReleaseScope(s)
+	// The race detector makes the pool drop scopes randomly
+	require.NotZero(t, client.Stats().ScopesAllocated)
+}
+
+func TestScopeReleasedTwice(t *testing.T) {
+	logger, logs := testLogger(slog.LevelWarn, t)
+	client, err := NewClient(context.TODO(), WithLogger(logger))
+	require.Nil(t, err)
+
+	s, err := client.AcquireScope(Attributes{"id": "1"})
+	require.Nil(t, err)
+	client.ReleaseScope(s)
+	client.ReleaseScope(s)
+	require.Equal(t, int64(0), client.Stats().ScopesInUse)
+	require.Len(t, *logs, 1)
+	require.Equal(t, "WARN", (*logs)[0].Level)
+
+	// The scope is pooled once, so requests don't share it
+	s1, err := client.AcquireScope(Attributes{"id": "1"})
+	require.Nil(t, err)
+	s2, err := client.AcquireScope(Attributes{"id": "2"})
+	require.Nil(t, err)
+	require.NotSame(t, s1, s2)
+	require.Equal(t, Attributes{"id": "1"}, s1.Client().Attributes())
+	client.ReleaseScope(s1)
+	client.ReleaseScope(s2)
+	require.Len(t, *logs, 1)
+}
+
+func TestScopeStrictAttributes(t *testing.T) {
+	client, err := NewClient(context.TODO(), WithStrictAttributes(true))
+	require.Nil(t, err)
+	_, err = client.AcquireScope(Attributes{"fn": func() {}})
+	require.ErrorIs(t, err, ErrUnsupportedAttribute)
+	require.Equal(t, int64(0), client.Stats().ScopesInUse)
+}
+
+// Simulates a service creating a child client per request from many goroutines.
+func BenchmarkScope(b *testing.B) {
+	attrs := Attributes{"country": "US", "plan": "pro", "tags": []any{"a", "b"}}
+	for i := 0; i < 20; i++ {
+		attrs["attr"+strconv.Itoa(i)] = i
+	}
+	features := FeatureMap{"f": NewFeature(false).WithRules(NewFeatureRule().WithCondition(MustCondition(map[string]any{"country": "US"})).WithForce(true))}
+	client, err := NewClient(context.TODO(), WithFeatures(features))
+	require.Nil(b, err)
+
+	b.Run("child", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				child, _ := client.WithAttributes(attrs)
+				child.EvalFeature(context.TODO(), "f")
+			}
+		})
+	})
+	b.Run("scope", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				s, _ := client.AcquireScope(attrs)
+				s.Client().EvalFeature(context.TODO(), "f")
+				client.ReleaseScope(s)
+			}
+		})
+	})
+}
@@ -0,0 +1,104 @@
+package growthbook
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// errFlightWait is returned to callers that stopped waiting for the in-flight call.
+var errFlightWait = errors.New("Timeout waiting for in-flight call")
+
+// FetchStats counts API requests coalesced by the client, see [ClientStats.Fetches].
+type FetchStats struct {
+	// Calls that made an HTTP request
+	Fetches int64
+	// Calls that shared the result of a request already in flight
+	Coalesced int64
+	// Calls that stopped waiting for the request in flight and used current data,
+	// see [WithCoalescedFetchTimeout]
+	StaleFallbacks int64
+}
+
+// flightGroup coalesces concurrent calls with the same key into a single call,
+// like golang.org/x/sync/singleflight, without the extra dependency.
+type flightGroup[T any] struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall[T]
+	stats map[string]*FetchStats
+}
+
+type flightCall[T any] struct {
+	done chan struct{}
+	val  T
+	err  error
+}
+
+// do executes f once for all concurrent callers with the same key and returns its result
+// to all of them. The call runs with the context of the first caller, while the other
+// callers stop waiting when their own context is done, or after maxWait if it's positive
+// with errFlightWait. Calls are counted by the stats key.
+func (g *flightGroup[T]) do(
+	ctx context.Context,
+	key string,
+	statsKey string,
+	maxWait time.Duration,
+	f func(context.Context) (T, error),
+) (T, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = map[string]*flightCall[T]{}
+		g.stats = map[string]*FetchStats{}
+	}
+	stats := g.stats[statsKey]
+	if stats == nil {
+		stats = &FetchStats{}
+		g.stats[statsKey] = stats
+	}
+	if call, ok := g.calls[key]; ok {
+		stats.Coalesced++
+		g.mu.Unlock()
+		var timeout <-chan time.Time
+		if maxWait > 0 {
+			timer := time.NewTimer(maxWait)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+		var zero T
+		select {
+		case <-call.done:
+			return call.val, call.err
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		case <-timeout:
+			g.mu.Lock()
+			stats.StaleFallbacks++
+			g.mu.Unlock()
+			return zero, errFlightWait
+		}
+	}
+	stats.Fetches++
+	call := &flightCall[T]{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.val, call.err = f(ctx)
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(call.done)
+	return call.val, call.err
+}
+
+// snapshot returns copy of the stats by key.
+func (g *flightGroup[T]) snapshot() map[string]FetchStats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	res := make(map[string]FetchStats, len(g.stats))
+	for key, stats := range g.stats {
+		res[key] = *stats
+	}
+	return res
+}
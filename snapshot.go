@@ -0,0 +1,44 @@
+package growthbook
+
+import (
+	"context"
+
+	"github.com/growthbook/growthbook-golang/internal/condition"
+)
+
+// snapshotKey binds pinned snapshot to the shared data of a client and its child clients.
+type snapshotKey struct {
+	d *data
+}
+
+type snapshot struct {
+	features    FeatureMap
+	savedGroups condition.SavedGroups
+}
+
+// PinSnapshot returns context that pins current features and saved groups.
+// All evaluations made with the returned context by the client and its child
+// clients use the same snapshot, even if the data source updates features
+// in between. Updates received after pinning become visible to new contexts only.
+// Pinning already pinned context keeps the original snapshot.
+func (client *Client) PinSnapshot(ctx context.Context) context.Context {
+	key := snapshotKey{client.data}
+	if _, ok := ctx.Value(key).(*snapshot); ok {
+		return ctx
+	}
+	client.data.mu.RLock()
+	s := snapshot{
+		features:    client.data.features,
+		savedGroups: client.data.savedGroups,
+	}
+	client.data.mu.RUnlock()
+	return context.WithValue(ctx, key, &s)
+}
+
+func (client *Client) pinnedSnapshot(ctx context.Context) (*snapshot, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	s, ok := ctx.Value(snapshotKey{client.data}).(*snapshot)
+	return s, ok
+}
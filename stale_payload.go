@@ -0,0 +1,107 @@
+package growthbook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/growthbook/growthbook-golang/eval"
+)
+
+// StalePayloadPolicy defines how evaluations behave once the features payload
+// is older than the max age, see [WithMaxPayloadAge].
+type StalePayloadPolicy string
+
+const (
+	// WarnStalePayload keeps evaluating the payload, with a warning.
+	WarnStalePayload StalePayloadPolicy = "warn"
+	// DefaultValuesStalePayload skips feature rules, so features evaluate to their
+	// default values without targeting, rollouts or experiments.
+	DefaultValuesStalePayload StalePayloadPolicy = "default-values"
+	// ErrorStalePayload makes features of the payload evaluate to nil with
+	// [StalePayloadResultSource], so callers fall back to their own defaults.
+	ErrorStalePayload StalePayloadPolicy = "error"
+)
+
+// StalePayloadCallback is executed once the payload becomes older than the max age.
+type StalePayloadCallback func(ctx context.Context, age time.Duration, policy StalePayloadPolicy)
+
+// WithMaxPayloadAge guards against serving old targeting, e.g. from a wedged SSE
+// connection. Payload age is the time since features were last loaded or confirmed
+// unchanged by the API, the stream or the shared cache. Once it exceeds maxAge,
+// evaluations follow the policy until features are refreshed. The SSE data source
+// revalidates features in the background while the payload is older than half of
+// maxAge, so a quiet stream doesn't make it stale. Shared with child clients.
+func WithMaxPayloadAge(maxAge time.Duration, policy StalePayloadPolicy) ClientOption {
+	return func(c *Client) error {
+		if maxAge <= 0 {
+			return errors.New("Max payload age must be positive")
+		}
+		switch policy {
+		case WarnStalePayload, DefaultValuesStalePayload, ErrorStalePayload:
+		default:
+			return fmt.Errorf("Unknown stale payload policy %q", policy)
+		}
+		c.data.maxAge, c.data.agePolicy = maxAge, policy
+		return nil
+	}
+}
+
+// WithStalePayloadCallback sets callback executed once the payload becomes older than
+// the max age set by [WithMaxPayloadAge]. It is executed again only after the payload
+// is refreshed and becomes stale once more.
+func WithStalePayloadCallback(cb StalePayloadCallback) ClientOption {
+	return func(c *Client) error {
+		c.stalePayloadCallback = cb
+		return nil
+	}
+}
+
+// PayloadAge returns time since features were last loaded or confirmed unchanged.
+func (client *Client) PayloadAge() time.Duration {
+	return time.Since(time.Unix(0, client.data.refreshed.Load()))
+}
+
+// markRefreshed resets the payload age when features are loaded or confirmed unchanged.
+func (client *Client) markRefreshed() {
+	client.data.refreshed.Store(time.Now().UnixNano())
+	if client.data.stale.CompareAndSwap(true, false) {
+		client.logger.Info("Features payload is fresh again")
+	}
+}
+
+// applyStalePolicy switches evaluation behavior if the payload is older than the max age.
+func (client *Client) applyStalePolicy(ctx context.Context, opts *eval.Options) {
+	d := client.data
+	d.mu.RLock()
+	maxAge, policy := d.maxAge, d.agePolicy
+	d.mu.RUnlock()
+	if maxAge <= 0 {
+		return
+	}
+	age := client.PayloadAge()
+	if age <= maxAge {
+		return
+	}
+	if d.stale.CompareAndSwap(false, true) {
+		client.logger.Warn("Features payload is older than max age", "age", age, "maxAge", maxAge, "policy", policy)
+		if client.stalePayloadCallback != nil {
+			client.callback(ctx, "stalePayload", func() { client.stalePayloadCallback(ctx, age, policy) })
+		}
+	}
+	switch policy {
+	case DefaultValuesStalePayload:
+		opts.DefaultValuesOnly = true
+	case ErrorStalePayload:
+		opts.StalePayload = true
+	}
+}
+
+// revalidateInterval returns how often the SSE data source checks the payload age,
+// zero if max age isn't set.
+func (d *data) revalidateInterval() time.Duration {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.maxAge / 2
+}
@@ -0,0 +1,103 @@
+package growthbook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxPayloadAge(t *testing.T) {
+	ctx := context.TODO()
+	featuresJson := `{"feature": {"defaultValue": 0, "rules": [{"force": 1}]}}`
+	age := func(client *Client, d time.Duration) {
+		client.data.refreshed.Store(time.Now().Add(-d).UnixNano())
+	}
+
+	t.Run("Warn", func(t *testing.T) {
+		var ages []time.Duration
+		client, err := NewClient(ctx,
+			WithJsonFeatures(featuresJson),
+			WithMaxPayloadAge(time.Hour, WarnStalePayload),
+			WithStalePayloadCallback(func(_ context.Context, age time.Duration, policy StalePayloadPolicy) {
+				require.Equal(t, WarnStalePayload, policy)
+				ages = append(ages, age)
+			}),
+		)
+		require.Nil(t, err)
+		require.Less(t, client.PayloadAge(), time.Minute)
+		require.Equal(t, 1.0, client.EvalFeature(ctx, "feature").Value)
+		require.Empty(t, ages)
+
+		age(client, 2*time.Hour)
+		require.Equal(t, 1.0, client.EvalFeature(ctx, "feature").Value)
+		require.Equal(t, 1.0, client.EvalFeature(ctx, "feature").Value)
+		require.Len(t, ages, 1)
+		require.GreaterOrEqual(t, ages[0], 2*time.Hour)
+
+		require.Nil(t, client.SetFeatures(client.Features()))
+		client.EvalFeature(ctx, "feature")
+		require.Len(t, ages, 1)
+		age(client, 2*time.Hour)
+		client.EvalFeature(ctx, "feature")
+		require.Len(t, ages, 2)
+	})
+
+	t.Run("Default values", func(t *testing.T) {
+		client, _ := NewClient(ctx, WithJsonFeatures(featuresJson), WithMaxPayloadAge(time.Hour, DefaultValuesStalePayload))
+		age(client, 2*time.Hour)
+		res := client.EvalFeature(ctx, "feature")
+		require.Equal(t, 0.0, res.Value)
+		require.Equal(t, DefaultValueResultSource, res.Source)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		client, _ := NewClient(ctx, WithJsonFeatures(featuresJson), WithMaxPayloadAge(time.Hour, ErrorStalePayload))
+		age(client, 2*time.Hour)
+		res := client.EvalFeature(ctx, "feature")
+		require.Nil(t, res.Value)
+		require.Equal(t, StalePayloadResultSource, res.Source)
+		require.Equal(t, UnknownFeatureResultSource, client.EvalFeature(ctx, "missing").Source)
+	})
+
+	t.Run("Invalid options", func(t *testing.T) {
+		_, err := NewClient(ctx, WithMaxPayloadAge(0, WarnStalePayload))
+		require.NotNil(t, err)
+		_, err = NewClient(ctx, WithMaxPayloadAge(time.Hour, "ignore"))
+		require.NotNil(t, err)
+	})
+
+	t.Run("SSE revalidation", func(t *testing.T) {
+		requests := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.Header().Set("Etag", "v1")
+			if r.Header.Get("If-None-Match") == "v1" {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Write([]byte(`{"features": {"feature": {"defaultValue": 2}}, "dateUpdated": "2000-05-01T00:00:12Z"}`))
+		}))
+		defer ts.Close()
+		client, _ := NewClient(ctx,
+			WithApiHost(ts.URL),
+			WithClientKey("somekey"),
+			WithHttpClient(ts.Client()),
+			WithMaxPayloadAge(time.Hour, ErrorStalePayload),
+		)
+		ds := newSseDataSource(client)
+
+		age(client, 2*time.Hour)
+		require.Nil(t, ds.revalidateData(ctx))
+		require.Less(t, client.PayloadAge(), time.Minute)
+		require.Equal(t, 2.0, client.EvalFeature(ctx, "feature").Value)
+
+		age(client, 2*time.Hour)
+		require.Nil(t, ds.revalidateData(ctx))
+		require.Less(t, client.PayloadAge(), time.Minute)
+		require.Equal(t, 2, requests)
+	})
+}
@@ -0,0 +1,74 @@
+package growthbook
+
+// ClientStats describes resources held by the client. Shared data, goroutines
+// and subscribers are the same for the client and its child clients, while
+// subscription assignments, saved results and DevTools logs belong to the client instance.
+type ClientStats struct {
+	// Background goroutines started by the SDK data sources and consistency checker
+	Goroutines int
+	// Size of the last features payload loaded from the API, in bytes
+	PayloadBytes int
+	// Size of the last payload written to or read from the shared cache, in bytes,
+	// compressed if [WithSharedCacheCompression] is set
+	SharedCacheBytes int
+	// Number of features in the current payload
+	Features int
+	// Number of saved groups in the current payload
+	SavedGroups int
+	// Assignments docs kept in memory, if the client uses [MemoryStickyBucketService]
+	StickyBucketDocs int
+	// Experiments tracked to notify subscribers only on assignment change
+	SubscriptionAssignments int
+	// Assignments returned by [Client.GetAllResults]
+	SavedResults int
+	// Evaluations recorded for DevTools in dev mode
+	DevToolsLogs int
+	// Experiment results kept by [WithExperimentMemo]
+	MemoizedExperiments int
+	// Streamed payloads replaced by a newer one before they were applied
+	DroppedUpdates int64
+	// Scopes acquired by [Client.AcquireScope] and not released yet
+	ScopesInUse int64
+	// Scopes allocated because the pool had none to reuse
+	ScopesAllocated int64
+	// Features API calls by API URL: HTTP requests made and calls coalesced into them
+	Fetches map[string]FetchStats
+}
+
+// Stats reports SDK overhead of the client, e.g. to export it as metrics.
+func (client *Client) Stats() ClientStats {
+	var stats ClientStats
+
+	d := client.data
+	stats.Goroutines = int(d.goroutines.Load())
+	stats.DroppedUpdates = d.dropped.Load()
+	stats.SharedCacheBytes = int(d.cacheBytes.Load())
+	stats.Fetches = d.apiFlights.snapshot()
+	stats.ScopesInUse = client.scopes.inUse.Load()
+	stats.ScopesAllocated = client.scopes.allocated.Load()
+	d.mu.RLock()
+	stats.PayloadBytes = d.payloadSize
+	stats.Features = len(d.features)
+	stats.SavedGroups = len(d.savedGroups)
+	d.mu.RUnlock()
+
+	if s, ok := client.stickyBucketService.(*MemoryStickyBucketService); ok {
+		stats.StickyBucketDocs = s.Len()
+	}
+
+	client.notified.mu.Lock()
+	stats.SubscriptionAssignments = len(client.notified.assigned)
+	client.notified.mu.Unlock()
+
+	client.results.mu.RLock()
+	stats.SavedResults = len(client.results.results)
+	client.results.mu.RUnlock()
+
+	stats.MemoizedExperiments = client.memoLen()
+
+	client.devToolsLogs.mu.Lock()
+	stats.DevToolsLogs = len(client.devToolsLogs.logs)
+	client.devToolsLogs.mu.Unlock()
+
+	return stats
+}
@@ -0,0 +1,41 @@
+package growthbook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientStats(t *testing.T) {
+	ctx := context.TODO()
+	featuresJSON := []byte(`{"features": {"foo": {"defaultValue": "api"}, "bar": {"defaultValue": 1}}, "dateUpdated": "2000-05-01T00:00:12Z"}`)
+	ts := startSseServer(featuresJSON, sseEvents(time.Millisecond))
+	defer ts.http.Close()
+
+	client, err := NewClient(ctx,
+		WithHttpClient(ts.http.Client()),
+		WithApiHost(ts.http.URL),
+		WithClientKey("somekey"),
+		WithSseDataSource(),
+		WithStickyBucketService(NewMemoryStickyBucketService()),
+	)
+	require.Nil(t, err)
+	require.Nil(t, client.EnsureLoaded(ctx))
+	require.Nil(t, client.SetJSONFeatures(`{"exp": {"defaultValue": 0, "rules": [{"variations": [0, 1]}]}}`))
+	child, err := client.WithAttributes(Attributes{"id": "1"})
+	require.Nil(t, err)
+	child.EvalFeature(ctx, "exp")
+
+	require.Eventually(t, func() bool { return client.Stats().Goroutines == 1 }, time.Second, time.Millisecond)
+	stats := client.Stats()
+	require.Equal(t, len(featuresJSON), stats.PayloadBytes)
+	require.Equal(t, 1, stats.Features)
+	require.Equal(t, 1, stats.StickyBucketDocs)
+	require.Equal(t, 0, stats.SavedResults)
+	require.Equal(t, 1, child.Stats().SavedResults)
+
+	require.Nil(t, client.Close())
+	require.Eventually(t, func() bool { return client.Stats().Goroutines == 0 }, time.Second, time.Millisecond)
+}
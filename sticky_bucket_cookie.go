@@ -0,0 +1,185 @@
+package growthbook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/growthbook/growthbook-golang/eval"
+)
+
+const (
+	defaultStickyBucketCookiePrefix  = "gbStickyBuckets__"
+	defaultStickyBucketCookieMaxSize = 4096
+	defaultStickyBucketCookieMaxAge  = 180 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidStickyBucketCookie  = errors.New("Invalid sticky bucket cookie signature")
+	ErrStickyBucketCookieTooLarge = errors.New("Sticky bucket cookie is too large")
+)
+
+// CookieStickyBucketService is a request scoped [StickyBucketService] that keeps
+// assignments in signed cookies, one cookie per attribute value, like the JS SDK
+// does in the browser. Create a new service for every request and pass it to
+// [Client.WithStickyBucketService]. Assignments must be evaluated before
+// the response header is written, otherwise cookies are not sent.
+type CookieStickyBucketService struct {
+	r       *http.Request
+	w       http.ResponseWriter
+	secret  []byte
+	prefix  string
+	maxSize int
+	cookie  http.Cookie
+
+	mu    sync.Mutex
+	saved map[string]*StickyBucketAssignmentDoc
+}
+
+var _ StickyBucketService = &CookieStickyBucketService{}
+
+// CookieStickyBucketOption configures [CookieStickyBucketService].
+type CookieStickyBucketOption func(*CookieStickyBucketService)
+
+// WithStickyBucketCookiePrefix sets prefix of cookie names. Default "gbStickyBuckets__".
+func WithStickyBucketCookiePrefix(prefix string) CookieStickyBucketOption {
+	return func(s *CookieStickyBucketService) {
+		s.prefix = prefix
+	}
+}
+
+// WithStickyBucketCookieMaxSize sets maximum size of a single serialized cookie in bytes. Default 4096.
+func WithStickyBucketCookieMaxSize(maxSize int) CookieStickyBucketOption {
+	return func(s *CookieStickyBucketService) {
+		s.maxSize = maxSize
+	}
+}
+
+// WithStickyBucketCookieAttributes sets Path, Domain, MaxAge, Expires, Secure,
+// HttpOnly and SameSite attributes of written cookies. Name and value are ignored.
+// Default is Path "/", 180 days MaxAge, HttpOnly and SameSite Lax.
+func WithStickyBucketCookieAttributes(cookie http.Cookie) CookieStickyBucketOption {
+	return func(s *CookieStickyBucketService) {
+		s.cookie = cookie
+	}
+}
+
+// NewCookieStickyBucketService creates service that reads assignments from the request
+// cookies and writes updated ones to the response. Cookies are signed with HMAC-SHA256
+// using secret; cookies with invalid signature are ignored.
+func NewCookieStickyBucketService(w http.ResponseWriter, r *http.Request, secret []byte, opts ...CookieStickyBucketOption) *CookieStickyBucketService {
+	s := &CookieStickyBucketService{
+		r:       r,
+		w:       w,
+		secret:  secret,
+		prefix:  defaultStickyBucketCookiePrefix,
+		maxSize: defaultStickyBucketCookieMaxSize,
+		cookie: http.Cookie{
+			Path:     "/",
+			MaxAge:   int(defaultStickyBucketCookieMaxAge / time.Second),
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		},
+		saved: map[string]*StickyBucketAssignmentDoc{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *CookieStickyBucketService) GetAssignments(_ context.Context, attributeName string, attributeValue string) (*StickyBucketAssignmentDoc, error) {
+	key := eval.StickyBucketKey(attributeName, attributeValue)
+
+	s.mu.Lock()
+	doc, ok := s.saved[key]
+	s.mu.Unlock()
+	if ok {
+		return doc.Clone(), nil
+	}
+
+	cookie, err := s.r.Cookie(s.cookieName(key))
+	if errors.Is(err, http.ErrNoCookie) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err = s.decode(cookie)
+	if err != nil {
+		return nil, err
+	}
+	if doc.AttributeName != attributeName || doc.AttributeValue != attributeValue {
+		return nil, ErrInvalidStickyBucketCookie
+	}
+	return doc, nil
+}
+
+func (s *CookieStickyBucketService) SaveAssignments(_ context.Context, doc *StickyBucketAssignmentDoc) error {
+	key := eval.StickyBucketKey(doc.AttributeName, doc.AttributeValue)
+	cookie, err := s.encode(s.cookieName(key), doc)
+	if err != nil {
+		return err
+	}
+	if len(cookie.String()) > s.maxSize {
+		return ErrStickyBucketCookieTooLarge
+	}
+	http.SetCookie(s.w, cookie)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.saved[key] = doc.Clone()
+	return nil
+}
+
+// cookieName encodes attribute name and value, as they may contain characters invalid in cookie names.
+func (s *CookieStickyBucketService) cookieName(key string) string {
+	return s.prefix + base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+func (s *CookieStickyBucketService) encode(name string, doc *StickyBucketAssignmentDoc) (*http.Cookie, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(data)
+
+	cookie := s.cookie
+	cookie.Name = name
+	cookie.Value = payload + "." + s.sign(name, payload)
+	return &cookie, nil
+}
+
+func (s *CookieStickyBucketService) decode(cookie *http.Cookie) (*StickyBucketAssignmentDoc, error) {
+	payload, sig, ok := strings.Cut(cookie.Value, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(s.sign(cookie.Name, payload))) {
+		return nil, ErrInvalidStickyBucketCookie
+	}
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, err
+	}
+	var doc StickyBucketAssignmentDoc
+	err = json.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// sign binds signature to the cookie name, so a valid cookie can't be reused for another user.
+func (s *CookieStickyBucketService) sign(name string, payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(name))
+	mac.Write([]byte{'='})
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,79 @@
+package growthbook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const stickyCookieFeaturesJSON = `{
+  "exp": {
+    "defaultValue": "control",
+    "rules": [{
+      "key": "exp",
+      "variations": ["control", "red", "blue"],
+      "meta": [{"key": "0"}, {"key": "1"}, {"key": "2"}],
+      "weights": %s
+    }]
+  }
+}`
+
+func TestCookieStickyBucketServiceKeepsAssignment(t *testing.T) {
+	ctx := context.TODO()
+	secret := []byte("secret")
+	client, err := NewClient(ctx,
+		WithJsonFeatures(fmt.Sprintf(stickyCookieFeaturesJSON, "[0, 1, 0]")),
+		WithAttributes(Attributes{"id": "123"}))
+	require.Nil(t, err)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	child, err := client.WithStickyBucketService(NewCookieStickyBucketService(rec, req, secret))
+	require.Nil(t, err)
+	require.Equal(t, "red", child.EvalFeature(ctx, "exp").Value)
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	require.True(t, cookies[0].HttpOnly)
+
+	require.Nil(t, client.SetJSONFeatures(fmt.Sprintf(stickyCookieFeaturesJSON, "[0, 0, 1]")))
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(cookies[0])
+	child, err = client.WithStickyBucketService(NewCookieStickyBucketService(rec, req, secret))
+	require.Nil(t, err)
+	res := child.EvalFeature(ctx, "exp")
+	require.Equal(t, "red", res.Value)
+	require.True(t, res.ExperimentResult.StickyBucketUsed)
+	require.Empty(t, rec.Result().Cookies())
+}
+
+func TestCookieStickyBucketServiceRejectsInvalidSignature(t *testing.T) {
+	ctx := context.TODO()
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	doc := &StickyBucketAssignmentDoc{AttributeName: "id", AttributeValue: "123", Assignments: StickyBucketAssignments{"exp__0": "1"}}
+	require.Nil(t, NewCookieStickyBucketService(rec, req, []byte("other")).SaveAssignments(ctx, doc))
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(rec.Result().Cookies()[0])
+	s := NewCookieStickyBucketService(httptest.NewRecorder(), req, []byte("secret"))
+	res, err := s.GetAssignments(ctx, "id", "123")
+	require.ErrorIs(t, err, ErrInvalidStickyBucketCookie)
+	require.Nil(t, res)
+}
+
+func TestCookieStickyBucketServiceMaxSize(t *testing.T) {
+	ctx := context.TODO()
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	s := NewCookieStickyBucketService(rec, req, []byte("secret"), WithStickyBucketCookieMaxSize(64))
+	doc := &StickyBucketAssignmentDoc{AttributeName: "id", AttributeValue: "123", Assignments: StickyBucketAssignments{"exp__0": "1"}}
+
+	require.ErrorIs(t, s.SaveAssignments(ctx, doc), ErrStickyBucketCookieTooLarge)
+	require.Empty(t, rec.Result().Cookies())
+}
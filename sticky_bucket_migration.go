@@ -0,0 +1,102 @@
+package growthbook
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+)
+
+// ExportAssignments returns assignments docs of the attribute values from any sticky bucket service,
+// e.g. to migrate them to another service. Values without assignments are skipped.
+// Use [MemoryStickyBucketService.Docs] to export all docs of the in-memory service.
+func ExportAssignments(ctx context.Context, service StickyBucketService, attributeName string, values ...string) ([]*StickyBucketAssignmentDoc, error) {
+	var res []*StickyBucketAssignmentDoc
+	for _, value := range values {
+		doc, err := service.GetAssignments(ctx, attributeName, value)
+		if err != nil {
+			return res, fmt.Errorf("Error exporting assignments of %s: %w", attributeName, err)
+		}
+		if doc != nil && len(doc.Assignments) > 0 {
+			res = append(res, doc)
+		}
+	}
+	return res, nil
+}
+
+// ImportAssignments saves assignments docs into the service, so users keep their variations after
+// migration instead of being re-bucketed. Docs are merged with ones already in the service, and
+// existing assignments take precedence, as they were made after the migration started.
+func ImportAssignments(ctx context.Context, service StickyBucketService, docs ...*StickyBucketAssignmentDoc) error {
+	for _, doc := range docs {
+		if err := validateAssignmentDoc(doc); err != nil {
+			return err
+		}
+		existing, err := service.GetAssignments(ctx, doc.AttributeName, doc.AttributeValue)
+		if err != nil {
+			return fmt.Errorf("Error importing assignments of %s: %w", doc.AttributeName, err)
+		}
+		merged := doc.Clone()
+		if existing != nil {
+			if merged.Assignments == nil {
+				merged.Assignments = StickyBucketAssignments{}
+			}
+			maps.Copy(merged.Assignments, existing.Assignments)
+			if maps.Equal(merged.Assignments, existing.Assignments) {
+				continue
+			}
+		}
+		if err := service.SaveAssignments(ctx, merged); err != nil {
+			return fmt.Errorf("Error importing assignments of %s: %w", doc.AttributeName, err)
+		}
+	}
+	return nil
+}
+
+// WriteAssignments writes assignments docs as JSON lines, one doc per line,
+// the streaming format read by [ReadAssignments].
+func WriteAssignments(w io.Writer, docs ...*StickyBucketAssignmentDoc) error {
+	enc := json.NewEncoder(w)
+	for _, doc := range docs {
+		if err := enc.Encode(doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadAssignments reads assignments docs written by [WriteAssignments] and calls fn for every
+// doc, so exports of any size can be imported without loading them into memory, e.g.
+//
+//	err := ReadAssignments(r, func(doc *StickyBucketAssignmentDoc) error {
+//		return ImportAssignments(ctx, redisService, doc)
+//	})
+func ReadAssignments(r io.Reader, fn func(doc *StickyBucketAssignmentDoc) error) error {
+	dec := json.NewDecoder(bufio.NewReader(r))
+	for line := 1; ; line++ {
+		var doc StickyBucketAssignmentDoc
+		err := dec.Decode(&doc)
+		if err == io.EOF {
+			return nil
+		}
+		if err == nil {
+			err = validateAssignmentDoc(&doc)
+		}
+		if err != nil {
+			return fmt.Errorf("Error reading assignments doc %d: %w", line, err)
+		}
+		if err := fn(&doc); err != nil {
+			return err
+		}
+	}
+}
+
+func validateAssignmentDoc(doc *StickyBucketAssignmentDoc) error {
+	if doc.AttributeName == "" || doc.AttributeValue == "" {
+		return errors.New("Assignments doc without attribute name or value")
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package growthbook
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStickyBucketAssignmentsMigration(t *testing.T) {
+	ctx := context.TODO()
+	source := NewMemoryStickyBucketService()
+	require.Nil(t, source.SaveAssignments(ctx, &StickyBucketAssignmentDoc{
+		AttributeName:  "id",
+		AttributeValue: "1",
+		Assignments:    StickyBucketAssignments{"exp__0": "0", "other__0": "1"},
+	}))
+	require.Nil(t, source.SaveAssignments(ctx, &StickyBucketAssignmentDoc{
+		AttributeName:  "deviceId",
+		AttributeValue: "d1",
+		Assignments:    StickyBucketAssignments{"exp__0": "1"},
+	}))
+
+	docs, err := ExportAssignments(ctx, source, "id", "1", "2")
+	require.Nil(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "1", docs[0].AttributeValue)
+
+	var buf bytes.Buffer
+	require.Nil(t, WriteAssignments(&buf, source.Docs()...))
+	require.Equal(t, 2, strings.Count(buf.String(), "\n"))
+
+	// Assignments made in the target after the migration started win
+	target := NewMemoryStickyBucketService()
+	require.Nil(t, target.SaveAssignments(ctx, &StickyBucketAssignmentDoc{
+		AttributeName:  "id",
+		AttributeValue: "1",
+		Assignments:    StickyBucketAssignments{"exp__0": "1", "new__0": "0"},
+	}))
+	err = ReadAssignments(&buf, func(doc *StickyBucketAssignmentDoc) error {
+		return ImportAssignments(ctx, target, doc)
+	})
+	require.Nil(t, err)
+	require.Equal(t, 2, target.Len())
+
+	doc, err := target.GetAssignments(ctx, "id", "1")
+	require.Nil(t, err)
+	require.Equal(t, StickyBucketAssignments{"exp__0": "1", "other__0": "1", "new__0": "0"}, doc.Assignments)
+	doc, err = target.GetAssignments(ctx, "deviceId", "d1")
+	require.Nil(t, err)
+	require.Equal(t, StickyBucketAssignments{"exp__0": "1"}, doc.Assignments)
+}
+
+func TestReadAssignmentsInvalid(t *testing.T) {
+	noop := func(*StickyBucketAssignmentDoc) error { return nil }
+	err := ReadAssignments(strings.NewReader(`{"attributeName":"id","attributeValue":"1"}`+"\n"+`{"assignments":{}}`), noop)
+	require.ErrorContains(t, err, "doc 2")
+	err = ReadAssignments(strings.NewReader(`not json`), noop)
+	require.NotNil(t, err)
+	require.NotNil(t, ImportAssignments(context.TODO(), NewMemoryStickyBucketService(), &StickyBucketAssignmentDoc{}))
+}
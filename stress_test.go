@@ -0,0 +1,208 @@
+//go:build stress
+
+package growthbook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Stress tests hammer the client from many goroutines to let the race detector
+// find unsynchronized access. Run them with `make stress`.
+
+const stressDuration = 2 * time.Second
+
+// stress runs every worker in parallel goroutines in a loop until the duration passes.
+func stress(t *testing.T, duration time.Duration, parallel int, workers map[string]func(i int)) {
+	t.Helper()
+	deadline := time.Now().Add(duration)
+	var wg sync.WaitGroup
+	var iterations atomic.Int64
+	for name, worker := range workers {
+		for p := 0; p < parallel; p++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() {
+					if r := recover(); r != nil {
+						t.Errorf("worker %s panicked: %v", name, r)
+					}
+				}()
+				for i := 0; time.Now().Before(deadline); i++ {
+					worker(i)
+					iterations.Add(1)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+	t.Logf("%d iterations", iterations.Load())
+}
+
+// stressFeatures returns features payload with the generation in the values.
+func stressFeatures(gen int) string {
+	return fmt.Sprintf(`{
+	  "flag": {"defaultValue": %d, "rules": [{"condition": {"country": "US"}, "force": true}]},
+	  "exp": {"defaultValue": 0, "rules": [{"key": "exp-%d", "variations": [0, 1, 2]}]},
+	  "child": {"defaultValue": "off", "rules": [{"parentConditions": [{"id": "flag", "condition": {"value": true}}], "force": "on"}]}
+	}`, gen, gen%3)
+}
+
+func TestStressClient(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClient(ctx,
+		WithJsonFeatures(stressFeatures(0)),
+		WithStickyBucketService(NewMemoryStickyBucketService()),
+		WithExperimentCallback(func(context.Context, *Experiment, *ExperimentResult, any) {}),
+	)
+	require.Nil(t, err)
+	unsubscribe := client.Subscribe(func(context.Context, *Experiment, *ExperimentResult) {})
+	defer unsubscribe()
+
+	stress(t, stressDuration, 4, map[string]func(int){
+		"eval": func(i int) {
+			child, err := client.WithAttributes(Attributes{"id": strconv.Itoa(i), "country": "US"})
+			require.Nil(t, err)
+			for _, key := range []string{"flag", "exp", "child", "missing"} {
+				child.EvalFeature(ctx, key)
+			}
+		},
+		"overrides": func(i int) {
+			child, err := client.WithAttributeOverrides(Attributes{"country": "CA"})
+			require.Nil(t, err)
+			child.EvalFeature(ctx, "flag")
+			child.GetAllResults()
+		},
+		"setFeatures": func(i int) {
+			require.Nil(t, client.SetJSONFeatures(stressFeatures(i)))
+		},
+		"setFeature": func(i int) {
+			client.SetFeature("extra", NewFeature(i))
+			client.RemoveFeature("extra")
+		},
+		"apiResponse": func(i int) {
+			resp := fmt.Sprintf(`{"features": %s, "dateUpdated": "%s"}`,
+				stressFeatures(i), time.Now().UTC().Format(time.RFC3339Nano))
+			require.Nil(t, client.UpdateFromApiResponseJSON(resp))
+		},
+		"read": func(i int) {
+			client.Features()
+			client.Attributes()
+			client.Stats()
+			client.RequiredAttributes("child")
+		},
+		"snapshot": func(i int) {
+			pinned := client.PinSnapshot(ctx)
+			require.Equal(t, client.EvalFeature(pinned, "flag").Value, client.EvalFeature(pinned, "flag").Value)
+		},
+	})
+	require.Nil(t, client.Close())
+}
+
+func TestStressWatchers(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClient(ctx, WithJsonFeatures(stressFeatures(0)))
+	require.Nil(t, err)
+
+	stress(t, stressDuration, 4, map[string]func(int){
+		"watch": func(i int) {
+			ch, stop := client.WatchFeature(ctx, "flag", Attributes{"id": strconv.Itoa(i)})
+			<-ch
+			stop()
+		},
+		"setFeatures": func(i int) {
+			require.Nil(t, client.SetJSONFeatures(stressFeatures(i)))
+		},
+	})
+	require.Nil(t, client.Close())
+}
+
+func TestStressWebhook(t *testing.T) {
+	ctx := context.Background()
+	var received atomic.Int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+	}))
+	defer ts.Close()
+	client, err := NewClient(ctx,
+		WithJsonFeatures(stressFeatures(0)),
+		WithHttpClient(ts.Client()),
+		WithChangeWebhook(ts.URL, []byte("secret")),
+		WithFeaturesChangeCallback(func(context.Context, *FeaturesDiff) {}),
+	)
+	require.Nil(t, err)
+
+	// Writers queue between read locks of notifications
+	stress(t, stressDuration, 4, map[string]func(int){
+		"apiResponse": func(i int) {
+			resp := fmt.Sprintf(`{"features": %s, "dateUpdated": "%s"}`,
+				stressFeatures(i), time.Now().UTC().Format(time.RFC3339Nano))
+			require.Nil(t, client.UpdateFromApiResponseJSON(resp))
+		},
+		"setFeatures": func(i int) {
+			require.Nil(t, client.SetJSONFeatures(stressFeatures(i)))
+		},
+		"reconfigure": func(i int) {
+			require.Nil(t, client.Reconfigure(ctx, WithHttpClient(ts.Client())))
+		},
+	})
+	require.Nil(t, client.Close())
+	require.NotZero(t, received.Load())
+}
+
+func TestStressSseDataSource(t *testing.T) {
+	ctx := context.Background()
+	events := make([]string, 1000)
+	for i := range events {
+		date := time.Date(2000, time.May, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(i+1) * time.Second)
+		events[i] = fmt.Sprintf("event: features\ndata: {\"features\": {\"flag\": {\"defaultValue\": %d}}, \"dateUpdated\": %q}\n\n", i, date.Format(time.RFC3339))
+	}
+	ts := startSseServer([]byte(`{"features": {"flag": {"defaultValue": -1}}, "dateUpdated": "2000-05-01T00:00:00Z"}`),
+		sseEvents(100*time.Microsecond, events...))
+	defer ts.http.Close()
+
+	newClient := func() *Client {
+		client, err := NewClient(ctx,
+			WithHttpClient(ts.http.Client()),
+			WithApiHost(ts.http.URL),
+			WithClientKey("somekey"),
+			WithSseDataSource(),
+		)
+		require.Nil(t, err)
+		require.Nil(t, client.EnsureLoaded(ctx))
+		return client
+	}
+	client := newClient()
+
+	stress(t, stressDuration, 4, map[string]func(int){
+		"eval": func(i int) {
+			child, err := client.WithAttributes(Attributes{"id": strconv.Itoa(i)})
+			require.Nil(t, err)
+			child.EvalFeature(ctx, "flag")
+		},
+		"read": func(i int) {
+			client.Features()
+			client.Stats()
+		},
+		"clients": func(i int) {
+			// Clients sharing the stream come and go
+			require.Nil(t, newClient().Close())
+		},
+		"reconfigure": func(i int) {
+			if i%100 == 0 {
+				require.Nil(t, client.Reconfigure(ctx, WithClientKey("somekey")))
+			}
+		},
+	})
+	require.Nil(t, client.Close())
+	require.Eventually(t, func() bool { return client.Stats().Goroutines == 0 }, time.Second, time.Millisecond)
+}
@@ -0,0 +1,89 @@
+package growthbook
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// ExperimentSubscriber function is executed when an experiment run changes its assignment.
+type ExperimentSubscriber func(context.Context, *Experiment, *ExperimentResult)
+
+type assignment struct {
+	inExperiment bool
+	variationId  int
+	hashValue    string
+}
+
+type subscriber struct {
+	id int
+	cb ExperimentSubscriber
+}
+
+// subscriptions are shared between a client and its child clients.
+type subscriptions struct {
+	mu     sync.Mutex
+	nextId int
+	subs   []subscriber
+}
+
+func newSubscriptions() *subscriptions {
+	return &subscriptions{}
+}
+
+// notifiedAssignments keep assignments subscribers were last notified about by a single
+// client instance, so runs of child clients for other users don't suppress notifications.
+type notifiedAssignments struct {
+	mu       sync.Mutex
+	assigned map[string]assignment
+}
+
+func newNotifiedAssignments() *notifiedAssignments {
+	return &notifiedAssignments{assigned: map[string]assignment{}}
+}
+
+// Subscribe adds subscriber executed on experiment runs. Subscriber is called only when
+// the assignment for the experiment changes since the last run of the same client instance.
+// Subscribers are shared with child clients. Returned function unsubscribes.
+func (client *Client) Subscribe(cb ExperimentSubscriber) func() {
+	s := client.subscriptions
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.nextId
+	s.nextId++
+	s.subs = append(s.subs, subscriber{id, cb})
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.subs = slices.DeleteFunc(s.subs, func(sub subscriber) bool {
+				return sub.id == id
+			})
+		})
+	}
+}
+
+// changed returns subscribers to call if the experiment assignment changed since
+// the notified one.
+func (s *subscriptions) changed(notified *notifiedAssignments, exp *Experiment, res *ExperimentResult) []ExperimentSubscriber {
+	cur := assignment{res.InExperiment, res.VariationId, res.HashValue}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.subs) == 0 {
+		return nil
+	}
+	notified.mu.Lock()
+	defer notified.mu.Unlock()
+	prev, ok := notified.assigned[exp.Key]
+	if ok && prev == cur {
+		return nil
+	}
+	notified.assigned[exp.Key] = cur
+	subs := make([]ExperimentSubscriber, len(s.subs))
+	for i, sub := range s.subs {
+		subs[i] = sub.cb
+	}
+	return subs
+}
@@ -0,0 +1,124 @@
+package growthbook
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptions(t *testing.T) {
+	ctx := context.TODO()
+	exp := &Experiment{Key: "my-test", Variations: []FeatureValue{0, 1}}
+	client, _ := NewClient(ctx, WithAttributes(Attributes{"id": "1"}))
+
+	var calls1, calls2 []*ExperimentResult
+	unsubscribe1 := client.Subscribe(func(_ context.Context, e *Experiment, r *ExperimentResult) {
+		require.Equal(t, exp, e)
+		calls1 = append(calls1, r)
+	})
+	unsubscribe2 := client.Subscribe(func(_ context.Context, _ *Experiment, r *ExperimentResult) {
+		calls2 = append(calls2, r)
+	})
+
+	t.Run("Subscribers are called on first run", func(t *testing.T) {
+		res := client.RunExperiment(ctx, exp)
+		require.Equal(t, []*ExperimentResult{res}, calls1)
+		require.Equal(t, []*ExperimentResult{res}, calls2)
+	})
+
+	t.Run("Same assignment doesn't call subscribers", func(t *testing.T) {
+		client.RunExperiment(ctx, exp)
+		require.Len(t, calls1, 1)
+	})
+
+	t.Run("Changed assignment in child client calls subscribers", func(t *testing.T) {
+		child, _ := client.WithForcedVariations(ForcedVariationsMap{"my-test": 1 - calls1[0].VariationId})
+		child.RunExperiment(ctx, exp)
+		require.Len(t, calls1, 2)
+		require.Len(t, calls2, 2)
+	})
+
+	t.Run("Child client assignment doesn't suppress parent client", func(t *testing.T) {
+		client.RunExperiment(ctx, exp)
+		require.Len(t, calls1, 2)
+	})
+
+	t.Run("Unsubscribe", func(t *testing.T) {
+		unsubscribe1()
+		unsubscribe1()
+		child, _ := client.WithAttributes(Attributes{"id": "2"})
+		child.RunExperiment(ctx, exp)
+		require.Len(t, calls1, 2)
+		require.Len(t, calls2, 3)
+		unsubscribe2()
+	})
+}
+
+func TestSubscriptionsOfChildClients(t *testing.T) {
+	ctx := context.TODO()
+	exp := &Experiment{Key: "my-test", Variations: []FeatureValue{0, 1}}
+	client, _ := NewClient(ctx, WithAttributes(Attributes{"id": "1"}))
+	var ids []string
+	client.Subscribe(func(_ context.Context, _ *Experiment, r *ExperimentResult) {
+		ids = append(ids, r.HashValue)
+	})
+
+	client.RunExperiment(ctx, exp)
+	child, _ := client.WithAttributes(Attributes{"id": "2"})
+	child.RunExperiment(ctx, exp)
+	scope, _ := client.AcquireScope(Attributes{"id": "3"})
+	scope.Client().RunExperiment(ctx, exp)
+	client.ReleaseScope(scope)
+	client.RunExperiment(ctx, exp)
+	require.Equal(t, []string{"1", "2", "3"}, ids)
+
+	// Parent is notified again after a child client ran the experiment for another user
+	ids = nil
+	client.RunExperiment(ctx, &Experiment{Key: "other", Variations: []FeatureValue{0, 1}})
+	child.RunExperiment(ctx, &Experiment{Key: "other", Variations: []FeatureValue{0, 1}})
+	client.RunExperiment(ctx, &Experiment{Key: "other", Variations: []FeatureValue{0, 1}})
+	require.Equal(t, []string{"1", "2"}, ids)
+	require.Equal(t, 2, client.Stats().SubscriptionAssignments)
+}
+
+func TestSubscriptionsFromFeatureExperiment(t *testing.T) {
+	ctx := context.TODO()
+	client, _ := NewClient(ctx,
+		WithAttributes(Attributes{"id": "1"}),
+		WithJsonFeatures(`{"feature": {"defaultValue": 0, "rules": [{"variations": [0, 1]}]}}`),
+	)
+	var keys []string
+	client.Subscribe(func(_ context.Context, e *Experiment, _ *ExperimentResult) {
+		keys = append(keys, e.Key)
+	})
+	client.EvalFeature(ctx, "feature")
+	require.Equal(t, []string{"feature"}, keys)
+}
+
+func TestSubscriptionsConcurrency(t *testing.T) {
+	ctx := context.TODO()
+	exp := &Experiment{Key: "my-test", Variations: []FeatureValue{0, 1}}
+	client, _ := NewClient(ctx)
+
+	var count atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			unsubscribe := client.Subscribe(func(context.Context, *Experiment, *ExperimentResult) {
+				count.Add(1)
+			})
+			defer unsubscribe()
+			for j := 0; j < 100; j++ {
+				child, _ := client.WithAttributes(Attributes{"id": j})
+				child.RunExperiment(ctx, exp)
+			}
+		}(i)
+	}
+	wg.Wait()
+	require.Greater(t, count.Load(), int32(0))
+}
@@ -83,18 +83,6 @@ func recordToLogEntry(record slog.Record) logEntry {
 	return entry
 }
 
-// Helper to round variation ranges for comparison with fixed test
-// values.
-func roundRanges(ranges []BucketRange) []BucketRange {
-	result := make([]BucketRange, len(ranges))
-	for i, r := range ranges {
-		rmin := math.Round(r.Min*1000000) / 1000000
-		rmax := math.Round(r.Max*1000000) / 1000000
-		result[i] = BucketRange{rmin, rmax}
-	}
-	return result
-}
-
 // Helper to round floating point arrays for test comparison.
 func roundArr(vals []float64) []float64 {
 	result := make([]float64, len(vals))
@@ -0,0 +1,130 @@
+package growthbook
+
+import (
+	"encoding/json"
+
+	"github.com/growthbook/growthbook-golang/eval"
+)
+
+// Evaluation types are defined in the eval package, which has no network dependencies.
+type (
+	AttributeSchema           = eval.AttributeSchema
+	AttributeValues           = eval.AttributeValues
+	Attributes                = eval.Attributes
+	BucketRange               = eval.BucketRange
+	Condition                 = eval.Condition
+	Experiment                = eval.Experiment
+	ExperimentOverride        = eval.ExperimentOverride
+	ExperimentOverrides       = eval.ExperimentOverrides
+	ExperimentResult          = eval.ExperimentResult
+	ExperimentReason          = eval.ExperimentReason
+	ExperimentStatus          = eval.ExperimentStatus
+	Feature                   = eval.Feature
+	FeatureMap                = eval.FeatureMap
+	FeatureResult             = eval.FeatureResult
+	FeatureResultSource       = eval.FeatureResultSource
+	FeatureRule               = eval.FeatureRule
+	FeatureValue              = eval.FeatureValue
+	Filter                    = eval.Filter
+	ForcedVariationsMap       = eval.ForcedVariationsMap
+	MemoryStickyBucketService = eval.MemoryStickyBucketService
+	Namespace                 = eval.Namespace
+	ParentCondition           = eval.ParentCondition
+	PassthroughAssignment     = eval.PassthroughAssignment
+	StickyBucketAssignmentDoc = eval.StickyBucketAssignmentDoc
+	StickyBucketAssignments   = eval.StickyBucketAssignments
+	StickyBucketService       = eval.StickyBucketService
+	VariationMeta             = eval.VariationMeta
+)
+
+const (
+	DraftStatus   = eval.DraftStatus
+	RunningStatus = eval.RunningStatus
+	StoppedStatus = eval.StoppedStatus
+)
+
+// FeatureResultSource values.
+const (
+	UnknownFeatureResultSource     = eval.UnknownFeatureResultSource
+	DefaultsResultSource           = eval.DefaultsResultSource
+	DefaultValueResultSource       = eval.DefaultValueResultSource
+	ForceResultSource              = eval.ForceResultSource
+	ExperimentResultSource         = eval.ExperimentResultSource
+	OverrideResultSource           = eval.OverrideResultSource
+	PrerequisiteResultSource       = eval.PrerequisiteResultSource
+	CyclicPrerequisiteResultSource = eval.CyclicPrerequisiteResultSource
+	TimeoutResultSource            = eval.TimeoutResultSource
+	StalePayloadResultSource       = eval.StalePayloadResultSource
+)
+
+// ExperimentReason values.
+const (
+	AssignedReason                     = eval.AssignedReason
+	StickyBucketReason                 = eval.StickyBucketReason
+	ForcedQueryStringReason            = eval.ForcedQueryStringReason
+	ForcedVariationReason              = eval.ForcedVariationReason
+	ForcedRuleReason                   = eval.ForcedRuleReason
+	ReleasedReason                     = eval.ReleasedReason
+	ExcludedInvalidReason              = eval.ExcludedInvalidReason
+	ExcludedDisabledReason             = eval.ExcludedDisabledReason
+	ExcludedDraftReason                = eval.ExcludedDraftReason
+	ExcludedStoppedReason              = eval.ExcludedStoppedReason
+	ExcludedInactiveReason             = eval.ExcludedInactiveReason
+	ExcludedMissingHashAttributeReason = eval.ExcludedMissingHashAttributeReason
+	ExcludedFilteredReason             = eval.ExcludedFilteredReason
+	ExcludedNamespaceReason            = eval.ExcludedNamespaceReason
+	ExcludedConditionReason            = eval.ExcludedConditionReason
+	ExcludedGroupsReason               = eval.ExcludedGroupsReason
+	ExcludedPrerequisiteReason         = eval.ExcludedPrerequisiteReason
+	ExcludedUrlReason                  = eval.ExcludedUrlReason
+	ExcludedHashVersionReason          = eval.ExcludedHashVersionReason
+	ExcludedStickyBucketReason         = eval.ExcludedStickyBucketReason
+	ExcludedCoverageReason             = eval.ExcludedCoverageReason
+	ExcludedQaModeReason               = eval.ExcludedQaModeReason
+)
+
+// ErrInvalidFeatureValue is returned when a feature value can't be decoded into the target type.
+var ErrInvalidFeatureValue = eval.ErrInvalidFeatureValue
+
+// ErrAttributeType is returned in strict mode when attributes don't match the schema, see [WithAttributeSchema].
+var ErrAttributeType = eval.ErrAttributeType
+
+// NewExperiment creates an experiment with default settings: active,
+// but all other fields empty.
+func NewExperiment(key string) *Experiment {
+	return eval.NewExperiment(key)
+}
+
+// NewFeature creates feature with the default value and no rules.
+func NewFeature(defaultValue FeatureValue) *Feature {
+	return eval.NewFeature(defaultValue)
+}
+
+// NewFeatureRule creates empty rule. Use With* methods to set it up.
+func NewFeatureRule() *FeatureRule {
+	return eval.NewFeatureRule()
+}
+
+// NewCondition builds condition from the object of the same shape as JSON conditions,
+// e.g. map[string]any{"country": map[string]any{"$in": []string{"US", "CA"}}}.
+func NewCondition(obj map[string]any) (Condition, error) {
+	return eval.NewCondition(obj)
+}
+
+// ParseCondition builds condition from JSON, e.g. {"country": {"$in": ["US", "CA"]}}.
+func ParseCondition(data []byte) (Condition, error) {
+	var cond Condition
+	err := json.Unmarshal(data, &cond)
+	return cond, err
+}
+
+// MustCondition is like [NewCondition] but panics if the condition is invalid.
+// Intended for tests and static conditions.
+func MustCondition(obj map[string]any) Condition {
+	return eval.MustCondition(obj)
+}
+
+// NewMemoryStickyBucketService creates empty in-memory service.
+func NewMemoryStickyBucketService() *MemoryStickyBucketService {
+	return eval.NewMemoryStickyBucketService()
+}
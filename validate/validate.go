@@ -0,0 +1,195 @@
+// Package validate simulates experiment assignment to verify traffic split before launch.
+//
+// Run an A/A check over a sample of ids and look at the p-value of the chi-square test:
+// a small value (e.g. below 0.001) means the observed split doesn't match the weights,
+// the same sample ratio mismatch (SRM) the analysis would report after launch.
+package validate
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/growthbook/growthbook-golang/eval"
+	"github.com/growthbook/growthbook-golang/hashutil"
+)
+
+// Ids is a stream of ids passed to the yield function until it returns false.
+type Ids func(yield func(id string) bool)
+
+// SliceIds streams ids from the slice.
+func SliceIds(ids []string) Ids {
+	return func(yield func(string) bool) {
+		for _, id := range ids {
+			if !yield(id) {
+				return
+			}
+		}
+	}
+}
+
+// SequentialIds streams n ids: prefix0, prefix1 and so on.
+func SequentialIds(prefix string, n int) Ids {
+	return func(yield func(string) bool) {
+		for i := 0; i < n; i++ {
+			if !yield(prefix + strconv.Itoa(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Report describes simulated assignment of the experiment.
+type Report struct {
+	// Number of ids simulated
+	Total int `json:"total"`
+	// Number of ids excluded from the experiment, e.g. by coverage or namespace
+	Excluded int `json:"excluded"`
+	// Number of ids assigned to each variation
+	Counts []int `json:"counts"`
+	// Expected share of included ids for each variation
+	Expected []float64 `json:"expected"`
+	// Observed share of included ids for each variation
+	Observed []float64 `json:"observed"`
+	// Pearson's chi-square statistic of counts against expected shares
+	ChiSquare float64 `json:"chiSquare"`
+	// Degrees of freedom of the chi-square test
+	DegreesOfFreedom int `json:"degreesOfFreedom"`
+	// Probability to observe the same or larger statistic if the split is correct
+	PValue float64 `json:"pValue"`
+}
+
+// SRM reports whether the p-value is below the threshold, i.e. the split likely
+// doesn't match the weights.
+func (r *Report) SRM(threshold float64) bool {
+	return r.PValue < threshold
+}
+
+// Experiment assigns every id to the experiment as the SDK does, with the id set to
+// the experiment hash attribute, and compares observed split with the weights.
+// Experiment targeting conditions and filters on other attributes exclude all ids.
+func Experiment(exp *eval.Experiment, ids Ids) (*Report, error) {
+	numVariations := len(exp.Variations)
+	if numVariations < 2 {
+		return nil, fmt.Errorf("Experiment %q has %d variations, at least 2 are required", exp.Key, numVariations)
+	}
+	hashAttribute := exp.HashAttribute
+	if hashAttribute == "" {
+		hashAttribute = "id"
+	}
+
+	ctx := context.Background()
+	report := &Report{Counts: make([]int, numVariations)}
+	ids(func(id string) bool {
+		report.Total++
+		opts := &eval.Options{Attributes: eval.NewAttributeValues(eval.Attributes{hashAttribute: id})}
+		res := eval.New(ctx, opts).RunExperiment(exp)
+		if res == nil || !res.InExperiment || res.VariationId < 0 || res.VariationId >= numVariations {
+			report.Excluded++
+			return true
+		}
+		report.Counts[res.VariationId]++
+		return true
+	})
+
+	report.Expected = expectedShares(exp)
+	report.Observed = make([]float64, numVariations)
+	included := report.Total - report.Excluded
+	if included == 0 {
+		report.PValue = 1
+		return report, nil
+	}
+	for i, count := range report.Counts {
+		report.Observed[i] = float64(count) / float64(included)
+		expected := report.Expected[i] * float64(included)
+		if expected > 0 {
+			d := float64(count) - expected
+			report.ChiSquare += d * d / expected
+			report.DegreesOfFreedom++
+		} else if count > 0 {
+			report.ChiSquare = math.Inf(1)
+		}
+	}
+	report.DegreesOfFreedom = max(report.DegreesOfFreedom-1, 1)
+	report.PValue = chiSquarePValue(report.ChiSquare, report.DegreesOfFreedom)
+	return report, nil
+}
+
+// expectedShares returns shares of bucket ranges of the experiment variations.
+func expectedShares(exp *eval.Experiment) []float64 {
+	ranges := exp.Ranges
+	if len(ranges) == 0 {
+		ranges = hashutil.GetBucketRanges(len(exp.Variations), 1, exp.Weights)
+	}
+	res := make([]float64, len(ranges))
+	total := 0.0
+	for i, r := range ranges {
+		res[i] = max(r.Max-r.Min, 0)
+		total += res[i]
+	}
+	for i := range res {
+		if total > 0 {
+			res[i] /= total
+		}
+	}
+	return res
+}
+
+// chiSquarePValue returns upper tail probability of the chi-square distribution.
+func chiSquarePValue(x float64, df int) float64 {
+	if math.IsInf(x, 1) {
+		return 0
+	}
+	if x <= 0 {
+		return 1
+	}
+	return upperGamma(float64(df)/2, x/2)
+}
+
+// upperGamma returns regularized upper incomplete gamma function Q(a, x),
+// using series expansion for small x and continued fraction otherwise.
+func upperGamma(a, x float64) float64 {
+	const eps = 1e-14
+	const maxIter = 1000
+	lgamma, _ := math.Lgamma(a)
+	norm := math.Exp(-x + a*math.Log(x) - lgamma)
+
+	if x < a+1 {
+		sum, term := 1/a, 1/a
+		for n := 1; n < maxIter; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*eps {
+				break
+			}
+		}
+		return max(1-sum*norm, 0)
+	}
+
+	// Modified Lentz's method
+	const tiny = 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for n := 1; n < maxIter; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < eps {
+			break
+		}
+	}
+	return h * norm
+}
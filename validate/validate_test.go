@@ -0,0 +1,50 @@
+package validate
+
+import (
+	"math"
+	"testing"
+
+	"github.com/growthbook/growthbook-golang/eval"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExperiment(t *testing.T) {
+	exp := eval.NewExperiment("checkout")
+	exp.Variations = []eval.FeatureValue{0, 1, 2}
+	exp.Weights = []float64{0.5, 0.25, 0.25}
+	coverage := 0.5
+	exp.Coverage = &coverage
+
+	report, err := Experiment(exp, SequentialIds("user-", 20000))
+	require.Nil(t, err)
+	require.Equal(t, 20000, report.Total)
+	require.InDelta(t, 10000, report.Excluded, 300)
+	require.Equal(t, report.Total-report.Excluded, report.Counts[0]+report.Counts[1]+report.Counts[2])
+	require.Equal(t, []float64{0.5, 0.25, 0.25}, report.Expected)
+	require.InDelta(t, 0.5, report.Observed[0], 0.02)
+	require.Equal(t, 2, report.DegreesOfFreedom)
+	require.False(t, report.SRM(0.001))
+}
+
+func TestExperimentSRM(t *testing.T) {
+	exp := eval.NewExperiment("checkout")
+	exp.Variations = []eval.FeatureValue{0, 1}
+	exp.Ranges = []eval.BucketRange{{Min: 0, Max: 0.6}, {Min: 0.5, Max: 1}}
+
+	report, err := Experiment(exp, SliceIds([]string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"}))
+	require.Nil(t, err)
+	require.Equal(t, 10, report.Total)
+	require.InDeltaSlice(t, []float64{0.6 / 1.1, 0.5 / 1.1}, report.Expected, 1e-9)
+
+	_, err = Experiment(eval.NewExperiment("empty"), SliceIds(nil))
+	require.NotNil(t, err)
+}
+
+func TestChiSquarePValue(t *testing.T) {
+	require.InDelta(t, 0.05, chiSquarePValue(3.841459, 1), 1e-6)
+	require.InDelta(t, 0.05, chiSquarePValue(5.991465, 2), 1e-6)
+	require.InDelta(t, 0.01, chiSquarePValue(11.344867, 3), 1e-6)
+	require.InDelta(t, 0.5, chiSquarePValue(0.454936, 1), 1e-6)
+	require.Equal(t, 1.0, chiSquarePValue(0, 1))
+	require.Equal(t, 0.0, chiSquarePValue(math.Inf(1), 1))
+}
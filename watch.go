@@ -0,0 +1,53 @@
+package growthbook
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/growthbook/growthbook-golang/eval"
+)
+
+// WatchFeature evaluates the feature for the attributes and sends the result into the
+// returned channel, then sends a new result every time a payload update changes the
+// feature value. Client attributes are used if attrs is nil. Watching evaluations don't
+// call tracking callbacks. The channel is closed when ctx is done or stop is called.
+// Results the receiver hasn't read yet are replaced by newer ones.
+func (client *Client) WatchFeature(ctx context.Context, key string, attrs Attributes) (<-chan FeatureResult, func()) {
+	ctx, stop := context.WithCancel(ctx)
+	ch := make(chan FeatureResult, 1)
+	watcher := client
+	if attrs != nil {
+		var err error
+		watcher, err = client.WithAttributes(attrs)
+		if err != nil {
+			client.logger.Error("Error watching feature", "key", key, "error", err)
+			stop()
+			close(ch)
+			return ch, stop
+		}
+	}
+
+	client.data.spawn(func() {
+		defer close(ch)
+		var last *FeatureResult
+		for {
+			updated := client.data.updated()
+			res := eval.New(ctx, watcher.evalOptions(ctx)).EvalFeature(key)
+			if last == nil || !reflect.DeepEqual(last.Value, res.Value) {
+				last = res
+				// Drop unread result, receiver needs the latest one only
+				select {
+				case <-ch:
+				default:
+				}
+				ch <- *res
+			}
+			select {
+			case <-updated:
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+	return ch, stop
+}
@@ -0,0 +1,44 @@
+package growthbook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientWatchFeature(t *testing.T) {
+	ctx := context.TODO()
+	client, err := NewClient(ctx,
+		WithJsonFeatures(`{"flag": {"defaultValue": false, "rules": [{"condition": {"id": "1"}, "force": true}]}}`))
+	require.Nil(t, err)
+
+	ch, stop := client.WatchFeature(ctx, "flag", Attributes{"id": "1"})
+	next := func() FeatureResult {
+		select {
+		case res := <-ch:
+			return res
+		case <-time.After(time.Second):
+			t.Fatal("no result")
+			return FeatureResult{}
+		}
+	}
+	require.Equal(t, true, next().Value)
+
+	// Update that doesn't change the value for the attributes
+	client.SetFeature("other", NewFeature(1))
+	client.SetFeature("flag", &Feature{DefaultValue: true})
+	select {
+	case res := <-ch:
+		t.Fatalf("unexpected result %v", res.Value)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	client.SetFeature("flag", &Feature{DefaultValue: "off"})
+	require.Equal(t, "off", next().Value)
+
+	stop()
+	_, ok := <-ch
+	require.False(t, ok)
+}
@@ -0,0 +1,135 @@
+package growthbook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+)
+
+const (
+	webhookMaxAttempts  = 3
+	webhookRetryDelay   = 500 * time.Millisecond
+	webhookTimeout      = 10 * time.Second
+	webhookSignatureHdr = "X-GrowthBook-Signature"
+)
+
+// FeaturesDiff summarizes payload change sent to the change webhook.
+type FeaturesDiff struct {
+	ClientKey   string    `json:"clientKey"`
+	DateUpdated time.Time `json:"dateUpdated"`
+	Added       []string  `json:"added"`
+	Removed     []string  `json:"removed"`
+	Changed     []string  `json:"changed"`
+	// Features depending on the added, removed or changed ones through prerequisites
+	Affected []string `json:"affected"`
+}
+
+func (d *FeaturesDiff) empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+type changeWebhook struct {
+	url    string
+	secret []byte
+	// Cancelled by [Client.Close] to stop pending requests
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// WithChangeWebhook sets URL that receives [FeaturesDiff] in JSON POST request every time the data
+// source loads payload with added, removed or changed features. Requests are signed with HMAC-SHA256
+// of the body using secret, hex-encoded in X-GrowthBook-Signature header as "sha256=<signature>".
+// Failed requests are retried twice in background, until the client is closed.
+func WithChangeWebhook(url string, secret []byte) ClientOption {
+	return func(c *Client) error {
+		ctx, cancel := context.WithCancel(context.Background())
+		c.webhook = &changeWebhook{url, secret, ctx, cancel}
+		return nil
+	}
+}
+
+// diffFeatures returns sorted keys of added, removed and changed features.
+func diffFeatures(old FeatureMap, new FeatureMap) (added []string, removed []string, changed []string) {
+	for key, f := range new {
+		oldF, ok := old[key]
+		if !ok {
+			added = append(added, key)
+			continue
+		}
+		oldJSON, _ := json.Marshal(oldF)
+		newJSON, _ := json.Marshal(f)
+		if !bytes.Equal(oldJSON, newJSON) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range old {
+		if _, ok := new[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+	slices.Sort(added)
+	slices.Sort(removed)
+	slices.Sort(changed)
+	return added, removed, changed
+}
+
+func (w *changeWebhook) notify(client *Client, diff *FeaturesDiff) {
+	httpClient := client.data.getHttpClient()
+
+	body, err := json.Marshal(diff)
+	if err != nil {
+		client.logger.Error("Error encoding change webhook payload", "error", err)
+		return
+	}
+	client.data.spawn(func() {
+		for attempt := 1; ; attempt++ {
+			err := w.send(httpClient, body)
+			if err == nil || w.ctx.Err() != nil {
+				return
+			}
+			if attempt == webhookMaxAttempts {
+				client.logger.Error("Error sending change webhook", "url", w.url, "error", err)
+				return
+			}
+			client.logger.Warn("Error sending change webhook, retrying", "url", w.url, "attempt", attempt, "error", err)
+			timer := time.NewTimer(webhookRetryDelay * time.Duration(attempt))
+			select {
+			case <-timer.C:
+			case <-w.ctx.Done():
+				timer.Stop()
+				return
+			}
+		}
+	})
+}
+
+func (w *changeWebhook) send(httpClient *http.Client, body []byte) error {
+	ctx, cancel := context.WithTimeout(w.ctx, webhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	mac := hmac.New(sha256.New, w.secret)
+	mac.Write(body)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(webhookSignatureHdr, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("Webhook responded with code: %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package growthbook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeWebhook(t *testing.T) {
+	secret := []byte("secret")
+	var attempts atomic.Int32
+	received := make(chan FeaturesDiff, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.Nil(t, err)
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(body)
+		require.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), r.Header.Get("X-GrowthBook-Signature"))
+
+		var diff FeaturesDiff
+		require.Nil(t, json.Unmarshal(body, &diff))
+		received <- diff
+	}))
+	defer ts.Close()
+
+	logger, _ := testLogger(slog.LevelError, t)
+	client, err := NewClient(context.TODO(),
+		WithLogger(logger),
+		WithClientKey("somekey"),
+		WithHttpClient(ts.Client()),
+		WithChangeWebhook(ts.URL, secret),
+	)
+	require.Nil(t, err)
+
+	require.Nil(t, client.UpdateFromApiResponseJSON(`{"features": {"a": {"defaultValue": 1}, "b": {"defaultValue": 1}, "c": {"defaultValue": 1}}}`))
+	require.Nil(t, client.UpdateFromApiResponseJSON(`{"features": {"a": {"defaultValue": 1}, "b": {"defaultValue": 2}, "d": {"defaultValue": 1}}}`))
+
+	select {
+	case diff := <-received:
+		require.Equal(t, "somekey", diff.ClientKey)
+		require.Equal(t, []string{"d"}, diff.Added)
+		require.Equal(t, []string{"c"}, diff.Removed)
+		require.Equal(t, []string{"b"}, diff.Changed)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Webhook was not called")
+	}
+	require.Equal(t, int32(2), attempts.Load())
+
+	require.Nil(t, client.UpdateFromApiResponseJSON(`{"features": {"a": {"defaultValue": 1}, "b": {"defaultValue": 2}, "d": {"defaultValue": 1}}}`))
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, int32(2), attempts.Load())
+}
+
+func TestChangeWebhookStopsRetriesOnClose(t *testing.T) {
+	var attempts atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	logger, _ := testLogger(slog.LevelError, t)
+	client, err := NewClient(context.TODO(),
+		WithLogger(logger),
+		WithHttpClient(ts.Client()),
+		WithChangeWebhook(ts.URL, []byte("secret")),
+	)
+	require.Nil(t, err)
+
+	require.Nil(t, client.UpdateFromApiResponseJSON(`{"features": {"a": {"defaultValue": 1}}}`))
+	require.Nil(t, client.UpdateFromApiResponseJSON(`{"features": {"a": {"defaultValue": 2}}}`))
+	require.Eventually(t, func() bool { return attempts.Load() == 1 }, time.Second, time.Millisecond)
+	require.Nil(t, client.Close())
+	require.Eventually(t, func() bool { return client.Stats().Goroutines == 0 }, time.Second, time.Millisecond)
+	time.Sleep(webhookRetryDelay + 100*time.Millisecond)
+	require.Equal(t, int32(1), attempts.Load())
+}